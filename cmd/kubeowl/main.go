@@ -1,33 +1,200 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"kubeowl/internal/archive"
+	"kubeowl/internal/demo"
 	"kubeowl/internal/handlers"
 	"kubeowl/internal/k8s"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/policy"
 	"kubeowl/internal/services"
+	"kubeowl/internal/snapshot"
 	"kubeowl/internal/watchers"
 	"kubeowl/internal/websocket"
 )
 
 func main() {
-	if err := k8s.InitClient(); err != nil {
-		log.Printf("Aviso: Falha ao inicializar completamente o cliente K8s: %v", err)
+	quotaThreshold := flag.Float64("quota-threshold", services.DefaultQuotaAlertThreshold, "Percentual de uso a partir do qual um ResourceQuota é sinalizado na visão geral")
+	allowSecretReveal := flag.Bool("allow-secret-reveal", false, "Permite revelar os valores de Secrets via API a usuários autenticados pelo proxy (cada revelação é auditada no log)")
+	authUserHeader := flag.String("auth-user-header", "", "Cabeçalho em que o proxy de autenticação informa o usuário autenticado (ex.: X-Forwarded-User); vazio ignora cabeçalhos de identidade")
+	nodeRoleMapping := flag.String("node-role-mapping", "", "Mapeamentos de rótulos para papéis de nós, no formato rotulo[=valor]:papel separados por vírgula")
+	nodePoolLabel := flag.String("node-pool-label", "", "Rótulo adicional usado para agrupar os nós por pool")
+	policyDisable := flag.String("policy-disable", "", "Regras de boas práticas desabilitadas, separadas por vírgula (ex.: probes,latest-image)")
+	policyExemptions := flag.String("policy-exemptions", "", "Namespaces isentos das regras de boas práticas, no formato namespace[:regra] separados por vírgula")
+	corsOrigins := flag.String("cors-origins", "", "Origens liberadas pelo CORS, separadas por vírgula (\"*\" libera todas)")
+	requestTimeout := flag.Duration("request-timeout", handlers.DefaultRequestTimeout, "Tempo máximo de uma requisição da API (0 desabilita)")
+	eventArchivePath := flag.String("event-archive", "", "Arquivo onde os eventos do cluster são guardados além do TTL do Kubernetes (vazio desabilita)")
+	eventArchiveRetention := flag.Duration("event-archive-retention", archive.DefaultRetention, "Por quanto tempo os eventos arquivados são mantidos")
+	eventArchiveMaxEvents := flag.Int("event-archive-max-events", archive.DefaultMaxEvents, "Quantidade máxima de eventos arquivados")
+	logLevel := flag.String("log-level", "info", "Nível de log: debug, info, warn ou error")
+	logFormat := flag.String("log-format", logging.FormatText, "Formato do log: text ou json")
+	snapshotPath := flag.String("snapshot", "", "Serve o painel offline a partir de um snapshot .tar.gz, sem acessar o cluster")
+	snapshotCapture := flag.String("snapshot-capture", "", "Captura um snapshot .tar.gz do cluster no caminho informado e encerra")
+	usageInterval := flag.Duration("usage-interval", services.DefaultUsageInterval, "Intervalo entre as coletas de uso dos contêineres para as recomendações de dimensionamento (0 desabilita)")
+	usageWindow := flag.Duration("usage-window", services.DefaultUsageWindow, "Janela do histórico de uso usado nas recomendações de dimensionamento")
+	demoMode := flag.Bool("demo", false, "Serve um cluster simulado, sem acessar um cluster real")
+	demoInterval := flag.Duration("demo-interval", demo.DefaultInterval, "Intervalo entre as alterações do cluster simulado")
+	flag.Parse()
+
+	if err := logging.Setup(os.Stderr, *logLevel, *logFormat); err != nil {
+		fatal("configuração de log inválida", err)
+	}
+
+	if *allowSecretReveal && *authUserHeader == "" {
+		fatal("flag -allow-secret-reveal inválida", errors.New("a revelação de Secrets exige -auth-user-header"))
+	}
+
+	roleMappings, err := services.ParseNodeRoleMappings(*nodeRoleMapping)
+	if err != nil {
+		fatal("flag -node-role-mapping inválida", err)
+	}
+	// As flags de política são validadas contra as regras registradas no Linter.
+	rules := policy.New()
+	disabledRules, err := rules.ParseRuleIDs(*policyDisable)
+	if err != nil {
+		fatal("flag -policy-disable inválida", err)
+	}
+	exemptions, err := rules.ParseExemptions(*policyExemptions)
+	if err != nil {
+		fatal("flag -policy-exemptions inválida", err)
+	}
+
+	var nodeStats services.NodeStatsFunc
+	switch {
+	case *demoMode:
+		cluster, err := demo.New(demo.WithInterval(*demoInterval))
+		if err != nil {
+			fatal("falha ao gerar o cluster de demonstração", err)
+		}
+		nodeStats = useClients(cluster.Clients)
+		go cluster.Run(context.Background())
+		slog.Info("modo de demonstração: servindo um cluster simulado", "interval", demoInterval.String())
+	case *snapshotPath != "":
+		nodeStats, err = loadSnapshot(*snapshotPath)
+		if err != nil {
+			fatal("falha ao carregar o snapshot", err)
+		}
+	default:
+		if err := k8s.InitClient(); err != nil {
+			slog.Warn("falha ao inicializar completamente o cliente K8s", logging.Err(err))
+		}
+	}
+
+	if *snapshotCapture != "" {
+		if err := captureSnapshot(*snapshotCapture); err != nil {
+			fatal("falha ao capturar o snapshot", err)
+		}
+		return
 	}
 
 	hub := websocket.NewHub()
 	go hub.Run()
 
-	go watchers.Start(hub)
+	var eventArchive *archive.Store
+	var recorder watchers.EventRecorder
+	if *eventArchivePath != "" {
+		eventArchive, err = archive.Open(*eventArchivePath,
+			archive.WithRetention(*eventArchiveRetention),
+			archive.WithMaxEvents(*eventArchiveMaxEvents),
+			archive.WithExcludedNamespaces(services.IsSystemNamespace),
+		)
+		if err != nil {
+			fatal("falha ao abrir o arquivo de eventos", err)
+		}
+		defer eventArchive.Close()
+		go eventArchive.RunRetention(context.Background(), time.Hour)
+		recorder = eventArchive
+		slog.Info("arquivo de eventos habilitado", "path", *eventArchivePath, "retention", eventArchiveRetention.String())
+	}
+
+	go watchers.Start(hub, recorder)
 
-	k8sService := services.NewK8sService(k8s.Clientset, k8s.MetricsClientset)
+	serviceOptions := []services.Option{
+		services.WithDynamicClient(k8s.DynamicClient),
+		services.WithQuotaAlertThreshold(*quotaThreshold),
+		services.WithNodeRoleMappings(roleMappings),
+		services.WithNodePoolLabel(*nodePoolLabel),
+		services.WithPolicyLinter(policy.New(policy.WithDisabledRules(disabledRules...), policy.WithExemptions(exemptions...))),
+	}
+	if nodeStats != nil {
+		serviceOptions = append(serviceOptions, services.WithNodeStatsFunc(nodeStats))
+	}
+	if *usageInterval > 0 && k8s.Clientset != nil && k8s.MetricsClientset != nil {
+		usageHistory := services.NewUsageHistory(services.WithUsageWindow(*usageWindow))
+		go usageHistory.Run(context.Background(), k8s.Clientset, k8s.MetricsClientset, *usageInterval)
+		serviceOptions = append(serviceOptions, services.WithUsageHistory(usageHistory))
+	}
+	k8sService := services.NewLoggingService(services.NewK8sService(k8s.Clientset, k8s.MetricsClientset, serviceOptions...))
 
 	router := handlers.NewRouter(hub, k8sService)
-	router.RegisterRoutes()
+	router.AllowSecretReveal = *allowSecretReveal
+	router.AuthUserHeader = *authUserHeader
+	router.EventArchive = eventArchive
+	router.RequestTimeout = *requestTimeout
+	if *corsOrigins != "" {
+		router.AllowedOrigins = strings.Split(*corsOrigins, ",")
+	}
+
+	server := &http.Server{
+		Addr:              ":8080",
+		Handler:           router.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	slog.Info("iniciando o servidor KubeOwl", "addr", server.Addr)
+	if err := server.ListenAndServe(); err != nil {
+		fatal("falha ao iniciar o servidor", err)
+	}
+}
 
-	log.Println("Iniciando o servidor KubeOwl na porta :8080...")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		log.Fatalf("Falha ao iniciar o servidor: %v", err)
+// loadSnapshot passa a servir o cluster a partir do snapshot gravado.
+func loadSnapshot(path string) (services.NodeStatsFunc, error) {
+	snap, err := snapshot.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := snap.Clients()
+	if err != nil {
+		return nil, err
 	}
+	slog.Info("modo offline: servindo o snapshot", "path", path, "capturedAt", snap.CapturedAt, "resources", len(snap.Resources))
+	return useClients(clients), nil
+}
+
+// useClients substitui os clientes do cluster pelos clientes falsos, usados também pelos watchers.
+func useClients(clients *snapshot.Clients) services.NodeStatsFunc {
+	k8s.Clientset = clients.Clientset
+	k8s.DynamicClient = clients.Dynamic
+	k8s.MetricsClientset = clients.Metrics
+	return clients.NodeStats
+}
+
+// captureSnapshot grava um snapshot do cluster conectado.
+func captureSnapshot(path string) error {
+	if k8s.Clientset == nil || k8s.DynamicClient == nil || k8s.MetricsClientset == nil {
+		return errors.New("cliente do cluster não inicializado")
+	}
+	snap, err := snapshot.Capture(context.Background(), k8s.Clientset, k8s.DynamicClient, k8s.MetricsClientset)
+	if err != nil {
+		return err
+	}
+	if err := snap.WriteFile(path); err != nil {
+		return err
+	}
+	slog.Info("snapshot capturado", "path", path, "resources", len(snap.Resources), "nodeStats", len(snap.NodeStats))
+	return nil
+}
+
+// fatal registra o erro e encerra o processo.
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Err(err))
+	os.Exit(1)
 }
@@ -0,0 +1,323 @@
+// Package archive mantém um histórico local dos eventos do cluster, que o Kubernetes descarta após cerca de uma hora.
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+// errClosed indica uma gravação após o fechamento do Store.
+var errClosed = errors.New("arquivo de eventos fechado")
+
+const (
+	// DefaultRetention é por quanto tempo os eventos ficam no arquivo.
+	DefaultRetention = 7 * 24 * time.Hour
+	// DefaultMaxEvents limita a quantidade de eventos guardados; os mais antigos são descartados primeiro.
+	DefaultMaxEvents = 100000
+)
+
+// record é uma linha do arquivo: o evento convertido, identificado pelo UID do objeto Event.
+// Atualizações do mesmo evento (ex.: contagem incrementada) geram novas linhas com o mesmo UID.
+type record struct {
+	UID      string           `json:"uid"`
+	Event    models.EventInfo `json:"event"`
+	lastSeen time.Time
+}
+
+// Query descreve uma consulta ao histórico: os filtros de eventos e o fim do período. A paginação, a ordenação
+// e a busca textual ficam a cargo da consulta comum das listagens (ver internal/listquery).
+type Query struct {
+	eventinfo.Filter
+	Until time.Time
+}
+
+// Store guarda os eventos em um arquivo NDJSON somente de acréscimo, com um índice em memória.
+// O arquivo é compactado quando acumula linhas obsoletas e a cada aplicação da retenção.
+type Store struct {
+	mu        sync.RWMutex
+	path      string
+	file      *os.File
+	records   map[string]*record
+	lines     int
+	retention time.Duration
+	maxEvents int
+	excluded  func(namespace string) bool
+	now       func() time.Time
+	rename    func(oldpath, newpath string) error
+}
+
+// Option personaliza o Store.
+type Option func(*Store)
+
+// WithRetention define por quanto tempo, a partir da última ocorrência, um evento é mantido.
+func WithRetention(retention time.Duration) Option {
+	return func(s *Store) {
+		if retention > 0 {
+			s.retention = retention
+		}
+	}
+}
+
+// WithMaxEvents define a quantidade máxima de eventos guardados.
+func WithMaxEvents(maxEvents int) Option {
+	return func(s *Store) {
+		if maxEvents > 0 {
+			s.maxEvents = maxEvents
+		}
+	}
+}
+
+// WithExcludedNamespaces define os namespaces cujos eventos não são guardados, como os do sistema, que o
+// dashboard não exibe.
+func WithExcludedNamespaces(excluded func(namespace string) bool) Option {
+	return func(s *Store) {
+		s.excluded = excluded
+	}
+}
+
+// Open carrega o arquivo informado (criando-o se necessário), aplica a retenção e o prepara para novas gravações.
+// Linhas corrompidas, como uma gravação interrompida, são ignoradas.
+func Open(path string, opts ...Option) (*Store, error) {
+	s := &Store{
+		path:      path,
+		records:   map[string]*record{},
+		retention: DefaultRetention,
+		maxEvents: DefaultMaxEvents,
+		now:       time.Now,
+		rename:    os.Rename,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("falha ao criar o diretório do arquivo de eventos: %w", err)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	if err := s.compactLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) load() error {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("falha ao abrir o arquivo de eventos: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	skipped := 0
+	for scanner.Scan() {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.UID == "" {
+			skipped++
+			continue
+		}
+		r.lastSeen, _ = time.Parse(time.RFC3339, r.Event.LastSeen)
+		s.records[r.UID] = &r
+	}
+	if skipped > 0 {
+		slog.Warn("linhas inválidas ignoradas no arquivo de eventos", "skipped", skipped, "path", s.path)
+	}
+	return scanner.Err()
+}
+
+// Record grava ou atualiza um evento do cluster, lido da API events.k8s.io/v1.
+func (s *Store) Record(event *eventsv1.Event) error {
+	if event == nil || event.UID == "" || (event.Namespace != "" && s.excluded != nil && s.excluded(event.Namespace)) {
+		return nil
+	}
+	info, firstSeen, lastSeen := eventinfo.FromEvent(*event)
+	info.Timestamp = lastSeen.Format(time.RFC822)
+	info.FirstSeen = firstSeen.Format(time.RFC3339)
+	info.LastSeen = lastSeen.Format(time.RFC3339)
+	r := &record{UID: string(event.UID), Event: info, lastSeen: lastSeen}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return errClosed
+	}
+	if existing, ok := s.records[r.UID]; ok && existing.Event == info {
+		return nil
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("falha ao gravar no arquivo de eventos: %w", err)
+	}
+	s.records[r.UID] = r
+	s.lines++
+
+	if len(s.records) > s.maxEvents {
+		s.pruneLocked()
+	}
+	// Compacta quando a maior parte das linhas é de versões antigas de eventos já atualizados.
+	if s.lines > 2*len(s.records)+1000 {
+		return s.compactLocked()
+	}
+	return nil
+}
+
+// Prune remove os eventos fora da retenção e compacta o arquivo.
+func (s *Store) Prune() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+	return s.compactLocked()
+}
+
+// RunRetention aplica a retenção periodicamente até o contexto ser cancelado.
+func (s *Store) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Prune(); err != nil {
+				slog.Error("erro ao aplicar a retenção do arquivo de eventos", logging.Err(err))
+			}
+		}
+	}
+}
+
+// pruneLocked descarta os eventos mais antigos que a retenção e, acima do limite, os de última ocorrência mais antiga.
+func (s *Store) pruneLocked() {
+	cutoff := s.now().Add(-s.retention)
+	for uid, r := range s.records {
+		if r.lastSeen.Before(cutoff) {
+			delete(s.records, uid)
+		}
+	}
+	if len(s.records) <= s.maxEvents {
+		return
+	}
+	sorted := s.sortedLocked()
+	for _, r := range sorted[s.maxEvents:] {
+		delete(s.records, r.UID)
+	}
+}
+
+// compactLocked reescreve o arquivo com uma linha por evento e o reabre para acréscimos.
+func (s *Store) compactLocked() error {
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("falha ao compactar o arquivo de eventos: %w", err)
+	}
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, r := range s.sortedLocked() {
+		if err := encoder.Encode(r); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if s.file != nil {
+		s.file.Close()
+	}
+	if err := s.rename(tmpPath, s.path); err != nil {
+		// O arquivo original continua válido: volta a acrescentar nele, e a compactação é tentada de novo depois.
+		os.Remove(tmpPath)
+		return errors.Join(fmt.Errorf("falha ao compactar o arquivo de eventos: %w", err), s.reopenLocked())
+	}
+	if err := s.reopenLocked(); err != nil {
+		return err
+	}
+	s.lines = len(s.records)
+	return nil
+}
+
+// reopenLocked abre o arquivo para acréscimos. Em caso de falha, o Store fica fechado e as gravações
+// seguintes retornam errClosed.
+func (s *Store) reopenLocked() error {
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		s.file = nil
+		return fmt.Errorf("falha ao reabrir o arquivo de eventos: %w", err)
+	}
+	s.file = file
+	return nil
+}
+
+// sortedLocked retorna os eventos do mais recente para o mais antigo.
+func (s *Store) sortedLocked() []*record {
+	sorted := make([]*record, 0, len(s.records))
+	for _, r := range s.records {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].lastSeen.Equal(sorted[j].lastSeen) {
+			return sorted[i].UID < sorted[j].UID
+		}
+		return sorted[i].lastSeen.After(sorted[j].lastSeen)
+	})
+	return sorted
+}
+
+// Query consulta o histórico, do evento mais recente para o mais antigo.
+func (s *Store) Query(q Query) []models.EventInfo {
+	events := []models.EventInfo{}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.sortedLocked() {
+		if !q.Matches(r.Event) {
+			continue
+		}
+		if !q.Since.IsZero() && r.lastSeen.Before(q.Since) {
+			continue
+		}
+		if !q.Until.IsZero() && r.lastSeen.After(q.Until) {
+			continue
+		}
+		events = append(events, r.Event)
+	}
+	return events
+}
+
+// Close fecha o arquivo.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,138 @@
+package archive
+
+import (
+	"errors"
+	"io"
+	"kubeowl/internal/eventinfo"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TestMain silencia a saída de log durante os testes deste pacote.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func testEvent(uid, namespace, eventType, reason, message string, lastSeen time.Time, count int32) *eventsv1.Event {
+	return &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: uid, Namespace: namespace, UID: types.UID(uid)},
+		Regarding:  v1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: "web-0"},
+		Type:       eventType,
+		Reason:     reason,
+		Note:       message,
+		EventTime:  metav1.NewMicroTime(lastSeen.Add(-time.Minute)),
+		Series:     &eventsv1.EventSeries{Count: count, LastObservedTime: metav1.NewMicroTime(lastSeen)},
+	}
+}
+
+func TestStore_RecordAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "archive.ndjson")
+	store, err := Open(path, WithExcludedNamespaces(func(namespace string) bool { return namespace == "kube-system" }))
+	if err != nil {
+		t.Fatalf("falha ao abrir o arquivo: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	assert.NoError(t, store.Record(testEvent("e1", "app", "Warning", "BackOff", "Back-off restarting failed container", now.Add(-2*time.Hour), 3)))
+	assert.NoError(t, store.Record(testEvent("e2", "app", "Normal", "Pulled", "Successfully pulled image nginx", now.Add(-time.Hour), 1)))
+	assert.NoError(t, store.Record(testEvent("e3", "kube-system", "Warning", "BackOff", "coredns", now, 1)))
+	// Atualização do mesmo evento substitui a versão anterior.
+	assert.NoError(t, store.Record(testEvent("e1", "app", "Warning", "BackOff", "Back-off restarting failed container", now.Add(-30*time.Minute), 7)))
+
+	events := store.Query(Query{})
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "BackOff", events[0].Reason)
+		assert.Equal(t, int32(7), events[0].Count)
+		assert.Equal(t, "Back-off restarting failed container", events[0].Message)
+		assert.Equal(t, now.Add(-31*time.Minute).Format(time.RFC3339), events[0].FirstSeen)
+		assert.Equal(t, "Pulled", events[1].Reason)
+	}
+
+	assert.Len(t, store.Query(Query{Filter: eventinfo.Filter{Type: "normal"}}), 1)
+	assert.Len(t, store.Query(Query{Until: now.Add(-45 * time.Minute)}), 1)
+	assert.Len(t, store.Query(Query{Filter: eventinfo.Filter{Since: now.Add(-45 * time.Minute)}}), 1)
+
+	assert.NoError(t, store.Close())
+	assert.Error(t, store.Record(testEvent("e4", "app", "Normal", "Created", "", now, 1)))
+
+	// O histórico sobrevive à reabertura, mesmo com uma linha corrompida no fim do arquivo.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("falha ao abrir o arquivo: %v", err)
+	}
+	_, _ = file.WriteString(`{"uid":"e5","event":{"rea`)
+	file.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("falha ao reabrir o arquivo: %v", err)
+	}
+	defer reopened.Close()
+	assert.Len(t, reopened.Query(Query{}), 2)
+}
+
+func TestStore_Retention(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	store, err := Open(filepath.Join(t.TempDir(), "archive.ndjson"), WithRetention(24*time.Hour), WithMaxEvents(2))
+	if err != nil {
+		t.Fatalf("falha ao abrir o arquivo: %v", err)
+	}
+	defer store.Close()
+
+	assert.NoError(t, store.Record(testEvent("old", "app", "Normal", "Pulled", "", now.Add(-48*time.Hour), 1)))
+	assert.NoError(t, store.Record(testEvent("a", "app", "Normal", "Pulled", "", now.Add(-3*time.Hour), 1)))
+	assert.NoError(t, store.Record(testEvent("b", "app", "Normal", "Pulled", "", now.Add(-2*time.Hour), 1)))
+	assert.NoError(t, store.Prune())
+	assert.Len(t, store.Query(Query{}), 2)
+
+	// Acima do limite, os eventos de última ocorrência mais antiga são descartados.
+	assert.NoError(t, store.Record(testEvent("c", "app", "Normal", "Pulled", "", now.Add(-time.Hour), 1)))
+	events := store.Query(Query{})
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, []string{now.Add(-time.Hour).Format(time.RFC3339), now.Add(-2 * time.Hour).Format(time.RFC3339)},
+			[]string{events[0].LastSeen, events[1].LastSeen})
+	}
+
+	// A retenção é aplicada pelo relógio do Store.
+	store.now = func() time.Time { return now.Add(25 * time.Hour) }
+	assert.NoError(t, store.Prune())
+	assert.Empty(t, store.Query(Query{}))
+}
+
+// TestStore_CompactRenameFailure verifica que uma falha ao substituir o arquivo na compactação mantém o Store
+// gravando no arquivo original.
+func TestStore_CompactRenameFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.ndjson")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("falha ao abrir o arquivo: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now().Truncate(time.Second)
+	assert.NoError(t, store.Record(testEvent("a", "app", "Normal", "Pulled", "", now.Add(-time.Hour), 1)))
+	store.rename = func(oldpath, newpath string) error { return errors.New("disco cheio") }
+	assert.Error(t, store.Prune())
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "o arquivo temporário deve ser removido")
+
+	// As gravações seguintes continuam no arquivo original.
+	assert.NoError(t, store.Record(testEvent("b", "app", "Normal", "Pulled", "", now, 1)))
+	assert.NoError(t, store.Close())
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("falha ao reabrir o arquivo: %v", err)
+	}
+	defer reopened.Close()
+	assert.Len(t, reopened.Query(Query{}), 2)
+}
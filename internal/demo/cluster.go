@@ -0,0 +1,788 @@
+// Package demo gera um cluster fictício servido por clientes falsos do client-go, com um simulador que
+// altera os pods continuamente (reinícios, crash loops e escalonamento) para que o painel e o feed ao vivo
+// possam ser usados sem um cluster real.
+package demo
+
+import (
+	"fmt"
+	"kubeowl/internal/snapshot"
+	"math/rand/v2"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// object é um objeto do Kubernetes com metadados.
+type object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// resourceType descreve um tipo de recurso servido pelo cluster simulado.
+type resourceType struct {
+	gvr        schema.GroupVersionResource
+	kind       string
+	namespaced bool
+}
+
+var (
+	namespacesResource      = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	nodesResource           = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	podsResource            = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	servicesResource        = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	configMapsResource      = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secretsResource         = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	coreEventsResource      = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+	quotasResource          = schema.GroupVersionResource{Version: "v1", Resource: "resourcequotas"}
+	limitRangesResource     = schema.GroupVersionResource{Version: "v1", Resource: "limitranges"}
+	pvcsResource            = schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+	pvsResource             = schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}
+	deploymentsResource     = appsv1.SchemeGroupVersion.WithResource("deployments")
+	replicaSetsResource     = appsv1.SchemeGroupVersion.WithResource("replicasets")
+	endpointSlicesResource  = discoveryv1.SchemeGroupVersion.WithResource("endpointslices")
+	eventsResource          = eventsv1.SchemeGroupVersion.WithResource("events")
+	ingressesResource       = networkingv1.SchemeGroupVersion.WithResource("ingresses")
+	ingressClassesResource  = networkingv1.SchemeGroupVersion.WithResource("ingressclasses")
+	storageClassesResource  = storagev1.SchemeGroupVersion.WithResource("storageclasses")
+	nodeMetricsResource     = metricsv1beta1.SchemeGroupVersion.WithResource("nodes")
+	podMetricsResource      = metricsv1beta1.SchemeGroupVersion.WithResource("pods")
+	defaultStorageClassName = "standard"
+)
+
+// resourceTypes são os tipos gerados, na ordem em que aparecem na descoberta.
+var resourceTypes = []resourceType{
+	{namespacesResource, "Namespace", false},
+	{nodesResource, "Node", false},
+	{podsResource, "Pod", true},
+	{servicesResource, "Service", true},
+	{configMapsResource, "ConfigMap", true},
+	{secretsResource, "Secret", true},
+	{coreEventsResource, "Event", true},
+	{quotasResource, "ResourceQuota", true},
+	{limitRangesResource, "LimitRange", true},
+	{pvcsResource, "PersistentVolumeClaim", true},
+	{pvsResource, "PersistentVolume", false},
+	{deploymentsResource, "Deployment", true},
+	{replicaSetsResource, "ReplicaSet", true},
+	{endpointSlicesResource, "EndpointSlice", true},
+	{eventsResource, "Event", true},
+	{ingressesResource, "Ingress", true},
+	{ingressClassesResource, "IngressClass", false},
+	{storageClassesResource, "StorageClass", false},
+}
+
+func lookupResourceType(gvr schema.GroupVersionResource) (resourceType, bool) {
+	for _, rt := range resourceTypes {
+		if rt.gvr == gvr {
+			return rt, true
+		}
+	}
+	return resourceType{}, false
+}
+
+// app descreve uma aplicação do cluster gerado: um Deployment com Service, Ingress e volume opcionais.
+type app struct {
+	namespace string
+	name      string
+	image     string
+	replicas  int32
+	cpu       string
+	memory    string
+	port      int32
+	host      string
+	storage   string
+	secret    bool
+}
+
+var apps = []app{
+	{namespace: "shop", name: "frontend", image: "nginx:1.27", replicas: 3, cpu: "100m", memory: "128Mi", port: 80, host: "shop.demo.local"},
+	{namespace: "shop", name: "catalog", image: "ghcr.io/kubeowl-demo/catalog:2.4.1", replicas: 2, cpu: "250m", memory: "256Mi", port: 8080},
+	{namespace: "shop", name: "cart", image: "redis:7.4", replicas: 1, cpu: "100m", memory: "512Mi", port: 6379, storage: "5Gi"},
+	{namespace: "payments", name: "api", image: "ghcr.io/kubeowl-demo/payments-api:1.9.0", replicas: 2, cpu: "500m", memory: "512Mi", port: 8080, host: "pay.demo.local", secret: true},
+	{namespace: "payments", name: "worker", image: "ghcr.io/kubeowl-demo/payments-worker:1.9.0", replicas: 2, cpu: "250m", memory: "384Mi"},
+	{namespace: "payments", name: "postgres", image: "postgres:16.4", replicas: 1, cpu: "1", memory: "2Gi", port: 5432, storage: "20Gi", secret: true},
+	{namespace: "monitoring", name: "prometheus", image: "prom/prometheus:v2.54.1", replicas: 1, cpu: "500m", memory: "2Gi", port: 9090, storage: "50Gi"},
+	{namespace: "monitoring", name: "grafana", image: "grafana/grafana:11.2.0", replicas: 1, cpu: "100m", memory: "256Mi", port: 3000, host: "grafana.demo.local", secret: true},
+	{namespace: "kube-system", name: "coredns", image: "registry.k8s.io/coredns/coredns:v1.11.3", replicas: 2, cpu: "100m", memory: "70Mi", port: 53},
+}
+
+// nodeSpec descreve um nó do cluster gerado.
+type nodeSpec struct {
+	name   string
+	role   string
+	pool   string
+	cpu    string
+	memory string
+}
+
+var nodeSpecs = []nodeSpec{
+	{name: "control-plane-1", role: "control-plane", pool: "system", cpu: "2", memory: "8Gi"},
+	{name: "worker-1", role: "worker", pool: "general", cpu: "4", memory: "16Gi"},
+	{name: "worker-2", role: "worker", pool: "general", cpu: "4", memory: "16Gi"},
+	{name: "worker-3", role: "worker", pool: "memory-optimized", cpu: "8", memory: "64Gi"},
+}
+
+// nodePoolLabel é um dos rótulos de pool reconhecidos pelo serviço.
+const nodePoolLabel = "node.kubernetes.io/pool"
+
+// generator monta os objetos do cluster gerado.
+type generator struct {
+	rand    *rand.Rand
+	now     time.Time
+	objects map[schema.GroupVersionResource][]object
+	metrics map[string]*metricsv1beta1.PodMetrics
+	nodeIP  map[string]string
+	podSeq  int
+}
+
+func newGenerator(r *rand.Rand, now time.Time) *generator {
+	return &generator{
+		rand:    r,
+		now:     now,
+		objects: make(map[schema.GroupVersionResource][]object),
+		metrics: make(map[string]*metricsv1beta1.PodMetrics),
+		nodeIP:  make(map[string]string),
+	}
+}
+
+func (g *generator) add(gvr schema.GroupVersionResource, obj object) {
+	g.objects[gvr] = append(g.objects[gvr], obj)
+}
+
+// ago retorna um instante no passado, em horas.
+func (g *generator) ago(hours int) metav1.Time {
+	return metav1.NewTime(g.now.Add(-time.Duration(hours) * time.Hour).Truncate(time.Second))
+}
+
+// generate monta o snapshot do cluster gerado.
+func (g *generator) generate() *snapshot.Snapshot {
+	for _, name := range []string{"default", "kube-system", "kube-public", "kube-node-lease", "shop", "payments", "monitoring"} {
+		g.add(namespacesResource, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: g.uid(), CreationTimestamp: g.ago(24 * 30)},
+			Status:     v1.NamespaceStatus{Phase: v1.NamespaceActive},
+		})
+	}
+	for i, spec := range nodeSpecs {
+		g.add(nodesResource, g.node(spec, i))
+	}
+	g.add(storageClassesResource, &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultStorageClassName, UID: g.uid(), CreationTimestamp: g.ago(24 * 30),
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+		Provisioner:       "rancher.io/local-path",
+		ReclaimPolicy:     ptr(v1.PersistentVolumeReclaimDelete),
+		VolumeBindingMode: ptr(storagev1.VolumeBindingWaitForFirstConsumer),
+	})
+	g.add(ingressClassesResource, &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nginx", UID: g.uid(), CreationTimestamp: g.ago(24 * 30),
+			Annotations: map[string]string{"ingressclass.kubernetes.io/is-default-class": "true"},
+		},
+		Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+	})
+	for _, a := range apps {
+		g.app(a)
+	}
+	g.quotas()
+
+	s := &snapshot.Snapshot{CapturedAt: g.now, ServerVersion: "v1.31.0-demo"}
+	discovery := map[string]*metav1.APIResourceList{}
+	var order []string
+	for _, rt := range resourceTypes {
+		gv := rt.gvr.GroupVersion().String()
+		list, ok := discovery[gv]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: gv}
+			discovery[gv] = list
+			order = append(order, gv)
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name: rt.gvr.Resource, Kind: rt.kind, Namespaced: rt.namespaced,
+			Verbs: metav1.Verbs{"create", "delete", "get", "list", "patch", "update", "watch"},
+		})
+		resource := snapshot.Resource{
+			Group: rt.gvr.Group, Version: rt.gvr.Version, Resource: rt.gvr.Resource, Kind: rt.kind, Namespaced: rt.namespaced,
+			Items: []unstructured.Unstructured{},
+		}
+		for _, obj := range g.objects[rt.gvr] {
+			resource.Items = append(resource.Items, *toUnstructured(rt, obj))
+		}
+		s.Resources = append(s.Resources, resource)
+	}
+	for _, gv := range order {
+		s.Discovery = append(s.Discovery, discovery[gv])
+	}
+
+	nodeUsage := map[string]v1.ResourceList{}
+	for _, obj := range g.objects[podsResource] {
+		pod := obj.(*v1.Pod)
+		podMetrics := g.metrics[pod.Namespace+"/"+pod.Name]
+		s.PodMetrics = append(s.PodMetrics, *podMetrics)
+		addUsage(nodeUsage, pod.Spec.NodeName, podMetrics)
+	}
+	for _, spec := range nodeSpecs {
+		s.NodeMetrics = append(s.NodeMetrics, nodeMetrics(spec.name, nodeUsage[spec.name], g.now))
+	}
+	return s
+}
+
+func (g *generator) node(spec nodeSpec, index int) *v1.Node {
+	ip := fmt.Sprintf("10.0.0.%d", 10+index)
+	g.nodeIP[spec.name] = ip
+	capacity := v1.ResourceList{
+		v1.ResourceCPU:              resource.MustParse(spec.cpu),
+		v1.ResourceMemory:           resource.MustParse(spec.memory),
+		v1.ResourcePods:             resource.MustParse("110"),
+		v1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+	}
+	allocatable := capacity.DeepCopy()
+	allocatable[v1.ResourceMemory] = *resource.NewQuantity(capacity.Memory().Value()-512*1024*1024, resource.BinarySI)
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.name, UID: g.uid(), CreationTimestamp: g.ago(24 * 30),
+			Labels: map[string]string{
+				"kubernetes.io/hostname":               spec.name,
+				"kubernetes.io/os":                     "linux",
+				"kubernetes.io/arch":                   "amd64",
+				"node-role.kubernetes.io/" + spec.role: "",
+				nodePoolLabel:                          spec.pool,
+				"topology.kubernetes.io/region":        "demo-1",
+				"topology.kubernetes.io/zone":          fmt.Sprintf("demo-1%c", 'a'+index%3),
+				"node.kubernetes.io/instance-type":     "demo." + spec.cpu + "x" + spec.memory,
+			},
+		},
+		Status: v1.NodeStatus{
+			Capacity:    capacity,
+			Allocatable: allocatable,
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: ip},
+				{Type: v1.NodeHostName, Address: spec.name},
+			},
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady", Message: "kubelet is posting ready status", LastTransitionTime: g.ago(24 * 30)},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse, Reason: "KubeletHasSufficientMemory"},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse, Reason: "KubeletHasNoDiskPressure"},
+				{Type: v1.NodePIDPressure, Status: v1.ConditionFalse, Reason: "KubeletHasSufficientPID"},
+			},
+			NodeInfo: v1.NodeSystemInfo{
+				KubeletVersion:          "v1.31.0",
+				ContainerRuntimeVersion: "containerd://1.7.20",
+				OSImage:                 "Ubuntu 24.04 LTS",
+				KernelVersion:           "6.8.0-45-generic",
+				OperatingSystem:         "linux",
+				Architecture:            "amd64",
+			},
+		},
+	}
+	if spec.role == "control-plane" {
+		node.Spec.Taints = []v1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule}}
+	}
+	return node
+}
+
+// app gera o Deployment, o ReplicaSet e os pods da aplicação, além de Service, EndpointSlice, Ingress,
+// volume, ConfigMap e Secret conforme a descrição.
+func (g *generator) app(a app) {
+	created := g.ago(24 * (3 + g.rand.IntN(20)))
+	labels := map[string]string{"app.kubernetes.io/name": a.name, "app.kubernetes.io/part-of": a.namespace}
+	hash := g.randomString(10)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: a.name, Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created, Labels: labels, Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(a.replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels}, Spec: podSpec(a, "")},
+		},
+	}
+	setDeploymentReplicas(deployment, a.replicas)
+	g.add(deploymentsResource, deployment)
+
+	rsLabels := mergeLabels(labels, map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash})
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: a.name + "-" + hash, Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created, Labels: rsLabels,
+			OwnerReferences: []metav1.OwnerReference{ownerReference("apps/v1", "Deployment", deployment.Name, deployment.UID)},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr(a.replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: rsLabels},
+			Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: rsLabels}, Spec: podSpec(a, "")},
+		},
+	}
+	setReplicaSetReplicas(replicaSet, a.replicas)
+	g.add(replicaSetsResource, replicaSet)
+
+	var pods []*v1.Pod
+	for i := int32(0); i < a.replicas; i++ {
+		pod := g.pod(a, replicaSet, g.ago(g.rand.IntN(72)+1))
+		pods = append(pods, pod)
+		g.add(podsResource, pod)
+		g.metrics[pod.Namespace+"/"+pod.Name] = podMetrics(g.rand, a, pod, g.now)
+		coreEvent, event := newEvent(g.rand, pod, v1.EventTypeNormal, "Scheduled",
+			fmt.Sprintf("Successfully assigned %s/%s to %s", pod.Namespace, pod.Name, pod.Spec.NodeName), "default-scheduler", pod.CreationTimestamp.Time)
+		g.add(coreEventsResource, coreEvent)
+		g.add(eventsResource, event)
+	}
+
+	if a.port > 0 {
+		service := &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: a.name, Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created, Labels: labels},
+			Spec: v1.ServiceSpec{
+				Type:      v1.ServiceTypeClusterIP,
+				ClusterIP: fmt.Sprintf("10.96.%d.%d", g.rand.IntN(250)+1, g.rand.IntN(250)+1),
+				Selector:  labels,
+				Ports:     []v1.ServicePort{{Name: "main", Port: a.port, TargetPort: intstr.FromInt32(a.port), Protocol: v1.ProtocolTCP}},
+			},
+		}
+		g.add(servicesResource, service)
+		g.add(endpointSlicesResource, endpointSlice(service, pods))
+	}
+
+	if a.host != "" {
+		g.add(ingressesResource, &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: a.name, Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr("nginx"),
+				TLS:              []networkingv1.IngressTLS{{Hosts: []string{a.host}, SecretName: a.name + "-tls"}},
+				Rules: []networkingv1.IngressRule{{
+					Host: a.host,
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: ptr(networkingv1.PathTypePrefix),
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+								Name: a.name, Port: networkingv1.ServiceBackendPort{Number: a.port},
+							}},
+						}},
+					}},
+				}},
+			},
+			Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "192.168.49.2"}},
+			}},
+		})
+	}
+
+	if a.storage != "" {
+		g.volume(a, created)
+	}
+
+	g.add(configMapsResource, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: a.name + "-config", Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created, Labels: labels},
+		Data: map[string]string{
+			"LOG_LEVEL":    "info",
+			"APP_NAME":     a.name,
+			"settings.yml": fmt.Sprintf("service: %s\nport: %d\nfeatures:\n  tracing: true\n", a.name, a.port),
+		},
+	})
+	if a.secret {
+		g.add(secretsResource, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: a.name + "-credentials", Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created, Labels: labels},
+			Type:       v1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"username": []byte(a.name),
+				"password": []byte(g.randomString(24)),
+			},
+		})
+	}
+}
+
+// pod gera um pod em execução do ReplicaSet, agendado em um nó de trabalho.
+func (g *generator) pod(a app, replicaSet *appsv1.ReplicaSet, created metav1.Time) *v1.Pod {
+	g.podSeq++
+	workers := nodeSpecs[1:]
+	node := workers[g.rand.IntN(len(workers))].name
+	return newPod(a, replicaSet, replicaSet.Name+"-"+g.randomString(5), g.uid(), node, g.nodeIP[node],
+		fmt.Sprintf("10.244.%d.%d", g.podSeq/250+1, g.podSeq%250+2), created)
+}
+
+func (g *generator) volume(a app, created metav1.Time) {
+	claimName := "data-" + a.name
+	volumeName := "pvc-" + string(g.uid())
+	size := resource.MustParse(a.storage)
+	g.add(pvsResource, &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: volumeName, UID: g.uid(), CreationTimestamp: created},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity:                      v1.ResourceList{v1.ResourceStorage: size},
+			AccessModes:                   []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+			StorageClassName:              defaultStorageClassName,
+			ClaimRef:                      &v1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: a.namespace, Name: claimName},
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				HostPath: &v1.HostPathVolumeSource{Path: "/var/local-path-provisioner/" + volumeName},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	})
+	g.add(pvcsResource, &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: a.namespace, UID: g.uid(), CreationTimestamp: created},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			StorageClassName: ptr(defaultStorageClassName),
+			VolumeName:       volumeName,
+			Resources:        v1.VolumeResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: size}},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:       v1.ClaimBound,
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Capacity:    v1.ResourceList{v1.ResourceStorage: size},
+		},
+	})
+}
+
+// quotas gera os ResourceQuotas e o LimitRange dos namespaces das aplicações, com o uso atual.
+func (g *generator) quotas() {
+	used := map[string]v1.ResourceList{}
+	for _, obj := range g.objects[podsResource] {
+		pod := obj.(*v1.Pod)
+		list, ok := used[pod.Namespace]
+		if !ok {
+			list = v1.ResourceList{}
+			used[pod.Namespace] = list
+		}
+		for _, c := range pod.Spec.Containers {
+			addQuantity(list, v1.ResourceRequestsCPU, c.Resources.Requests[v1.ResourceCPU])
+			addQuantity(list, v1.ResourceRequestsMemory, c.Resources.Requests[v1.ResourceMemory])
+		}
+		addQuantity(list, v1.ResourcePods, resource.MustParse("1"))
+	}
+	limits := map[string]v1.ResourceList{
+		"shop": {
+			v1.ResourceRequestsCPU:    resource.MustParse("2"),
+			v1.ResourceRequestsMemory: resource.MustParse("3Gi"),
+			v1.ResourcePods:           resource.MustParse("12"),
+		},
+		"payments": {
+			v1.ResourceRequestsCPU:    resource.MustParse("3"),
+			v1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+			v1.ResourcePods:           resource.MustParse("10"),
+		},
+	}
+	for _, namespace := range []string{"shop", "payments"} {
+		status := v1.ResourceList{}
+		for name := range limits[namespace] {
+			quantity, ok := used[namespace][name]
+			if !ok {
+				quantity = resource.MustParse("0")
+			}
+			status[name] = quantity
+		}
+		g.add(quotasResource, &v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: namespace, UID: g.uid(), CreationTimestamp: g.ago(24 * 20)},
+			Spec:       v1.ResourceQuotaSpec{Hard: limits[namespace]},
+			Status:     v1.ResourceQuotaStatus{Hard: limits[namespace], Used: status},
+		})
+	}
+	g.add(limitRangesResource, &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "payments", UID: g.uid(), CreationTimestamp: g.ago(24 * 20)},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+			Type:           v1.LimitTypeContainer,
+			Default:        v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("512Mi")},
+			DefaultRequest: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+			Max:            v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("4Gi")},
+		}}},
+	})
+}
+
+func (g *generator) uid() types.UID {
+	return newUID(g.rand)
+}
+
+func (g *generator) randomString(n int) string {
+	return randomString(g.rand, n)
+}
+
+// newPod monta um pod em execução da aplicação.
+func newPod(a app, replicaSet *appsv1.ReplicaSet, name string, uid types.UID, node, hostIP, podIP string, created metav1.Time) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: a.namespace, UID: uid, CreationTimestamp: created,
+			Labels:          mergeLabels(replicaSet.Spec.Template.Labels, nil),
+			OwnerReferences: []metav1.OwnerReference{ownerReference("apps/v1", "ReplicaSet", replicaSet.Name, replicaSet.UID)},
+		},
+		Spec: podSpec(a, node),
+		Status: v1.PodStatus{
+			Phase:     v1.PodRunning,
+			HostIP:    hostIP,
+			PodIP:     podIP,
+			StartTime: &created,
+			QOSClass:  v1.PodQOSBurstable,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: created},
+				{Type: v1.PodInitialized, Status: v1.ConditionTrue, LastTransitionTime: created},
+				{Type: v1.ContainersReady, Status: v1.ConditionTrue, LastTransitionTime: created},
+				{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: created},
+			},
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:    a.name,
+				Image:   a.image,
+				ImageID: a.image,
+				Ready:   true,
+				Started: ptr(true),
+				State:   v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: created}},
+			}},
+		},
+	}
+}
+
+func podSpec(a app, node string) v1.PodSpec {
+	request := v1.ResourceList{v1.ResourceCPU: resource.MustParse(a.cpu), v1.ResourceMemory: resource.MustParse(a.memory)}
+	limit := v1.ResourceList{v1.ResourceMemory: resource.MustParse(a.memory)}
+	limit[v1.ResourceMemory] = *resource.NewQuantity(2*limit.Memory().Value(), resource.BinarySI)
+	container := v1.Container{
+		Name:      a.name,
+		Image:     a.image,
+		Resources: v1.ResourceRequirements{Requests: request, Limits: limit},
+		EnvFrom: []v1.EnvFromSource{{
+			ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: a.name + "-config"}},
+		}},
+	}
+	if a.port > 0 {
+		container.Ports = []v1.ContainerPort{{Name: "main", ContainerPort: a.port, Protocol: v1.ProtocolTCP}}
+	}
+	if a.secret {
+		container.EnvFrom = append(container.EnvFrom, v1.EnvFromSource{
+			SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: a.name + "-credentials"}},
+		})
+	}
+	spec := v1.PodSpec{NodeName: node, Containers: []v1.Container{container}, RestartPolicy: v1.RestartPolicyAlways}
+	if a.storage != "" {
+		container := &spec.Containers[0]
+		container.VolumeMounts = []v1.VolumeMount{{Name: "data", MountPath: "/data"}}
+		spec.Volumes = []v1.Volume{{
+			Name: "data",
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "data-" + a.name,
+			}},
+		}}
+	}
+	return spec
+}
+
+// endpointSlice monta o EndpointSlice do Service com os pods informados.
+func endpointSlice(service *v1.Service, pods []*v1.Pod) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: service.Name + "-demo", Namespace: service.Namespace, UID: service.UID + "-slice",
+			CreationTimestamp: service.CreationTimestamp,
+			Labels:            map[string]string{discoveryv1.LabelServiceName: service.Name},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{},
+		Ports: []discoveryv1.EndpointPort{{
+			Name: ptr("main"), Port: ptr(service.Spec.Ports[0].Port), Protocol: ptr(v1.ProtocolTCP),
+		}},
+	}
+	for _, pod := range pods {
+		ready := isPodReady(pod)
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{pod.Status.PodIP},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr(ready)},
+			NodeName:   ptr(pod.Spec.NodeName),
+			TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID},
+		})
+	}
+	return slice
+}
+
+// podMetrics gera o uso de um pod entre 20% e 90% do que ele requisita.
+func podMetrics(r *rand.Rand, a app, pod *v1.Pod, now time.Time) *metricsv1beta1.PodMetrics {
+	cpu := resource.MustParse(a.cpu)
+	memory := resource.MustParse(a.memory)
+	fraction := 0.2 + 0.7*r.Float64()
+	return &metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace, Labels: pod.Labels},
+		Timestamp:  metav1.NewTime(now),
+		Window:     metav1.Duration{Duration: 30 * time.Second},
+		Containers: []metricsv1beta1.ContainerMetrics{{
+			Name: a.name,
+			Usage: v1.ResourceList{
+				v1.ResourceCPU:    *resource.NewMilliQuantity(int64(float64(cpu.MilliValue())*fraction), resource.DecimalSI),
+				v1.ResourceMemory: *resource.NewQuantity(int64(float64(memory.Value())*fraction), resource.BinarySI),
+			},
+		}},
+	}
+}
+
+// systemUsage é o consumo atribuído ao sistema operacional e ao kubelet de cada nó.
+var systemUsage = v1.ResourceList{v1.ResourceCPU: resource.MustParse("150m"), v1.ResourceMemory: resource.MustParse("900Mi")}
+
+func nodeMetrics(name string, usage v1.ResourceList, now time.Time) metricsv1beta1.NodeMetrics {
+	total := systemUsage.DeepCopy()
+	for resourceName, quantity := range usage {
+		addQuantity(total, resourceName, quantity)
+	}
+	return metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Timestamp:  metav1.NewTime(now),
+		Window:     metav1.Duration{Duration: 30 * time.Second},
+		Usage:      total,
+	}
+}
+
+func addUsage(usage map[string]v1.ResourceList, node string, podMetrics *metricsv1beta1.PodMetrics) {
+	list, ok := usage[node]
+	if !ok {
+		list = v1.ResourceList{}
+		usage[node] = list
+	}
+	for _, c := range podMetrics.Containers {
+		for name, quantity := range c.Usage {
+			addQuantity(list, name, quantity)
+		}
+	}
+}
+
+func addQuantity(list v1.ResourceList, name v1.ResourceName, quantity resource.Quantity) {
+	current, ok := list[name]
+	if !ok {
+		list[name] = quantity.DeepCopy()
+		return
+	}
+	current.Add(quantity)
+	list[name] = current
+}
+
+// newEvent monta o mesmo evento nas APIs core/v1 (usada pelo watcher) e events.k8s.io/v1 (usada pelo feed).
+func newEvent(r *rand.Rand, regarding object, eventType, reason, message, component string, at time.Time) (*v1.Event, *eventsv1.Event) {
+	kind := regarding.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = kindOf(regarding)
+	}
+	name := fmt.Sprintf("%s.%x", regarding.GetName(), r.Uint64())
+	timestamp := metav1.NewTime(at.Truncate(time.Second))
+	ref := v1.ObjectReference{Kind: kind, Namespace: regarding.GetNamespace(), Name: regarding.GetName(), UID: regarding.GetUID()}
+	uid := newUID(r)
+	coreEvent := &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: regarding.GetNamespace(), UID: uid, CreationTimestamp: timestamp},
+		InvolvedObject: ref,
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Count:          1,
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Source:         v1.EventSource{Component: component},
+	}
+	event := &eventsv1.Event{
+		ObjectMeta:               metav1.ObjectMeta{Name: name, Namespace: regarding.GetNamespace(), UID: uid, CreationTimestamp: timestamp},
+		EventTime:                metav1.NewMicroTime(timestamp.Time),
+		Regarding:                ref,
+		Reason:                   reason,
+		Note:                     message,
+		Type:                     eventType,
+		ReportingController:      component,
+		DeprecatedCount:          1,
+		DeprecatedFirstTimestamp: timestamp,
+		DeprecatedLastTimestamp:  timestamp,
+	}
+	return coreEvent, event
+}
+
+// kindOf retorna o Kind dos tipos que geram eventos no cluster simulado.
+func kindOf(obj object) string {
+	switch obj.(type) {
+	case *v1.Pod:
+		return "Pod"
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.ReplicaSet:
+		return "ReplicaSet"
+	case *v1.Node:
+		return "Node"
+	}
+	return ""
+}
+
+func setDeploymentReplicas(deployment *appsv1.Deployment, replicas int32) {
+	deployment.Spec.Replicas = ptr(replicas)
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           replicas,
+		UpdatedReplicas:    replicas,
+		ReadyReplicas:      replicas,
+		AvailableReplicas:  replicas,
+	}
+}
+
+func setReplicaSetReplicas(replicaSet *appsv1.ReplicaSet, replicas int32) {
+	replicaSet.Spec.Replicas = ptr(replicas)
+	replicaSet.Status = appsv1.ReplicaSetStatus{
+		Replicas:             replicas,
+		FullyLabeledReplicas: replicas,
+		ReadyReplicas:        replicas,
+		AvailableReplicas:    replicas,
+	}
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func ownerReference(apiVersion, kind, name string, uid types.UID) metav1.OwnerReference {
+	return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: uid, Controller: ptr(true), BlockOwnerDeletion: ptr(true)}
+}
+
+func mergeLabels(base, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// toUnstructured converte o objeto tipado para a representação usada pelo cliente dinâmico.
+func toUnstructured(rt resourceType, obj object) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		// Os objetos são gerados por este pacote; uma falha aqui é um erro de programação.
+		panic(fmt.Sprintf("demo: falha ao converter %s %s: %v", rt.kind, obj.GetName(), err))
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetAPIVersion(rt.gvr.GroupVersion().String())
+	u.SetKind(rt.kind)
+	return u
+}
+
+const alphanumeric = "bcdfghjklmnpqrstvwxz2456789"
+
+func randomString(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphanumeric[r.IntN(len(alphanumeric))]
+	}
+	return string(b)
+}
+
+func newUID(r *rand.Rand) types.UID {
+	return types.UID(fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", r.Uint32(), r.Uint32()&0xffff, r.Uint32()&0xffff, r.Uint32()&0xffff, r.Uint64()&0xffffffffffff))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,168 @@
+package demo
+
+import (
+	"context"
+	"io"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/services"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TestMain silencia a saída de log durante os testes deste pacote.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func newTestCluster(t *testing.T) (*Cluster, services.Service) {
+	cluster, err := New(WithSeed(7))
+	if err != nil {
+		t.Fatalf("falha ao gerar o cluster: %v", err)
+	}
+	service := services.NewK8sService(cluster.Clients.Clientset, cluster.Clients.Metrics,
+		services.WithDynamicClient(cluster.Clients.Dynamic),
+		services.WithNodeStatsFunc(cluster.Clients.NodeStats),
+	)
+	return cluster, service
+}
+
+func TestNew_ServesGeneratedCluster(t *testing.T) {
+	_, service := newTestCluster(t)
+	ctx := context.Background()
+
+	overview, err := service.GetOverviewData(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, len(nodeSpecs), overview.NodeCount)
+	assert.Equal(t, 3, overview.NamespaceCount)
+	assert.Greater(t, overview.Capacity.CPUUsagePercentage, 0.0)
+
+	pods, err := service.GetPodInfo(ctx)
+	assert.NoError(t, err)
+	expected := 0
+	for _, a := range apps {
+		if !services.IsSystemNamespace(a.namespace) {
+			expected += int(a.replicas)
+		}
+	}
+	assert.Len(t, pods, expected)
+	for _, pod := range pods {
+		assert.Equal(t, "Running", pod.Status, pod.Name)
+		assert.NotEmpty(t, pod.UsedCPU, pod.Name)
+	}
+
+	serviceInfo, err := service.GetServiceInfo(ctx)
+	assert.NoError(t, err)
+	for _, s := range serviceInfo {
+		if s.Name == "frontend" {
+			assert.Equal(t, 3, s.ReadyEndpoints)
+		}
+	}
+
+	disk, err := service.GetDiskUsage(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, disk.Nodes, len(nodeSpecs))
+	assert.NotEmpty(t, disk.Volumes)
+
+	resources, err := service.GetResourceList(ctx, "apps", "v1", "deployments", "shop")
+	assert.NoError(t, err)
+	assert.Len(t, resources.Items, 3)
+
+	_, err = service.GetTopology(ctx, "shop")
+	assert.NoError(t, err)
+}
+
+func TestNew_SameSeedSameCluster(t *testing.T) {
+	names := func() []string {
+		cluster, err := New(WithSeed(42))
+		if err != nil {
+			t.Fatalf("falha ao gerar o cluster: %v", err)
+		}
+		pods, err := cluster.Clients.Clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("falha ao listar os pods: %v", err)
+		}
+		result := []string{}
+		for _, pod := range pods.Items {
+			result = append(result, pod.Name)
+		}
+		return result
+	}
+	assert.ElementsMatch(t, names(), names())
+}
+
+func TestStep_MutatesPodsAndEmitsWatchEvents(t *testing.T) {
+	cluster, service := newTestCluster(t)
+	ctx := context.Background()
+
+	podWatch, err := cluster.Clients.Clientset.CoreV1().Pods("").Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao observar os pods: %v", err)
+	}
+	defer podWatch.Stop()
+	eventWatch, err := cluster.Clients.Clientset.CoreV1().Events("").Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao observar os eventos: %v", err)
+	}
+	defer eventWatch.Stop()
+
+	before, err := service.GetEventInfo(ctx, eventinfo.Filter{})
+	assert.NoError(t, err)
+
+	const steps = 30
+	for i := 0; i < steps; i++ {
+		if err := cluster.Step(ctx); err != nil {
+			t.Fatalf("falha no passo %d: %v", i, err)
+		}
+	}
+
+	podEvents := drain(podWatch.ResultChan())
+	assert.NotEmpty(t, podEvents)
+	types := map[watch.EventType]bool{}
+	for _, event := range podEvents {
+		types[event.Type] = true
+	}
+	assert.True(t, types[watch.Modified], "reinícios e crash loops alteram pods")
+	assert.GreaterOrEqual(t, len(drain(eventWatch.ResultChan())), steps)
+
+	after, err := service.GetEventInfo(ctx, eventinfo.Filter{})
+	assert.NoError(t, err)
+	assert.Greater(t, len(after), len(before))
+
+	// Os clientes tipado e dinâmico continuam consistentes após o escalonamento.
+	deployments, err := cluster.Clients.Clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	for _, deployment := range deployments.Items {
+		pods, err := cluster.Clients.Clientset.CoreV1().Pods(deployment.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, pods.Items, int(*deployment.Spec.Replicas), deployment.Name)
+		assert.GreaterOrEqual(t, *deployment.Spec.Replicas, int32(minReplicas))
+		assert.LessOrEqual(t, *deployment.Spec.Replicas, int32(maxReplicas))
+	}
+	list, err := service.GetResourceList(ctx, "", "v1", "pods", "")
+	assert.NoError(t, err)
+	pods, err := service.GetPodInfo(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, len(pods))
+}
+
+// drain lê os eventos já entregues ao canal, sem bloquear.
+func drain(ch <-chan watch.Event) []watch.Event {
+	var events []watch.Event
+	for {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		case <-time.After(50 * time.Millisecond):
+			return events
+		}
+	}
+}
@@ -0,0 +1,614 @@
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/services"
+	"kubeowl/internal/snapshot"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	// DefaultInterval é o intervalo padrão entre as alterações do simulador.
+	DefaultInterval = 5 * time.Second
+	// defaultSeed torna o cluster gerado igual a cada execução, salvo se outra semente for informada.
+	defaultSeed = 2024
+	// maxEvents limita os eventos guardados; os mais antigos são removidos, como faz o TTL do Kubernetes.
+	maxEvents = 1000
+	// minReplicas e maxReplicas limitam o escalonamento simulado dos Deployments.
+	minReplicas = 1
+	maxReplicas = 6
+)
+
+// Option personaliza o cluster simulado.
+type Option func(*Cluster)
+
+// WithSeed define a semente do gerador; a mesma semente produz o mesmo cluster inicial.
+func WithSeed(seed uint64) Option {
+	return func(c *Cluster) {
+		c.seed = seed
+	}
+}
+
+// WithInterval define o intervalo entre as alterações do simulador.
+func WithInterval(interval time.Duration) Option {
+	return func(c *Cluster) {
+		c.interval = interval
+	}
+}
+
+// objectTracker é implementado pelos clientsets falsos do client-go.
+type objectTracker interface {
+	Tracker() k8stesting.ObjectTracker
+}
+
+// Cluster é um cluster gerado servido por clientes falsos. Run altera os pods periodicamente; as alterações
+// passam pelos rastreadores dos fakes, que as entregam aos watches abertos (e, portanto, ao feed ao vivo).
+type Cluster struct {
+	// Clients são os clientes que o serviço e os watchers devem usar.
+	Clients *snapshot.Clients
+
+	seed     uint64
+	interval time.Duration
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	typed   k8stesting.ObjectTracker
+	dynamic k8stesting.ObjectTracker
+	metrics k8stesting.ObjectTracker
+	nodeIP  map[string]string
+	podSeq  int
+}
+
+// New gera o cluster e monta os clientes falsos.
+func New(opts ...Option) (*Cluster, error) {
+	c := &Cluster{seed: defaultSeed, interval: DefaultInterval}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.rand = rand.New(rand.NewPCG(c.seed, c.seed))
+
+	g := newGenerator(c.rand, time.Now())
+	clients, err := g.generate().Clients()
+	if err != nil {
+		return nil, err
+	}
+	typed, ok := clients.Clientset.(objectTracker)
+	if !ok {
+		return nil, errors.New("clientset sem rastreador de objetos")
+	}
+	dynamic, ok := clients.Dynamic.(objectTracker)
+	if !ok {
+		return nil, errors.New("cliente dinâmico sem rastreador de objetos")
+	}
+	metrics, ok := clients.Metrics.(objectTracker)
+	if !ok {
+		return nil, errors.New("clientset de métricas sem rastreador de objetos")
+	}
+	c.typed, c.dynamic, c.metrics = typed.Tracker(), dynamic.Tracker(), metrics.Tracker()
+	c.nodeIP = g.nodeIP
+	c.podSeq = g.podSeq
+	clients.NodeStats = c.NodeStats
+	c.Clients = clients
+	return c, nil
+}
+
+// Run aplica uma alteração a cada intervalo até o contexto ser cancelado.
+func (c *Cluster) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Step(ctx); err != nil {
+				slog.WarnContext(ctx, "falha na simulação do cluster de demonstração", logging.Err(err))
+			}
+		}
+	}
+}
+
+// Step aplica uma alteração aleatória (reinício, crash loop, recuperação ou escalonamento) e varia as métricas.
+func (c *Cluster) Step(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var err error
+	switch n := c.rand.IntN(100); {
+	case n < 35:
+		err = c.restartPod(ctx, now)
+	case n < 55:
+		err = c.crashPod(ctx, now)
+	case n < 75:
+		err = c.recoverPod(ctx, now)
+	default:
+		err = c.scaleDeployment(ctx, now)
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.refreshMetrics(ctx, now); err != nil {
+		return err
+	}
+	return c.pruneEvents(ctx)
+}
+
+// restartPod reinicia o contêiner de um pod em execução, como após uma falha de liveness ou um OOM.
+func (c *Cluster) restartPod(ctx context.Context, now time.Time) error {
+	pod, err := c.pickPod(ctx, func(pod *v1.Pod) bool { return !isCrashLooping(pod) })
+	if err != nil || pod == nil {
+		return err
+	}
+	status := &pod.Status.ContainerStatuses[0]
+	reason, exitCode := "Error", int32(1)
+	eventReason, message := "Unhealthy", "Liveness probe failed: HTTP probe failed with statuscode: 503"
+	if c.rand.IntN(3) == 0 {
+		reason, exitCode = "OOMKilled", 137
+		eventReason, message = "OOMKilling", fmt.Sprintf("Memory cgroup out of memory: Killed process %d (%s)", 1000+c.rand.IntN(30000), status.Name)
+	}
+	terminate(status, reason, exitCode, now)
+	status.State = v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(now)}}
+	if err := c.update(podsResource, pod); err != nil {
+		return err
+	}
+	return c.emit(pod, v1.EventTypeWarning, eventReason, message, "kubelet", now)
+}
+
+// crashPod coloca um pod em CrashLoopBackOff.
+func (c *Cluster) crashPod(ctx context.Context, now time.Time) error {
+	pod, err := c.pickPod(ctx, func(pod *v1.Pod) bool { return !isCrashLooping(pod) && !services.IsSystemNamespace(pod.Namespace) })
+	if err != nil || pod == nil {
+		return err
+	}
+	status := &pod.Status.ContainerStatuses[0]
+	terminate(status, "Error", 1, now)
+	status.Ready = false
+	status.Started = ptr(false)
+	status.State = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+		Reason:  "CrashLoopBackOff",
+		Message: fmt.Sprintf("back-off 40s restarting failed container=%s pod=%s", status.Name, pod.Name),
+	}}
+	setPodReady(pod, false, now)
+	if err := c.update(podsResource, pod); err != nil {
+		return err
+	}
+	if err := c.syncEndpoints(ctx, pod.Namespace, appName(pod)); err != nil {
+		return err
+	}
+	return c.emit(pod, v1.EventTypeWarning, "BackOff",
+		fmt.Sprintf("Back-off restarting failed container %s in pod %s", status.Name, pod.Name), "kubelet", now)
+}
+
+// recoverPod tira um pod do CrashLoopBackOff; sem pods em crash loop, reinicia um pod qualquer.
+func (c *Cluster) recoverPod(ctx context.Context, now time.Time) error {
+	pod, err := c.pickPod(ctx, isCrashLooping)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		return c.restartPod(ctx, now)
+	}
+	status := &pod.Status.ContainerStatuses[0]
+	status.Ready = true
+	status.Started = ptr(true)
+	status.State = v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(now)}}
+	setPodReady(pod, true, now)
+	if err := c.update(podsResource, pod); err != nil {
+		return err
+	}
+	if err := c.syncEndpoints(ctx, pod.Namespace, appName(pod)); err != nil {
+		return err
+	}
+	return c.emit(pod, v1.EventTypeNormal, "Started", fmt.Sprintf("Started container %s", status.Name), "kubelet", now)
+}
+
+// scaleDeployment aumenta ou reduz em uma réplica um Deployment das aplicações, criando ou removendo pods.
+func (c *Cluster) scaleDeployment(ctx context.Context, now time.Time) error {
+	candidates := []app{}
+	for _, a := range apps {
+		if !services.IsSystemNamespace(a.namespace) {
+			candidates = append(candidates, a)
+		}
+	}
+	a := candidates[c.rand.IntN(len(candidates))]
+
+	deployment, err := c.Clients.Clientset.AppsV1().Deployments(a.namespace).Get(ctx, a.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	replicaSets, err := c.Clients.Clientset.AppsV1().ReplicaSets(a.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var replicaSet *appsv1.ReplicaSet
+	for i := range replicaSets.Items {
+		if metav1.IsControlledBy(&replicaSets.Items[i], deployment) {
+			replicaSet = &replicaSets.Items[i]
+		}
+	}
+	if replicaSet == nil {
+		return fmt.Errorf("ReplicaSet do Deployment %s/%s não encontrado", a.namespace, a.name)
+	}
+	pods, err := c.podsOf(ctx, replicaSet)
+	if err != nil {
+		return err
+	}
+
+	current := int32(len(pods))
+	target := current + 1
+	if current >= maxReplicas || (current > minReplicas && c.rand.IntN(2) == 0) {
+		target = current - 1
+	}
+
+	if target > current {
+		workers := nodeSpecs[1:]
+		node := workers[c.rand.IntN(len(workers))].name
+		c.podSeq++
+		pod := newPod(a, replicaSet, replicaSet.Name+"-"+randomString(c.rand, 5), newUID(c.rand), node, c.nodeIP[node],
+			fmt.Sprintf("10.244.%d.%d", c.podSeq/250+1, c.podSeq%250+2), metav1.NewTime(now.Truncate(time.Second)))
+		if err := c.create(podsResource, pod); err != nil {
+			return err
+		}
+		if err := c.metrics.Create(podMetricsResource, podMetrics(c.rand, a, pod, now), pod.Namespace); err != nil {
+			return err
+		}
+		if err := c.emit(pod, v1.EventTypeNormal, "Scheduled",
+			fmt.Sprintf("Successfully assigned %s/%s to %s", pod.Namespace, pod.Name, node), "default-scheduler", now); err != nil {
+			return err
+		}
+	} else {
+		pod := pods[c.rand.IntN(len(pods))]
+		if err := c.remove(podsResource, pod.Namespace, pod.Name); err != nil {
+			return err
+		}
+		if err := c.metrics.Delete(podMetricsResource, pod.Namespace, pod.Name); err != nil {
+			return err
+		}
+	}
+
+	setReplicaSetReplicas(replicaSet, target)
+	if err := c.update(replicaSetsResource, replicaSet); err != nil {
+		return err
+	}
+	deployment.Generation++
+	setDeploymentReplicas(deployment, target)
+	if err := c.update(deploymentsResource, deployment); err != nil {
+		return err
+	}
+	if err := c.syncEndpoints(ctx, a.namespace, a.name); err != nil {
+		return err
+	}
+	direction := "up"
+	if target < current {
+		direction = "down"
+	}
+	return c.emit(deployment, v1.EventTypeNormal, "ScalingReplicaSet",
+		fmt.Sprintf("Scaled %s replica set %s from %d to %d", direction, replicaSet.Name, current, target), "deployment-controller", now)
+}
+
+// refreshMetrics varia o uso de cada pod em até 15% e recalcula o uso dos nós.
+func (c *Cluster) refreshMetrics(ctx context.Context, now time.Time) error {
+	pods, err := c.Clients.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	podMetricsList, err := c.Clients.Metrics.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	usageByPod := map[string]v1.ResourceList{}
+	for i := range podMetricsList.Items {
+		podMetrics := &podMetricsList.Items[i]
+		for j := range podMetrics.Containers {
+			usage := podMetrics.Containers[j].Usage
+			factor := 0.85 + 0.3*c.rand.Float64()
+			cpu, memory := usage[v1.ResourceCPU], usage[v1.ResourceMemory]
+			usage[v1.ResourceCPU] = *resource.NewMilliQuantity(max(1, int64(float64(cpu.MilliValue())*factor)), resource.DecimalSI)
+			usage[v1.ResourceMemory] = *resource.NewQuantity(max(1<<20, int64(float64(memory.Value())*factor)), resource.BinarySI)
+		}
+		podMetrics.Timestamp = metav1.NewTime(now)
+		if err := c.metrics.Update(podMetricsResource, podMetrics, podMetrics.Namespace); err != nil {
+			return err
+		}
+		usageByPod[podMetrics.Namespace+"/"+podMetrics.Name] = podMetrics.Containers[0].Usage
+	}
+
+	usageByNode := map[string]v1.ResourceList{}
+	for _, pod := range pods.Items {
+		usage, ok := usageByPod[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		list, ok := usageByNode[pod.Spec.NodeName]
+		if !ok {
+			list = v1.ResourceList{}
+			usageByNode[pod.Spec.NodeName] = list
+		}
+		for name, quantity := range usage {
+			addQuantity(list, name, quantity)
+		}
+	}
+	for _, spec := range nodeSpecs {
+		metrics := nodeMetrics(spec.name, usageByNode[spec.name], now)
+		if err := c.metrics.Update(nodeMetricsResource, &metrics, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneEvents remove os eventos mais antigos além de maxEvents.
+func (c *Cluster) pruneEvents(ctx context.Context) error {
+	events, err := c.Clients.Clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil || len(events.Items) <= maxEvents {
+		return err
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+	for _, event := range events.Items[:len(events.Items)-maxEvents] {
+		if err := c.remove(coreEventsResource, event.Namespace, event.Name); err != nil {
+			return err
+		}
+		if err := c.remove(eventsResource, event.Namespace, event.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncEndpoints reconstrói o EndpointSlice do Service da aplicação a partir dos pods atuais.
+func (c *Cluster) syncEndpoints(ctx context.Context, namespace, name string) error {
+	service, err := c.Clients.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		// Aplicações sem Service (ex.: workers) não têm endpoints.
+		return nil
+	}
+	pods, err := c.Clients.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return err
+	}
+	selected := make([]*v1.Pod, 0, len(pods.Items))
+	for i := range pods.Items {
+		selected = append(selected, &pods.Items[i])
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	return c.update(endpointSlicesResource, endpointSlice(service, selected))
+}
+
+// pickPod sorteia um pod que satisfaça o filtro, ou nil se nenhum satisfizer.
+func (c *Cluster) pickPod(ctx context.Context, filter func(*v1.Pod) bool) (*v1.Pod, error) {
+	pods, err := c.Clients.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	candidates := []*v1.Pod{}
+	for i := range pods.Items {
+		if filter(&pods.Items[i]) {
+			candidates = append(candidates, &pods.Items[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	// A ordem da listagem do fake não é estável; ordenar mantém a simulação reproduzível pela semente.
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Namespace+"/"+candidates[i].Name < candidates[j].Namespace+"/"+candidates[j].Name
+	})
+	return candidates[c.rand.IntN(len(candidates))], nil
+}
+
+func (c *Cluster) podsOf(ctx context.Context, replicaSet *appsv1.ReplicaSet) ([]v1.Pod, error) {
+	pods, err := c.Clients.Clientset.CoreV1().Pods(replicaSet.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(replicaSet.Spec.Selector.MatchLabels).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	return pods.Items, nil
+}
+
+// emit registra o evento nas duas APIs de eventos.
+func (c *Cluster) emit(regarding object, eventType, reason, message, component string, now time.Time) error {
+	coreEvent, event := newEvent(c.rand, regarding, eventType, reason, message, component, now)
+	if err := c.create(coreEventsResource, coreEvent); err != nil {
+		return err
+	}
+	return c.create(eventsResource, event)
+}
+
+// create, update e remove aplicam a alteração nos clientes tipado e dinâmico, mantendo-os consistentes.
+func (c *Cluster) create(gvr schema.GroupVersionResource, obj object) error {
+	rt, _ := lookupResourceType(gvr)
+	if err := c.typed.Create(gvr, obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.dynamic.Create(gvr, toUnstructured(rt, obj), obj.GetNamespace())
+}
+
+func (c *Cluster) update(gvr schema.GroupVersionResource, obj object) error {
+	rt, _ := lookupResourceType(gvr)
+	if err := c.typed.Update(gvr, obj, obj.GetNamespace()); err != nil {
+		return err
+	}
+	return c.dynamic.Update(gvr, toUnstructured(rt, obj), obj.GetNamespace())
+}
+
+func (c *Cluster) remove(gvr schema.GroupVersionResource, namespace, name string) error {
+	if err := c.typed.Delete(gvr, namespace, name); err != nil {
+		return err
+	}
+	return c.dynamic.Delete(gvr, namespace, name)
+}
+
+// NodeStats gera o /stats/summary do nó a partir dos pods atuais. Os valores derivam do nome de cada
+// objeto, e não do gerador aleatório, para que consultas repetidas retornem o mesmo resultado.
+func (c *Cluster) NodeStats(ctx context.Context, nodeName string) ([]byte, error) {
+	if _, ok := c.nodeIP[nodeName]; !ok {
+		return nil, services.ErrNodeStatsUnavailable
+	}
+	pods, err := c.Clients.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	const gi = uint64(1 << 30)
+	const mi = uint64(1 << 20)
+
+	summary := kubeletSummary{Pods: []kubeletPod{}}
+	nodeUsed := 12*gi + uint64(stableFraction(nodeName)*float64(10*gi))
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		entry := kubeletPod{PodRef: kubeletRef{Name: pod.Name, Namespace: pod.Namespace}, Volumes: []kubeletVolume{}}
+		for _, container := range pod.Spec.Containers {
+			rootfs := 20*mi + uint64(stableFraction(pod.Name+"/rootfs")*float64(480*mi))
+			logs := mi + uint64(stableFraction(pod.Name+"/logs")*float64(80*mi))
+			nodeUsed += rootfs + logs
+			entry.Containers = append(entry.Containers, kubeletContainer{
+				Name:   container.Name,
+				Rootfs: &kubeletFs{UsedBytes: rootfs},
+				Logs:   &kubeletFs{UsedBytes: logs},
+			})
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			claim, err := c.Clients.Clientset.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, volume.PersistentVolumeClaim.ClaimName, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			capacity := uint64(claim.Status.Capacity.Storage().Value())
+			used := uint64((0.2 + 0.75*stableFraction(claim.Namespace+"/"+claim.Name)) * float64(capacity))
+			entry.Volumes = append(entry.Volumes, kubeletVolume{
+				kubeletFs: kubeletFs{UsedBytes: used, CapacityBytes: capacity, AvailableBytes: capacity - used},
+				Name:      volume.Name,
+				PVCRef:    &kubeletRef{Name: claim.Name, Namespace: claim.Namespace},
+			})
+		}
+		summary.Pods = append(summary.Pods, entry)
+	}
+	nodeCapacity := 100 * gi
+	imageUsed := 6*gi + uint64(stableFraction(nodeName+"/images")*float64(8*gi))
+	summary.Node = kubeletNode{
+		NodeName: nodeName,
+		Fs:       &kubeletFs{UsedBytes: nodeUsed, CapacityBytes: nodeCapacity, AvailableBytes: nodeCapacity - nodeUsed},
+		Runtime: &kubeletRuntime{ImageFs: &kubeletFs{
+			UsedBytes: imageUsed, CapacityBytes: nodeCapacity, AvailableBytes: nodeCapacity - imageUsed,
+		}},
+	}
+	return json.Marshal(summary)
+}
+
+// Estruturas do /stats/summary do kubelet, com os campos usados pelo serviço.
+type kubeletSummary struct {
+	Node kubeletNode  `json:"node"`
+	Pods []kubeletPod `json:"pods"`
+}
+
+type kubeletNode struct {
+	NodeName string          `json:"nodeName"`
+	Fs       *kubeletFs      `json:"fs,omitempty"`
+	Runtime  *kubeletRuntime `json:"runtime,omitempty"`
+}
+
+type kubeletRuntime struct {
+	ImageFs *kubeletFs `json:"imageFs,omitempty"`
+}
+
+type kubeletPod struct {
+	PodRef     kubeletRef         `json:"podRef"`
+	Containers []kubeletContainer `json:"containers"`
+	Volumes    []kubeletVolume    `json:"volume"`
+}
+
+type kubeletRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type kubeletContainer struct {
+	Name   string     `json:"name"`
+	Rootfs *kubeletFs `json:"rootfs,omitempty"`
+	Logs   *kubeletFs `json:"logs,omitempty"`
+}
+
+type kubeletVolume struct {
+	kubeletFs
+	Name   string      `json:"name"`
+	PVCRef *kubeletRef `json:"pvcRef,omitempty"`
+}
+
+type kubeletFs struct {
+	AvailableBytes uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      uint64 `json:"usedBytes"`
+}
+
+// stableFraction retorna um valor em [0, 1) derivado da chave.
+func stableFraction(key string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return float64(h.Sum64()%10000) / 10000
+}
+
+func terminate(status *v1.ContainerStatus, reason string, exitCode int32, now time.Time) {
+	started := metav1.NewTime(now.Add(-time.Minute))
+	if status.State.Running != nil {
+		started = status.State.Running.StartedAt
+	}
+	status.RestartCount++
+	status.LastTerminationState = v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+		ExitCode: exitCode, Reason: reason, StartedAt: started, FinishedAt: metav1.NewTime(now),
+	}}
+}
+
+func setPodReady(pod *v1.Pod, ready bool, now time.Time) {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == v1.PodReady || condition.Type == v1.ContainersReady {
+			condition.Status = status
+			condition.LastTransitionTime = metav1.NewTime(now)
+		}
+	}
+}
+
+func isCrashLooping(pod *v1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+// appName retorna o nome da aplicação do pod, guardado no rótulo app.kubernetes.io/name.
+func appName(pod *v1.Pod) string {
+	return pod.Labels["app.kubernetes.io/name"]
+}
@@ -0,0 +1,162 @@
+// Package eventinfo converte os eventos do Kubernetes para o formato exibido no dashboard e define os filtros
+// aplicados a eles, compartilhados pela consulta ao cluster e pelo histórico local.
+package eventinfo
+
+import (
+	"fmt"
+	"kubeowl/internal/models"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+// Filter descreve os filtros da consulta de eventos. Campos vazios não filtram; a paginação, a ordenação
+// e a busca textual ficam a cargo da consulta comum das listagens (ver internal/listquery).
+type Filter struct {
+	Type      string
+	Reason    string
+	Namespace string
+	Kind      string
+	Name      string
+	// Since descarta eventos vistos pela última vez antes do instante informado.
+	Since time.Time
+}
+
+// Matches aplica os filtros de tipo, motivo, namespace e objeto a um evento. Tipo, motivo e kind
+// não diferenciam maiúsculas de minúsculas; Since é tratado por quem consulta.
+func (filter Filter) Matches(info models.EventInfo) bool {
+	if filter.Type != "" && !strings.EqualFold(info.Type, filter.Type) {
+		return false
+	}
+	if filter.Reason != "" && !strings.EqualFold(info.Reason, filter.Reason) {
+		return false
+	}
+	if filter.Namespace != "" && info.Namespace != filter.Namespace {
+		return false
+	}
+	if filter.Kind != "" && !strings.EqualFold(info.InvolvedObject.Kind, filter.Kind) {
+		return false
+	}
+	if filter.Name != "" && info.InvolvedObject.Name != filter.Name {
+		return false
+	}
+	return true
+}
+
+// FromEvent converte um evento de events.k8s.io/v1, retornando também o início e o fim do intervalo observado.
+// Eventos novos usam eventTime e series; os campos deprecated cobrem eventos criados pela API core/v1.
+func FromEvent(event eventsv1.Event) (models.EventInfo, time.Time, time.Time) {
+	var lastSeen time.Time
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		lastSeen = event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		lastSeen = event.EventTime.Time
+	case !event.DeprecatedLastTimestamp.IsZero():
+		lastSeen = event.DeprecatedLastTimestamp.Time
+	case !event.DeprecatedFirstTimestamp.IsZero():
+		lastSeen = event.DeprecatedFirstTimestamp.Time
+	default:
+		lastSeen = event.CreationTimestamp.Time
+	}
+	firstSeen := lastSeen
+	switch {
+	case !event.EventTime.IsZero():
+		firstSeen = event.EventTime.Time
+	case !event.DeprecatedFirstTimestamp.IsZero():
+		firstSeen = event.DeprecatedFirstTimestamp.Time
+	}
+
+	count := int32(1)
+	switch {
+	case event.Series != nil && event.Series.Count > 0:
+		count = event.Series.Count
+	case event.DeprecatedCount > 0:
+		count = event.DeprecatedCount
+	}
+
+	controller, instance := event.ReportingController, event.ReportingInstance
+	if controller == "" {
+		controller = event.DeprecatedSource.Component
+	}
+	if instance == "" {
+		instance = event.DeprecatedSource.Host
+	}
+
+	info := models.EventInfo{
+		Type:                event.Type,
+		Reason:              event.Reason,
+		Object:              fmt.Sprintf("%s/%s", event.Regarding.Kind, event.Regarding.Name),
+		Message:             event.Note,
+		Namespace:           event.Namespace,
+		Count:               count,
+		ReportingController: controller,
+		ReportingInstance:   instance,
+		InvolvedObject:      objectRef(event.Regarding),
+	}
+	return info, firstSeen, lastSeen
+}
+
+// objectRef converte a referência ao objeto envolvido no evento.
+func objectRef(ref v1.ObjectReference) models.EventObjectRef {
+	return models.EventObjectRef{
+		Kind:       ref.Kind,
+		APIVersion: ref.APIVersion,
+		Namespace:  ref.Namespace,
+		Name:       ref.Name,
+		UID:        string(ref.UID),
+	}
+}
+
+// CoreLastSeen retorna o instante da última ocorrência de um evento core/v1, que pode ter sido
+// criado pela API events.k8s.io/v1 e, portanto, não ter lastTimestamp.
+func CoreLastSeen(event v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// FromCoreEvent converte um evento core/v1 para o formato exibido no dashboard.
+func FromCoreEvent(event v1.Event) models.EventInfo {
+	lastSeen := CoreLastSeen(event)
+	firstSeen := lastSeen
+	if !event.FirstTimestamp.IsZero() {
+		firstSeen = event.FirstTimestamp.Time
+	}
+	count := event.Count
+	if event.Series != nil && event.Series.Count > 0 {
+		count = event.Series.Count
+	}
+	if count == 0 {
+		count = 1
+	}
+	controller := event.ReportingController
+	if controller == "" {
+		controller = event.Source.Component
+	}
+	return models.EventInfo{
+		Timestamp:           lastSeen.Format(time.RFC822),
+		Type:                event.Type,
+		Reason:              event.Reason,
+		Object:              fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+		Message:             event.Message,
+		Namespace:           event.Namespace,
+		Count:               count,
+		FirstSeen:           firstSeen.Format(time.RFC3339),
+		LastSeen:            lastSeen.Format(time.RFC3339),
+		ReportingController: controller,
+		ReportingInstance:   event.ReportingInstance,
+		InvolvedObject:      objectRef(event.InvolvedObject),
+	}
+}
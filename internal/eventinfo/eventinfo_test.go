@@ -0,0 +1,59 @@
+package eventinfo
+
+import (
+	"kubeowl/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var baseTime = time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+
+func TestFromEvent_DeprecatedFields(t *testing.T) {
+	// Evento criado pela API core/v1: sem eventTime, com os campos deprecated.
+	info, firstSeen, lastSeen := FromEvent(eventsv1.Event{
+		ObjectMeta:               metav1.ObjectMeta{Namespace: "app"},
+		Type:                     "Normal",
+		Reason:                   "Scheduled",
+		Note:                     "Assigned app/web-1",
+		Regarding:                v1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "web-1"},
+		DeprecatedSource:         v1.EventSource{Component: "default-scheduler", Host: "node-1"},
+		DeprecatedFirstTimestamp: metav1.NewTime(baseTime.Add(-time.Hour)),
+		DeprecatedLastTimestamp:  metav1.NewTime(baseTime),
+		DeprecatedCount:          2,
+	})
+	assert.Equal(t, baseTime.Add(-time.Hour), firstSeen)
+	assert.Equal(t, baseTime, lastSeen)
+	assert.Equal(t, "Pod/web-1", info.Object)
+	assert.Equal(t, "Assigned app/web-1", info.Message)
+	assert.Equal(t, int32(2), info.Count)
+	assert.Equal(t, "default-scheduler", info.ReportingController)
+	assert.Equal(t, "node-1", info.ReportingInstance)
+}
+
+func TestFromCoreEvent_TimeFallback(t *testing.T) {
+	event := v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "app"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "web-0"},
+		EventTime:      metav1.NewMicroTime(baseTime),
+		Series:         &v1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(baseTime.Add(time.Minute))},
+	}
+	info := FromCoreEvent(event)
+	assert.Equal(t, baseTime.Add(time.Minute).Format(time.RFC3339), info.LastSeen)
+	assert.Equal(t, int32(3), info.Count)
+}
+
+func TestFilter_Matches(t *testing.T) {
+	info := models.EventInfo{
+		Type: "Warning", Reason: "BackOff", Namespace: "app",
+		InvolvedObject: models.EventObjectRef{Kind: "Pod", Namespace: "app", Name: "web-0"},
+	}
+	assert.True(t, Filter{}.Matches(info))
+	assert.True(t, Filter{Type: "warning", Reason: "backoff", Kind: "pod", Name: "web-0"}.Matches(info))
+	assert.False(t, Filter{Namespace: "other"}.Matches(info))
+	assert.False(t, Filter{Name: "Web-0"}.Matches(info), "o nome diferencia maiúsculas de minúsculas")
+}
@@ -0,0 +1,295 @@
+// Package export serializa as listagens da API em formatos para planilhas e ferramentas de linha de comando:
+// CSV (colunas na ordem dos campos das structs de models), NDJSON e YAML.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kubeowl/internal/i18n"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Format é um formato de resposta das listagens.
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatYAML   Format = "yaml"
+)
+
+var contentTypes = map[Format]string{
+	FormatJSON:   "application/json",
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatYAML:   "application/yaml",
+}
+
+// mediaTypes associa os tipos aceitos no cabeçalho Accept aos formatos.
+var mediaTypes = map[string]Format{
+	"application/json":     FormatJSON,
+	"text/csv":             FormatCSV,
+	"application/x-ndjson": FormatNDJSON,
+	"application/ndjson":   FormatNDJSON,
+	"application/yaml":     FormatYAML,
+	"application/x-yaml":   FormatYAML,
+	"text/yaml":            FormatYAML,
+}
+
+// listSeparator separa os itens de listas e mapas dentro de uma célula CSV.
+const listSeparator = "; "
+
+// maxColumnDepth limita o achatamento de structs aninhadas em colunas.
+const maxColumnDepth = 3
+
+// ContentType retorna o Content-Type do formato.
+func ContentType(format Format) string {
+	return contentTypes[format]
+}
+
+// Negotiate escolhe o formato pelo parâmetro format ou, na sua ausência, pelo tipo de maior preferência
+// do cabeçalho Accept. Sem nenhum tipo conhecido, o formato é JSON.
+func Negotiate(req *http.Request) (Format, error) {
+	if raw := req.URL.Query().Get("format"); raw != "" {
+		format := Format(strings.ToLower(raw))
+		if _, ok := contentTypes[format]; !ok {
+			return "", i18n.Errorf(i18n.MsgInvalidParameter, "format", raw)
+		}
+		return format, nil
+	}
+	best, bestQuality := FormatJSON, 0.0
+	for _, part := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		format, ok := mediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = format, quality
+		}
+	}
+	return best, nil
+}
+
+// Write serializa os itens no formato pedido.
+func Write[T any](w io.Writer, format Format, items []T) error {
+	if items == nil {
+		items = []T{}
+	}
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, items)
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatYAML:
+		data, err := yaml.Marshal(items)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(items)
+	}
+	return fmt.Errorf("formato de exportação desconhecido: %q", format)
+}
+
+// column é uma coluna do CSV: o caminho de nomes JSON (com pontos nas structs aninhadas) e os índices do campo.
+type column struct {
+	name  string
+	index []int
+}
+
+func writeCSV[T any](w io.Writer, items []T) error {
+	columns := columnsOf(reflect.TypeOf((*T)(nil)).Elem())
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for _, item := range items {
+		v := reflect.ValueOf(item)
+		for i, col := range columns {
+			row[i] = escapeFormula(cell(fieldByIndex(v, col.index)))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formulaPrefixes são os caracteres iniciais que fazem planilhas interpretarem a célula como fórmula.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixa com ' as células que uma planilha executaria como fórmula, como mensagens de eventos
+// e anotações controladas por quem cria os objetos no cluster. Números, inclusive negativos, ficam intactos.
+func escapeFormula(text string) string {
+	if text == "" || !strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return text
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return text
+	}
+	return "'" + text
+}
+
+// columnsOf lista as colunas do tipo na ordem de declaração dos campos. Structs aninhadas viram colunas
+// com prefixo (ex.: filesystem.usedBytes); tipos que não são structs geram uma única coluna "value".
+func columnsOf(t reflect.Type) []column {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return []column{{name: "value"}}
+	}
+	return appendColumns(nil, t, "", nil, 0)
+}
+
+func appendColumns(columns []column, t reflect.Type, prefix string, index []int, depth int) []column {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && depth < maxColumnDepth {
+			nestedPrefix := prefix + name + "."
+			if field.Anonymous && field.Tag.Get("json") == "" {
+				nestedPrefix = prefix
+			}
+			columns = appendColumns(columns, fieldType, nestedPrefix, fieldIndex, depth+1)
+			continue
+		}
+		columns = append(columns, column{name: prefix + name, index: fieldIndex})
+	}
+	return columns
+}
+
+// jsonName retorna o nome do campo no JSON, ou false para campos não exportados ou ignorados.
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// fieldByIndex percorre os índices, tratando ponteiros nulos como valor ausente.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// cell formata um valor para o CSV: escalares como texto, listas e mapas de escalares separados por "; "
+// e as demais estruturas como JSON compacto.
+func cell(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if text, ok := scalar(v); ok {
+		return text
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			text, ok := scalar(v.Index(i))
+			if !ok {
+				return compactJSON(v)
+			}
+			parts = append(parts, text)
+		}
+		return strings.Join(parts, listSeparator)
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, keyOK := scalar(iter.Key())
+			value, valueOK := scalar(iter.Value())
+			if !keyOK || !valueOK {
+				return compactJSON(v)
+			}
+			parts = append(parts, key+"="+value)
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, listSeparator)
+	}
+	return compactJSON(v)
+}
+
+func scalar(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	}
+	return "", false
+}
+
+func compactJSON(v reflect.Value) string {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"kubeowl/internal/models"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]Format{
+		"":                                     FormatJSON,
+		"*/*":                                  FormatJSON,
+		"text/csv":                             FormatCSV,
+		"application/json;q=0.5, text/yaml":    FormatYAML,
+		"application/x-ndjson, text/csv;q=0.9": FormatNDJSON,
+	}
+	for accept, expected := range cases {
+		req := httptest.NewRequest("GET", "/api/v1/pods", nil)
+		req.Header.Set("Accept", accept)
+		format, err := Negotiate(req)
+		assert.NoError(t, err, accept)
+		assert.Equal(t, expected, format, accept)
+	}
+
+	// O parâmetro format prevalece sobre o Accept.
+	req := httptest.NewRequest("GET", "/api/v1/pods?format=CSV", nil)
+	req.Header.Set("Accept", "application/yaml")
+	format, err := Negotiate(req)
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = Negotiate(httptest.NewRequest("GET", "/api/v1/pods?format=xlsx", nil))
+	assert.Error(t, err)
+}
+
+func TestWrite_CSV(t *testing.T) {
+	nodes := []models.NodeInfo{{
+		Name:       "worker-1",
+		Roles:      []string{"worker", "ingress"},
+		Filesystem: &models.FilesystemUsage{UsedBytes: 1024, CapacityBytes: 4096, UsagePercentage: 25},
+		Taints:     []models.TaintInfo{{Key: "gpu", Effect: "NoSchedule"}},
+	}, {Name: "worker-2, \"b\""}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatCSV, nodes))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("esperadas 3 linhas, obtidas %d: %s", len(lines), buf.String())
+	}
+	header := string(lines[0])
+	assert.Contains(t, header, "name,role,roles,pool,")
+	assert.Contains(t, header, ",filesystem.usedBytes,filesystem.capacityBytes,filesystem.availableBytes,filesystem.usagePercentage,")
+	assert.Contains(t, string(lines[1]), `worker-1,,worker; ingress,`)
+	assert.Contains(t, string(lines[1]), `,1024,4096,0,25,`)
+	assert.Contains(t, string(lines[1]), `"[{""key"":""gpu"",""effect"":""NoSchedule""}]"`)
+	assert.Contains(t, string(lines[2]), `"worker-2, ""b"""`)
+
+	// Sem itens, o CSV traz apenas o cabeçalho.
+	buf.Reset()
+	assert.NoError(t, Write[models.PodInfo](&buf, FormatCSV, nil))
+	assert.Equal(t, "name,namespace,nodeName,status,restarts,usedCpu,usedCpuMilli,usedMemory,usedMemoryBytes\n", buf.String())
+}
+
+func TestWrite_CSVEscapesFormulas(t *testing.T) {
+	events := []models.EventInfo{
+		{Reason: "=HYPERLINK(\"http://exemplo\")", Message: "+1+1", Object: "@SUM(A1)"},
+		{Reason: "-2+3", Message: "\tcmd", Object: "\rcmd"},
+		{Reason: "BackOff", Message: "a=b", Count: -1},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatCSV, events))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV inválido: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("esperadas 4 linhas, obtidas %d", len(rows))
+	}
+	column := func(name string) int {
+		for i, header := range rows[0] {
+			if header == name {
+				return i
+			}
+		}
+		t.Fatalf("coluna %s ausente", name)
+		return -1
+	}
+	reason, message, object, count := column("reason"), column("message"), column("object"), column("count")
+	assert.Equal(t, `'=HYPERLINK("http://exemplo")`, rows[1][reason])
+	assert.Equal(t, "'+1+1", rows[1][message])
+	assert.Equal(t, "'@SUM(A1)", rows[1][object])
+	assert.Equal(t, "'-2+3", rows[2][reason])
+	assert.Equal(t, "'\tcmd", rows[2][message])
+	assert.Equal(t, "'\rcmd", rows[2][object])
+	assert.Equal(t, "BackOff", rows[3][reason])
+	assert.Equal(t, "a=b", rows[3][message])
+	assert.Equal(t, "-1", rows[3][count], "números negativos não são fórmulas")
+}
+
+func TestWrite_NDJSONAndYAML(t *testing.T) {
+	services := []models.ServiceInfo{
+		{Name: "web", Namespace: "shop", Selector: map[string]string{"app": "web"}},
+		{Name: "api", Namespace: "shop"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatNDJSON, services))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"name":"web"`)
+
+	buf.Reset()
+	assert.NoError(t, Write(&buf, FormatYAML, services))
+	assert.Contains(t, buf.String(), "- clusterIp: \"\"")
+	assert.Contains(t, buf.String(), "  selector:\n    app: web\n")
+
+	buf.Reset()
+	assert.NoError(t, Write[models.ServiceInfo](&buf, FormatYAML, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
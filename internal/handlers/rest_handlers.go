@@ -1,69 +1,352 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"kubeowl/internal/archive"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/export"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/listquery"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/middleware"
+	"kubeowl/internal/models"
+	"kubeowl/internal/services"
+	"log/slog"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 func (r *Router) OverviewHandler(w http.ResponseWriter, req *http.Request) {
 	data, err := r.Service.GetOverviewData(req.Context())
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados da visão geral", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgOverviewFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
 func (r *Router) NodesHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetNodeInfo(req.Context())
+	serveList(w, req, r.Service.GetNodeInfo, i18n.MsgNodesFailed)
+}
+
+func (r *Router) NodePoolsHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetNodePoolInfo, i18n.MsgNodePoolsFailed)
+}
+
+func (r *Router) PodsHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetPodInfo, i18n.MsgPodsFailed)
+}
+
+func (r *Router) ServicesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetServiceInfo, i18n.MsgServicesFailed)
+}
+
+func (r *Router) IngressesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetIngressInfo, i18n.MsgIngressesFailed)
+}
+
+func (r *Router) TopologyHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetTopology(req.Context(), req.URL.Query().Get("namespace"))
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos nós", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgTopologyFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
-func (r *Router) PodsHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetPodInfo(req.Context())
+func (r *Router) HealthReportHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetHealthReport(req.Context())
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos pods", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgHealthReportFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
-func (r *Router) ServicesHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetServiceInfo(req.Context())
+func (r *Router) PolicyReportHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetPolicyReport(req.Context(), req.URL.Query().Get("namespace"))
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos services", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgPolicyReportFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
-func (r *Router) IngressesHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetIngressInfo(req.Context())
+func (r *Router) RightsizingHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetRightsizingReport(req.Context(), req.URL.Query().Get("namespace"))
+	if err != nil {
+		serviceErrorResponse(w, req, err, i18n.MsgRightsizingFailed)
+		return
+	}
+	jsonResponse(w, data, http.StatusOK)
+}
+
+func (r *Router) GatewaysHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetGatewayAPIInfo(req.Context(), req.URL.Query().Get("namespace"))
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos ingresses", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgGatewaysFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
 func (r *Router) PvcsHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetPvcInfo(req.Context())
+	serveList(w, req, r.Service.GetPvcInfo, i18n.MsgPvcsFailed)
+}
+
+func (r *Router) PersistentVolumesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetPersistentVolumeInfo, i18n.MsgPersistentVolsFailed)
+}
+
+func (r *Router) StorageClassesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetStorageClassInfo, i18n.MsgStorageClassesFailed)
+}
+
+func (r *Router) DiskUsageHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetDiskUsage(req.Context())
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos PVCs", http.StatusInternalServerError)
+		serviceErrorResponse(w, req, err, i18n.MsgDiskUsageFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
 }
 
+// EventsHandler lista os eventos com os filtros type, reason, namespace, kind, name e since
+// (RFC3339 ou duração, ex.: 2h), paginados, ordenados e pesquisados como as demais listagens.
 func (r *Router) EventsHandler(w http.ResponseWriter, req *http.Request) {
-	data, err := r.Service.GetEventInfo(req.Context())
+	format, query, filter, err := parseEventRequest(req)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	data, err := r.Service.GetEventInfo(listquery.NewContext(req.Context(), query), filter)
+	if err != nil {
+		serviceErrorResponse(w, req, err, i18n.MsgEventsFailed)
+		return
+	}
+	eventPageResponse(w, req, format, query, data)
+}
+
+// EventHistoryHandler consulta o arquivo local de eventos, que guarda eventos além do TTL do Kubernetes.
+// Aceita os mesmos filtros e parâmetros de listagem de /api/v1/events, além de until.
+func (r *Router) EventHistoryHandler(w http.ResponseWriter, req *http.Request) {
+	if r.EventArchive == nil {
+		jsonErrorResponse(w, req, models.ErrorCodeFeatureDisabled, i18n.M(i18n.MsgEventArchiveDisabled), http.StatusNotFound)
+		return
+	}
+	format, query, filter, err := parseEventRequest(req)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	history := archive.Query{Filter: filter}
+	if until := req.URL.Query().Get("until"); until != "" {
+		history.Until, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			jsonErrorResponse(w, req, models.ErrorCodeInvalidParameter, i18n.M(i18n.MsgInvalidParameter, "until", until), http.StatusBadRequest)
+			return
+		}
+	}
+	eventPageResponse(w, req, format, query, r.EventArchive.Query(history))
+}
+
+// eventPageResponse aplica a consulta da listagem aos eventos e responde com a página. Os cabeçalhos de
+// listagem valem para todos os formatos; nos de exportação, apenas os itens são serializados.
+func eventPageResponse(w http.ResponseWriter, req *http.Request, format export.Format, query listquery.Query, events []models.EventInfo) {
+	result, err := listquery.Apply(events, query)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	setListHeaders(w, result.Total, result.Continue)
+	if format != export.FormatJSON {
+		exportResponse(w, req, format, result.Items)
+		return
+	}
+	jsonResponse(w, models.EventPage{Items: result.Items, Total: result.Total, Limit: query.Limit, Continue: result.Continue}, http.StatusOK)
+}
+
+// parseEventRequest lê o formato, a consulta da listagem e os filtros de eventos da requisição.
+func parseEventRequest(req *http.Request) (export.Format, listquery.Query, eventinfo.Filter, error) {
+	format, err := export.Negotiate(req)
+	if err != nil {
+		return format, listquery.Query{}, eventinfo.Filter{}, err
+	}
+	query, err := listquery.Parse(req.URL.Query())
+	if err != nil {
+		return format, query, eventinfo.Filter{}, err
+	}
+	filter, err := parseEventFilter(req)
+	return format, query, filter, err
+}
+
+func parseEventFilter(req *http.Request) (eventinfo.Filter, error) {
+	query := req.URL.Query()
+	filter := eventinfo.Filter{
+		Type:      query.Get("type"),
+		Reason:    query.Get("reason"),
+		Namespace: query.Get("namespace"),
+		Kind:      query.Get("kind"),
+		Name:      query.Get("name"),
+	}
+	if since := query.Get("since"); since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			filter.Since = time.Now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+			filter.Since = t
+		} else {
+			return filter, i18n.Errorf(i18n.MsgInvalidParameter, "since", since)
+		}
+	}
+	return filter, nil
+}
+
+func (r *Router) NamespacesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetNamespaceInfo, i18n.MsgNamespacesFailed)
+}
+
+func (r *Router) ResourceQuotasHandler(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	serveList(w, req, func(ctx context.Context) ([]models.ResourceQuotaInfo, error) {
+		return r.Service.GetResourceQuotaInfo(ctx, namespace)
+	}, i18n.MsgResourceQuotasFailed)
+}
+
+func (r *Router) LimitRangesHandler(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	serveList(w, req, func(ctx context.Context) ([]models.LimitRangeInfo, error) {
+		return r.Service.GetLimitRangeInfo(ctx, namespace)
+	}, i18n.MsgLimitRangesFailed)
+}
+
+func (r *Router) ConfigMapsHandler(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	serveList(w, req, func(ctx context.Context) ([]models.ConfigMapInfo, error) {
+		return r.Service.GetConfigMapInfo(ctx, namespace)
+	}, i18n.MsgConfigMapsFailed)
+}
+
+func (r *Router) ConfigMapDetailHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetConfigMapDetail(req.Context(), req.PathValue("namespace"), req.PathValue("name"))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			jsonErrorResponse(w, req, models.ErrorCodeNotFound, i18n.M(i18n.MsgConfigMapNotFound), http.StatusNotFound)
+			return
+		}
+		serviceErrorResponse(w, req, err, i18n.MsgConfigMapFailed)
+		return
+	}
+	jsonResponse(w, data, http.StatusOK)
+}
+
+func (r *Router) SecretsHandler(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	serveList(w, req, func(ctx context.Context) ([]models.SecretInfo, error) {
+		return r.Service.GetSecretInfo(ctx, namespace)
+	}, i18n.MsgSecretsFailed)
+}
+
+// SecretDetailHandler retorna um Secret com os valores ocultos. Com ?reveal=true os valores são
+// revelados, desde que a revelação esteja habilitada no servidor e o proxy de autenticação informe o usuário;
+// cada tentativa de revelação gera um registro de auditoria.
+func (r *Router) SecretDetailHandler(w http.ResponseWriter, req *http.Request) {
+	namespace, name := req.PathValue("namespace"), req.PathValue("name")
+	reveal := req.URL.Query().Get("reveal") == "true"
+	if reveal && !r.AllowSecretReveal {
+		r.auditLog(req, "revelação NEGADA do Secret %s/%s", namespace, name)
+		jsonErrorResponse(w, req, models.ErrorCodeForbidden, i18n.M(i18n.MsgSecretRevealDisabled), http.StatusForbidden)
+		return
+	}
+	if reveal && r.authenticatedUser(req) == "" {
+		r.auditLog(req, "revelação NEGADA do Secret %s/%s sem usuário autenticado", namespace, name)
+		jsonErrorResponse(w, req, models.ErrorCodeForbidden, i18n.M(i18n.MsgSecretRevealUnauthenticated), http.StatusForbidden)
+		return
+	}
+
+	data, err := r.Service.GetSecretDetail(req.Context(), namespace, name, reveal)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			jsonErrorResponse(w, req, models.ErrorCodeNotFound, i18n.M(i18n.MsgSecretNotFound), http.StatusNotFound)
+			return
+		}
+		serviceErrorResponse(w, req, err, i18n.MsgSecretFailed)
+		return
+	}
+	if reveal {
+		r.auditLog(req, "valores do Secret %s/%s revelados", namespace, name)
+	}
+	jsonResponse(w, data, http.StatusOK)
+}
+
+func (r *Router) APIResourcesHandler(w http.ResponseWriter, req *http.Request) {
+	serveList(w, req, r.Service.GetAPIResources, i18n.MsgAPIResourcesFailed)
+}
+
+// resourceGroup lê o grupo de API da rota; o grupo core (vazio) é representado por "core" na URL.
+func resourceGroup(req *http.Request) string {
+	group := req.PathValue("group")
+	if group == "core" {
+		return ""
+	}
+	return group
+}
+
+// ResourceListHandler lista objetos de qualquer tipo de recurso. A consulta comum de listagem é aplicada às linhas.
+func (r *Router) ResourceListHandler(w http.ResponseWriter, req *http.Request) {
+	format, err := export.Negotiate(req)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	query, err := listquery.Parse(req.URL.Query())
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	ctx := listquery.NewContext(req.Context(), query)
+	data, err := r.Service.GetResourceList(ctx, resourceGroup(req), req.PathValue("version"), req.PathValue("resource"), req.URL.Query().Get("namespace"))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			jsonErrorResponse(w, req, models.ErrorCodeNotFound, i18n.M(i18n.MsgResourceTypeNotFound), http.StatusNotFound)
+			return
+		}
+		serviceErrorResponse(w, req, err, i18n.MsgResourceListFailed)
+		return
+	}
+	result, err := listquery.Apply(data.Items, query)
 	if err != nil {
-		jsonErrorResponse(w, "Falha ao buscar dados dos eventos", http.StatusInternalServerError)
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	setListHeaders(w, result.Total, result.Continue)
+	if format != export.FormatJSON {
+		exportResponse(w, req, format, result.Items)
+		return
+	}
+	data.Items = result.Items
+	jsonResponse(w, data, http.StatusOK)
+}
+
+func (r *Router) ResourceDetailHandler(w http.ResponseWriter, req *http.Request) {
+	data, err := r.Service.GetResourceDetail(req.Context(), resourceGroup(req), req.PathValue("version"), req.PathValue("resource"), req.URL.Query().Get("namespace"), req.PathValue("name"))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			jsonErrorResponse(w, req, models.ErrorCodeNotFound, i18n.M(i18n.MsgResourceNotFound), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrNamespaceRequired) {
+			parameterErrorResponse(w, req, err)
+			return
+		}
+		serviceErrorResponse(w, req, err, i18n.MsgResourceFailed)
 		return
 	}
 	jsonResponse(w, data, http.StatusOK)
@@ -71,15 +354,121 @@ func (r *Router) EventsHandler(w http.ResponseWriter, req *http.Request) {
 
 // --- Funções Utilitárias de Resposta ---
 
+// Cabeçalhos com os metadados de paginação das listagens; o corpo continua sendo a lista de itens.
+const (
+	totalCountHeader = "X-Total-Count"
+	continueHeader   = "X-Continue"
+)
+
+// serveList aplica a consulta comum de listagem (limit/continue, sort, q, labelSelector e fieldSelector)
+// a um endpoint que retorna uma lista, respondendo no formato negociado. Os seletores chegam ao serviço pelo contexto.
+func serveList[T any](w http.ResponseWriter, req *http.Request, fetch func(context.Context) ([]T, error), errMessage i18n.Key) {
+	format, err := export.Negotiate(req)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	query, err := listquery.Parse(req.URL.Query())
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	data, err := fetch(listquery.NewContext(req.Context(), query))
+	if err != nil {
+		serviceErrorResponse(w, req, err, errMessage)
+		return
+	}
+	result, err := listquery.Apply(data, query)
+	if err != nil {
+		parameterErrorResponse(w, req, err)
+		return
+	}
+	setListHeaders(w, result.Total, result.Continue)
+	exportResponse(w, req, format, result.Items)
+}
+
+func setListHeaders(w http.ResponseWriter, total int, continueToken string) {
+	w.Header().Set(totalCountHeader, strconv.Itoa(total))
+	if continueToken != "" {
+		w.Header().Set(continueHeader, continueToken)
+	}
+}
+
+// exportResponse serializa a listagem no formato pedido. Fora do JSON, a resposta é um anexo com o nome do recurso
+// (ex.: pods.csv), pronto para abrir em planilhas.
+func exportResponse[T any](w http.ResponseWriter, req *http.Request, format export.Format, items []T) {
+	if format == export.FormatJSON {
+		jsonResponse(w, items, http.StatusOK)
+		return
+	}
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(req.URL.Path)+"."+string(format)))
+	w.WriteHeader(http.StatusOK)
+	if err := export.Write(w, format, items); err != nil {
+		slog.ErrorContext(req.Context(), "erro ao exportar a listagem", "format", format, logging.Err(err))
+	}
+}
+
 func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Printf("Erro ao codificar resposta JSON: %v", err)
+		slog.Error("erro ao codificar resposta JSON", logging.Err(err))
+	}
+}
+
+// authenticatedUser retorna o usuário informado pelo proxy de autenticação, ou vazio quando não há proxy configurado
+// ou a requisição não traz um usuário. Sem AuthUserHeader, cabeçalhos de identidade vêm do próprio cliente e são ignorados.
+func (r *Router) authenticatedUser(req *http.Request) string {
+	if r.AuthUserHeader == "" {
+		return ""
+	}
+	return strings.TrimSpace(req.Header.Get(r.AuthUserHeader))
+}
+
+// auditLog registra uma ação sensível junto com a identidade informada pelo proxy de autenticação e a origem da requisição.
+func (r *Router) auditLog(req *http.Request, format string, args ...interface{}) {
+	user := r.authenticatedUser(req)
+	if user == "" {
+		user = "anônimo"
 	}
+	slog.InfoContext(req.Context(), "auditoria",
+		"action", fmt.Sprintf(format, args...),
+		"user", user,
+		"remote_addr", req.RemoteAddr,
+	)
 }
 
-func jsonErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	log.Println(message)
-	jsonResponse(w, map[string]string{"error": message}, statusCode)
+// jsonErrorResponse responde com o envelope de erro da API, com a mensagem no idioma da requisição. O identificador
+// da requisição vem do cabeçalho definido pelo middleware RequestID. O log usa sempre o idioma padrão; os atributos
+// extras são acrescentados apenas a ele.
+func jsonErrorResponse(w http.ResponseWriter, req *http.Request, code string, message i18n.Message, statusCode int, logAttrs ...any) {
+	requestID := w.Header().Get(middleware.RequestIDHeader)
+	lang := i18n.FromContext(req.Context())
+	level := slog.LevelWarn
+	if statusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	attrs := append([]any{"code", code, "status", statusCode, "message_key", string(message.Key), logging.KeyRequestID, requestID}, logAttrs...)
+	slog.Log(context.Background(), level, message.String(), attrs...)
+	w.Header().Set("Content-Language", lang)
+	jsonResponse(w, models.ErrorResponse{Error: models.APIError{Code: code, Message: message.In(lang), RequestID: requestID}}, statusCode)
+}
+
+// serviceErrorResponse responde a uma falha do serviço: 504 quando o timeout da requisição expirou, 500 nos demais casos.
+func serviceErrorResponse(w http.ResponseWriter, req *http.Request, err error, key i18n.Key) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		jsonErrorResponse(w, req, models.ErrorCodeTimeout, i18n.M(i18n.MsgTimeout, i18n.M(key)), http.StatusGatewayTimeout, logging.Err(err))
+		return
+	}
+	jsonErrorResponse(w, req, models.ErrorCodeInternal, i18n.M(key), http.StatusInternalServerError, logging.Err(err))
+}
+
+// parameterErrorResponse responde 400 a um parâmetro inválido, traduzindo a mensagem quando o erro a oferece.
+func parameterErrorResponse(w http.ResponseWriter, req *http.Request, err error) {
+	message, ok := i18n.MessageOf(err)
+	if !ok {
+		message = i18n.Text(err.Error())
+	}
+	jsonErrorResponse(w, req, models.ErrorCodeInvalidParameter, message, http.StatusBadRequest)
 }
@@ -5,16 +5,28 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"kubeowl/internal/archive"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/listquery"
+	"kubeowl/internal/middleware"
 	"kubeowl/internal/models"
 	"kubeowl/internal/services"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // TestMain silencia a saída de log durante os testes deste pacote.
@@ -45,6 +57,13 @@ func (m *MockService) GetNodeInfo(ctx context.Context) ([]models.NodeInfo, error
 	}
 	return args.Get(0).([]models.NodeInfo), args.Error(1)
 }
+func (m *MockService) GetNodePoolInfo(ctx context.Context) ([]models.NodePoolInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.NodePoolInfo), args.Error(1)
+}
 func (m *MockService) GetPodInfo(ctx context.Context) ([]models.PodInfo, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -66,6 +85,41 @@ func (m *MockService) GetIngressInfo(ctx context.Context) ([]models.IngressInfo,
 	}
 	return args.Get(0).([]models.IngressInfo), args.Error(1)
 }
+func (m *MockService) GetHealthReport(ctx context.Context) (*models.HealthReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HealthReport), args.Error(1)
+}
+func (m *MockService) GetPolicyReport(ctx context.Context, namespace string) (*models.PolicyReport, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PolicyReport), args.Error(1)
+}
+func (m *MockService) GetRightsizingReport(ctx context.Context, namespace string) (*models.RightsizingReport, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RightsizingReport), args.Error(1)
+}
+func (m *MockService) GetTopology(ctx context.Context, namespace string) (*models.TopologyGraph, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TopologyGraph), args.Error(1)
+}
+func (m *MockService) GetGatewayAPIInfo(ctx context.Context, namespace string) (*models.GatewayAPIInfo, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GatewayAPIInfo), args.Error(1)
+}
 func (m *MockService) GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -73,13 +127,104 @@ func (m *MockService) GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error)
 	}
 	return args.Get(0).([]models.PvcInfo), args.Error(1)
 }
-func (m *MockService) GetEventInfo(ctx context.Context) ([]models.EventInfo, error) {
+func (m *MockService) GetPersistentVolumeInfo(ctx context.Context) ([]models.PersistentVolumeInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PersistentVolumeInfo), args.Error(1)
+}
+func (m *MockService) GetStorageClassInfo(ctx context.Context) ([]models.StorageClassInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StorageClassInfo), args.Error(1)
+}
+func (m *MockService) GetDiskUsage(ctx context.Context) (*models.DiskUsageReport, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*models.DiskUsageReport), args.Error(1)
+}
+func (m *MockService) GetEventInfo(ctx context.Context, filter eventinfo.Filter) ([]models.EventInfo, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]models.EventInfo), args.Error(1)
 }
+func (m *MockService) GetNamespaceInfo(ctx context.Context) ([]models.NamespaceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.NamespaceInfo), args.Error(1)
+}
+func (m *MockService) GetResourceQuotaInfo(ctx context.Context, namespace string) ([]models.ResourceQuotaInfo, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ResourceQuotaInfo), args.Error(1)
+}
+func (m *MockService) GetLimitRangeInfo(ctx context.Context, namespace string) ([]models.LimitRangeInfo, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LimitRangeInfo), args.Error(1)
+}
+func (m *MockService) GetConfigMapInfo(ctx context.Context, namespace string) ([]models.ConfigMapInfo, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ConfigMapInfo), args.Error(1)
+}
+func (m *MockService) GetConfigMapDetail(ctx context.Context, namespace, name string) (*models.ConfigMapInfo, error) {
+	args := m.Called(ctx, namespace, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ConfigMapInfo), args.Error(1)
+}
+func (m *MockService) GetSecretInfo(ctx context.Context, namespace string) ([]models.SecretInfo, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SecretInfo), args.Error(1)
+}
+func (m *MockService) GetSecretDetail(ctx context.Context, namespace, name string, reveal bool) (*models.SecretInfo, error) {
+	args := m.Called(ctx, namespace, name, reveal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SecretInfo), args.Error(1)
+}
+func (m *MockService) GetAPIResources(ctx context.Context) ([]models.APIResourceInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.APIResourceInfo), args.Error(1)
+}
+func (m *MockService) GetResourceList(ctx context.Context, group, version, resource, namespace string) (*models.ResourceListInfo, error) {
+	args := m.Called(ctx, group, version, resource, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ResourceListInfo), args.Error(1)
+}
+func (m *MockService) GetResourceDetail(ctx context.Context, group, version, resource, namespace, name string) (map[string]interface{}, error) {
+	args := m.Called(ctx, group, version, resource, namespace, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]interface{}), args.Error(1)
+}
 
 // TestHandlers_Success utiliza uma tabela de testes para validar todos os cenários de sucesso.
 func TestHandlers_Success(t *testing.T) {
@@ -101,6 +246,33 @@ func TestHandlers_Success(t *testing.T) {
 			},
 			path: "/api/overview",
 		},
+		{
+			name:    "HealthReportHandler Success",
+			handler: router.HealthReportHandler,
+			mockSetup: func() {
+				mockService.On("GetHealthReport", mock.Anything).Return(&models.HealthReport{
+					Summary:  models.HealthSummary{Score: 100, Status: models.HealthStatusHealthy},
+					Findings: []models.HealthFinding{},
+				}, nil).Once()
+			},
+			path: "/api/health-report",
+		},
+		{
+			name:    "PolicyReportHandler Success",
+			handler: router.PolicyReportHandler,
+			mockSetup: func() {
+				mockService.On("GetPolicyReport", mock.Anything, "app-ns").Return(&models.PolicyReport{Workloads: 2, Violations: []models.PolicyViolation{}}, nil).Once()
+			},
+			path: "/api/policy-report?namespace=app-ns",
+		},
+		{
+			name:    "RightsizingHandler Success",
+			handler: router.RightsizingHandler,
+			mockSetup: func() {
+				mockService.On("GetRightsizingReport", mock.Anything, "").Return(&models.RightsizingReport{Window: "24h0m0s", Workloads: []models.WorkloadRecommendation{}}, nil).Once()
+			},
+			path: "/api/rightsizing",
+		},
 		{
 			name:    "NodesHandler Success",
 			handler: router.NodesHandler,
@@ -109,6 +281,14 @@ func TestHandlers_Success(t *testing.T) {
 			},
 			path: "/api/nodes",
 		},
+		{
+			name:    "NodePoolsHandler Success",
+			handler: router.NodePoolsHandler,
+			mockSetup: func() {
+				mockService.On("GetNodePoolInfo", mock.Anything).Return([]models.NodePoolInfo{{Name: "default-pool"}}, nil).Once()
+			},
+			path: "/api/node-pools",
+		},
 		{
 			name:    "PodsHandler Success",
 			handler: router.PodsHandler,
@@ -133,6 +313,22 @@ func TestHandlers_Success(t *testing.T) {
 			},
 			path: "/api/ingresses",
 		},
+		{
+			name:    "TopologyHandler Success",
+			handler: router.TopologyHandler,
+			mockSetup: func() {
+				mockService.On("GetTopology", mock.Anything, "app-ns").Return(&models.TopologyGraph{Nodes: []models.TopologyNode{{ID: "Service/app-ns/web"}}}, nil).Once()
+			},
+			path: "/api/topology?namespace=app-ns",
+		},
+		{
+			name:    "GatewaysHandler Success",
+			handler: router.GatewaysHandler,
+			mockSetup: func() {
+				mockService.On("GetGatewayAPIInfo", mock.Anything, "").Return(&models.GatewayAPIInfo{Installed: true}, nil).Once()
+			},
+			path: "/api/gateways",
+		},
 		{
 			name:    "PvcsHandler Success",
 			handler: router.PvcsHandler,
@@ -141,14 +337,86 @@ func TestHandlers_Success(t *testing.T) {
 			},
 			path: "/api/pvcs",
 		},
+		{
+			name:    "PersistentVolumesHandler Success",
+			handler: router.PersistentVolumesHandler,
+			mockSetup: func() {
+				mockService.On("GetPersistentVolumeInfo", mock.Anything).Return([]models.PersistentVolumeInfo{{Name: "pv-1"}}, nil).Once()
+			},
+			path: "/api/pvs",
+		},
+		{
+			name:    "StorageClassesHandler Success",
+			handler: router.StorageClassesHandler,
+			mockSetup: func() {
+				mockService.On("GetStorageClassInfo", mock.Anything).Return([]models.StorageClassInfo{{Name: "standard"}}, nil).Once()
+			},
+			path: "/api/storageclasses",
+		},
+		{
+			name:    "DiskUsageHandler Success",
+			handler: router.DiskUsageHandler,
+			mockSetup: func() {
+				mockService.On("GetDiskUsage", mock.Anything).Return(&models.DiskUsageReport{}, nil).Once()
+			},
+			path: "/api/disk-usage",
+		},
 		{
 			name:    "EventsHandler Success",
 			handler: router.EventsHandler,
 			mockSetup: func() {
-				mockService.On("GetEventInfo", mock.Anything).Return([]models.EventInfo{{Reason: "Scheduled"}}, nil).Once()
+				mockService.On("GetEventInfo", mock.Anything, eventinfo.Filter{}).Return([]models.EventInfo{{Reason: "Scheduled"}}, nil).Once()
 			},
 			path: "/api/events",
 		},
+		{
+			name:    "NamespacesHandler Success",
+			handler: router.NamespacesHandler,
+			mockSetup: func() {
+				mockService.On("GetNamespaceInfo", mock.Anything).Return([]models.NamespaceInfo{{Name: "app-ns"}}, nil).Once()
+			},
+			path: "/api/namespaces",
+		},
+		{
+			name:    "ResourceQuotasHandler Success",
+			handler: router.ResourceQuotasHandler,
+			mockSetup: func() {
+				mockService.On("GetResourceQuotaInfo", mock.Anything, "app-ns").Return([]models.ResourceQuotaInfo{{Name: "compute"}}, nil).Once()
+			},
+			path: "/api/resourcequotas?namespace=app-ns",
+		},
+		{
+			name:    "LimitRangesHandler Success",
+			handler: router.LimitRangesHandler,
+			mockSetup: func() {
+				mockService.On("GetLimitRangeInfo", mock.Anything, "").Return([]models.LimitRangeInfo{{Name: "defaults"}}, nil).Once()
+			},
+			path: "/api/limitranges",
+		},
+		{
+			name:    "ConfigMapsHandler Success",
+			handler: router.ConfigMapsHandler,
+			mockSetup: func() {
+				mockService.On("GetConfigMapInfo", mock.Anything, "").Return([]models.ConfigMapInfo{{Name: "app-config"}}, nil).Once()
+			},
+			path: "/api/configmaps",
+		},
+		{
+			name:    "SecretsHandler Success",
+			handler: router.SecretsHandler,
+			mockSetup: func() {
+				mockService.On("GetSecretInfo", mock.Anything, "").Return([]models.SecretInfo{{Name: "app-secret"}}, nil).Once()
+			},
+			path: "/api/secrets",
+		},
+		{
+			name:    "APIResourcesHandler Success",
+			handler: router.APIResourcesHandler,
+			mockSetup: func() {
+				mockService.On("GetAPIResources", mock.Anything).Return([]models.APIResourceInfo{{Resource: "certificates"}}, nil).Once()
+			},
+			path: "/api/resources",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -176,8 +444,502 @@ func TestHandler_ServiceError(t *testing.T) {
 	router.NodesHandler(rr, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	var errorResponse map[string]string
+	var errorResponse models.ErrorResponse
 	json.Unmarshal(rr.Body.Bytes(), &errorResponse)
-	assert.Contains(t, errorResponse["error"], "Falha ao buscar dados dos nós")
+	assert.Equal(t, models.ErrorCodeInternal, errorResponse.Error.Code)
+	assert.Contains(t, errorResponse.Error.Message, "Falha ao buscar dados dos nós")
 	mockService.AssertExpectations(t)
+
+	// O timeout da requisição vira 504 com código próprio.
+	mockService.On("GetPodInfo", mock.Anything).Return(nil, context.DeadlineExceeded).Once()
+	rr = httptest.NewRecorder()
+	router.PodsHandler(rr, req)
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	assert.Equal(t, models.ErrorCodeTimeout, errorResponse.Error.Code)
+
+	mockService.On("GetHealthReport", mock.Anything).Return(nil, expectedError).Once()
+	req, _ = http.NewRequest("GET", "/api/v1/health-report", nil)
+	rr = httptest.NewRecorder()
+	router.HealthReportHandler(rr, req)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	assert.Contains(t, errorResponse.Error.Message, "Falha ao gerar o relatório de saúde")
+}
+
+// TestResourceHandlers valida o mapeamento do grupo "core" e o retorno 404 para tipos inexistentes.
+func TestResourceHandlers(t *testing.T) {
+	newRequest := func(path, group, version, resource, name string) *http.Request {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.SetPathValue("group", group)
+		req.SetPathValue("version", version)
+		req.SetPathValue("resource", resource)
+		req.SetPathValue("name", name)
+		return req
+	}
+
+	t.Run("List custom resources", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetResourceList", mock.Anything, "cert-manager.io", "v1", "certificates", "app-ns").
+			Return(&models.ResourceListInfo{Items: []models.ResourceRow{{Name: "web-tls"}}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ResourceListHandler(rr, newRequest("/api/resources/cert-manager.io/v1/certificates?namespace=app-ns", "cert-manager.io", "v1", "certificates", ""))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Core group detail", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetResourceDetail", mock.Anything, "", "v1", "configmaps", "app-ns", "settings").
+			Return(map[string]interface{}{"kind": "ConfigMap"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.ResourceDetailHandler(rr, newRequest("/api/resources/core/v1/configmaps/settings?namespace=app-ns", "core", "v1", "configmaps", "settings"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("System namespace detail", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "kubeadm-config")
+		mockService.On("GetResourceDetail", mock.Anything, "", "v1", "configmaps", "kube-system", "kubeadm-config").Return(nil, notFound).Once()
+
+		rr := httptest.NewRecorder()
+		router.ResourceDetailHandler(rr, newRequest("/api/resources/core/v1/configmaps/kubeadm-config?namespace=kube-system", "core", "v1", "configmaps", "kubeadm-config"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Namespaced detail without namespace", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetResourceDetail", mock.Anything, "", "v1", "configmaps", "", "settings").Return(nil, services.ErrNamespaceRequired).Once()
+
+		rr := httptest.NewRecorder()
+		router.ResourceDetailHandler(rr, newRequest("/api/resources/core/v1/configmaps/settings", "core", "v1", "configmaps", "settings"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), models.ErrorCodeInvalidParameter)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown resource type", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		notFound := apierrors.NewNotFound(schema.GroupResource{Group: "example.com", Resource: "widgets"}, "")
+		mockService.On("GetResourceList", mock.Anything, "example.com", "v1", "widgets", "").Return(nil, notFound).Once()
+
+		rr := httptest.NewRecorder()
+		router.ResourceListHandler(rr, newRequest("/api/resources/example.com/v1/widgets", "example.com", "v1", "widgets", ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+// TestSecretDetailHandler_Reveal valida que a revelação de valores só ocorre quando habilitada no servidor.
+func TestSecretDetailHandler_Reveal(t *testing.T) {
+	newRequest := func(path string) *http.Request {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.SetPathValue("namespace", "app-ns")
+		req.SetPathValue("name", "db-credentials")
+		return req
+	}
+
+	t.Run("Redacted by default", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetSecretDetail", mock.Anything, "app-ns", "db-credentials", false).Return(&models.SecretInfo{Name: "db-credentials"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		router.SecretDetailHandler(rr, newRequest("/api/secrets/app-ns/db-credentials"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reveal forbidden when disabled", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+
+		rr := httptest.NewRecorder()
+		router.SecretDetailHandler(rr, newRequest("/api/secrets/app-ns/db-credentials?reveal=true"))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		mockService.AssertNotCalled(t, "GetSecretDetail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reveal allowed for authenticated user", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		router.AllowSecretReveal = true
+		router.AuthUserHeader = "X-Forwarded-User"
+		mockService.On("GetSecretDetail", mock.Anything, "app-ns", "db-credentials", true).Return(&models.SecretInfo{Name: "db-credentials"}, nil).Once()
+
+		req := newRequest("/api/secrets/app-ns/db-credentials?reveal=true")
+		req.Header.Set("X-Forwarded-User", "alice")
+		rr := httptest.NewRecorder()
+		router.SecretDetailHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reveal denied without identity", func(t *testing.T) {
+		for name, configure := range map[string]func(*Router, *http.Request){
+			// Sem proxy configurado, o cabeçalho enviado pelo próprio cliente não é confiável.
+			"no auth proxy": func(router *Router, req *http.Request) {
+				req.Header.Set("X-Forwarded-User", "alice")
+			},
+			"no user from proxy": func(router *Router, req *http.Request) {
+				router.AuthUserHeader = "X-Forwarded-User"
+				req.Header.Set("X-Remote-User", "alice")
+			},
+		} {
+			mockService := new(MockService)
+			router := NewRouter(nil, mockService)
+			router.AllowSecretReveal = true
+			req := newRequest("/api/secrets/app-ns/db-credentials?reveal=true")
+			configure(router, req)
+
+			rr := httptest.NewRecorder()
+			router.SecretDetailHandler(rr, req)
+			assert.Equal(t, http.StatusForbidden, rr.Code, name)
+			mockService.AssertNotCalled(t, "GetSecretDetail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "db-credentials")
+		mockService.On("GetSecretDetail", mock.Anything, "app-ns", "db-credentials", false).Return(nil, notFound).Once()
+
+		rr := httptest.NewRecorder()
+		router.SecretDetailHandler(rr, newRequest("/api/secrets/app-ns/db-credentials"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestEventsHandler_Filters(t *testing.T) {
+	t.Run("Filters", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		since := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+		expected := eventinfo.Filter{Type: "Warning", Reason: "BackOff", Namespace: "app-ns", Kind: "Pod", Name: "web-0", Since: since}
+		mockService.On("GetEventInfo", mock.Anything, expected).Return([]models.EventInfo{}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/events?type=Warning&reason=BackOff&namespace=app-ns&kind=Pod&name=web-0&since=2024-05-01T03:00:00Z", nil)
+		rr := httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("List query", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		events := []models.EventInfo{{Reason: "BackOff", Count: 3}, {Reason: "Pulled", Count: 1}, {Reason: "BackOff", Count: 7}}
+		mockService.On("GetEventInfo", mock.Anything, eventinfo.Filter{}).Return(events, nil).Twice()
+
+		req, _ := http.NewRequest("GET", "/api/events?q=backoff&sort=-count&limit=1", nil)
+		rr := httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+		var page models.EventPage
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, 1, page.Limit)
+		assert.Equal(t, rr.Header().Get("X-Continue"), page.Continue)
+		if assert.Len(t, page.Items, 1) {
+			assert.Equal(t, int32(7), page.Items[0].Count)
+		}
+
+		req, _ = http.NewRequest("GET", "/api/events?q=backoff&sort=-count&limit=1&continue="+page.Continue, nil)
+		rr = httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+		page = models.EventPage{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		assert.Empty(t, page.Continue)
+		if assert.Len(t, page.Items, 1) {
+			assert.Equal(t, int32(3), page.Items[0].Count)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Relative since", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetEventInfo", mock.Anything, mock.MatchedBy(func(f eventinfo.Filter) bool {
+			return time.Since(f.Since) > 119*time.Minute && time.Since(f.Since) < 121*time.Minute
+		})).Return([]models.EventInfo{}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/events?since=2h", nil)
+		rr := httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	for _, query := range []string{"since=ontem", "limit=-1", "sort=naoexiste"} {
+		t.Run("Invalid "+query, func(t *testing.T) {
+			mockService := new(MockService)
+			router := NewRouter(nil, mockService)
+			mockService.On("GetEventInfo", mock.Anything, mock.Anything).Return([]models.EventInfo{}, nil).Maybe()
+			req, _ := http.NewRequest("GET", "/api/events?"+query, nil)
+			rr := httptest.NewRecorder()
+			router.EventsHandler(rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestEventHistoryHandler(t *testing.T) {
+	t.Run("Archive disabled", func(t *testing.T) {
+		router := NewRouter(nil, new(MockService))
+		req, _ := http.NewRequest("GET", "/api/events/history", nil)
+		rr := httptest.NewRecorder()
+		router.EventHistoryHandler(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	store, err := archive.Open(filepath.Join(t.TempDir(), "events.ndjson"))
+	if err != nil {
+		t.Fatalf("falha ao abrir o arquivo de eventos: %v", err)
+	}
+	defer store.Close()
+	for _, reason := range []string{"BackOff", "Pulled"} {
+		assert.NoError(t, store.Record(&eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: reason, Namespace: "app-ns", UID: types.UID(reason)},
+			Reason:     reason,
+			Note:       reason + " web-0",
+			EventTime:  metav1.NowMicro(),
+		}))
+	}
+	router := NewRouter(nil, new(MockService))
+	router.EventArchive = store
+
+	t.Run("Text search", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/events/history?q=backoff&namespace=app-ns", nil)
+		rr := httptest.NewRecorder()
+		router.EventHistoryHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var page models.EventPage
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		assert.Equal(t, 1, page.Total)
+	})
+
+	t.Run("Invalid until", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/events/history?until=ontem", nil)
+		rr := httptest.NewRecorder()
+		router.EventHistoryHandler(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListHandlers_Query(t *testing.T) {
+	pods := []models.PodInfo{{Name: "web-1", Namespace: "shop"}, {Name: "api-0", Namespace: "orders"}, {Name: "web-0", Namespace: "shop"}}
+
+	t.Run("Sort, search and pagination", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetPodInfo", mock.MatchedBy(func(ctx context.Context) bool {
+			return listquery.ListOptions(ctx).LabelSelector == "app=web"
+		})).Return(pods, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/pods?sort=-name&q=web&limit=1&labelSelector=app%3Dweb", nil)
+		rr := httptest.NewRecorder()
+		router.PodsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+		assert.NotEmpty(t, rr.Header().Get("X-Continue"))
+		var page []models.PodInfo
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		assert.Equal(t, []models.PodInfo{{Name: "web-1", Namespace: "shop"}}, page)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Namespaced list", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetConfigMapInfo", mock.Anything, "shop").Return([]models.ConfigMapInfo{{Name: "b"}, {Name: "a"}}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/configmaps?namespace=shop&sort=name", nil)
+		rr := httptest.NewRecorder()
+		router.ConfigMapsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var page []models.ConfigMapInfo
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		assert.Equal(t, "a", page[0].Name)
+		assert.Empty(t, rr.Header().Get("X-Continue"))
+	})
+
+	t.Run("CSV export keeps filters and sorting", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetPodInfo", mock.Anything).Return(pods, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/v1/pods?format=csv&sort=name&q=web", nil)
+		rr := httptest.NewRecorder()
+		router.PodsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="pods.csv"`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+		assert.Equal(t, "name,namespace,nodeName,status,restarts,usedCpu,usedCpuMilli,usedMemory,usedMemoryBytes\n"+
+			"web-0,shop,,,0,,0,,0\n"+
+			"web-1,shop,,,0,,0,,0\n", rr.Body.String())
+	})
+
+	t.Run("Events CSV export includes every matching event", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		events := make([]models.EventInfo, 120)
+		for i := range events {
+			events[i] = models.EventInfo{Reason: "BackOff", Count: int32(i)}
+		}
+		mockService.On("GetEventInfo", mock.Anything, eventinfo.Filter{}).Return(events, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/v1/events?format=csv&sort=-count", nil)
+		rr := httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "120", rr.Header().Get("X-Total-Count"))
+		assert.Empty(t, rr.Header().Get("X-Continue"))
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		if assert.Len(t, lines, 121) {
+			assert.Contains(t, lines[1], ",119,")
+			assert.Contains(t, lines[120], ",0,")
+		}
+	})
+
+	t.Run("YAML export by Accept header", func(t *testing.T) {
+		mockService := new(MockService)
+		router := NewRouter(nil, mockService)
+		mockService.On("GetEventInfo", mock.Anything, mock.Anything).Return([]models.EventInfo{{Reason: "BackOff"}}, nil).Once()
+
+		req, _ := http.NewRequest("GET", "/api/v1/events", nil)
+		req.Header.Set("Accept", "application/yaml")
+		rr := httptest.NewRecorder()
+		router.EventsHandler(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/yaml", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "- count: 0\n")
+		assert.Contains(t, rr.Body.String(), "  reason: BackOff\n")
+		assert.NotContains(t, rr.Body.String(), "total:")
+	})
+
+	for _, query := range []string{"sort=naoexiste", "labelSelector=app%20in%20(", "limit=abc", "format=xlsx"} {
+		t.Run("Invalid "+query, func(t *testing.T) {
+			mockService := new(MockService)
+			router := NewRouter(nil, mockService)
+			mockService.On("GetPodInfo", mock.Anything).Return(pods, nil).Maybe()
+
+			req, _ := http.NewRequest("GET", "/api/pods?"+query, nil)
+			rr := httptest.NewRecorder()
+			router.PodsHandler(rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestRouter_Handler(t *testing.T) {
+	staticDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(staticDir, "index.html"), []byte("<html></html>"), 0o644))
+
+	mockService := new(MockService)
+	router := NewRouter(nil, mockService)
+	router.StaticDir = staticDir
+	router.AllowedOrigins = []string{"https://grafana.example.com"}
+	mockService.On("GetConfigMapDetail", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	}), "shop", "settings").Return(&models.ConfigMapInfo{Name: "settings", Namespace: "shop"}, nil).Twice()
+	handler := router.Handler()
+
+	t.Run("Path values and middlewares", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/configmaps/shop/settings", nil)
+		req.Header.Set("Origin", "https://grafana.example.com")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("X-Request-ID"))
+		assert.Equal(t, "https://grafana.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, rr.Header().Get("Deprecation"))
+	})
+
+	t.Run("Legacy routes are deprecated aliases", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/configmaps/shop/settings", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "true", rr.Header().Get("Deprecation"))
+		assert.Equal(t, `</api/v1/configmaps/shop/settings>; rel="successor-version"`, rr.Header().Get("Link"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown API route", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/naoexiste", nil)
+		req.Header.Set("X-Request-ID", "abc123")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		var body models.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, models.APIError{Code: models.ErrorCodeRouteNotFound, Message: "Rota não encontrada: /api/v1/naoexiste", RequestID: "abc123"}, body.Error)
+	})
+
+	t.Run("Error messages follow the request language", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/pods?limit=-1", nil)
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "en", rr.Header().Get("Content-Language"))
+		var body models.ErrorResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, `Invalid limit parameter: "-1"`, body.Error.Message)
+
+		// A preferência salva pelo dashboard prevalece sobre o Accept-Language.
+		req = httptest.NewRequest("GET", "/api/v1/naoexiste", nil)
+		req.Header.Set("Accept-Language", "en")
+		req.AddCookie(&http.Cookie{Name: middleware.LanguageCookie, Value: "es"})
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "Ruta no encontrada: /api/v1/naoexiste", body.Error.Message)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/pods", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+
+	t.Run("OpenAPI document", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var doc struct {
+			OpenAPI string                     `json:"openapi"`
+			Paths   map[string]json.RawMessage `json:"paths"`
+		}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+		for _, rt := range router.routes() {
+			assert.Contains(t, doc.Paths, rt.Path)
+		}
+	})
+
+	t.Run("Static files", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "<html>")
+	})
 }
@@ -1,40 +1,184 @@
 package handlers
 
 import (
+	"kubeowl/internal/archive"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/middleware"
+	"kubeowl/internal/models"
+	"kubeowl/internal/openapi"
 	"kubeowl/internal/services"
 	"kubeowl/internal/websocket"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // Router gerencia o roteamento da API.
 type Router struct {
 	hub     *websocket.Hub
 	Service services.Service
+	// AllowSecretReveal habilita a revelação dos valores de Secrets via ?reveal=true. A revelação também exige
+	// um usuário autenticado em AuthUserHeader.
+	AllowSecretReveal bool
+	// AuthUserHeader é o cabeçalho em que o proxy de autenticação à frente do servidor informa o usuário
+	// autenticado. Vazio quando não há proxy: nenhum cabeçalho de identidade é confiável.
+	AuthUserHeader string
+	// EventArchive é o histórico local de eventos; nil quando o arquivo está desabilitado.
+	EventArchive *archive.Store
+	// StaticDir é o diretório com os arquivos da interface web.
+	StaticDir string
+	// AllowedOrigins lista as origens liberadas pelo CORS ("*" libera todas).
+	AllowedOrigins []string
+	// RequestTimeout limita a duração das requisições da API; zero desabilita.
+	RequestTimeout time.Duration
 }
 
+// DefaultRequestTimeout é o tempo máximo padrão de uma requisição da API.
+const DefaultRequestTimeout = 30 * time.Second
+
 // NewRouter cria uma nova instância do Router.
 func NewRouter(hub *websocket.Hub, service services.Service) *Router {
 	return &Router{
-		hub:     hub,
-		Service: service,
+		hub:            hub,
+		Service:        service,
+		StaticDir:      "./web/static",
+		RequestTimeout: DefaultRequestTimeout,
+	}
+}
+
+// APIPrefix é o prefixo da versão atual da API REST.
+const APIPrefix = "/api/v1"
+
+// legacyAPIPrefix é o prefixo das rotas sem versão, mantidas como aliases obsoletos de APIPrefix.
+const legacyAPIPrefix = "/api"
+
+// route associa um caminho da API (relativo a APIPrefix) ao handler e à sua descrição no documento OpenAPI.
+type route struct {
+	openapi.Route
+	handler http.HandlerFunc
+}
+
+var namespaceParam = openapi.QueryParam("namespace", "Restringe o resultado a um namespace.")
+
+var eventFilterParams = []openapi.Parameter{
+	openapi.QueryParam("type", "Tipo do evento (Normal ou Warning)."),
+	openapi.QueryParam("reason", "Motivo do evento."),
+	namespaceParam,
+	openapi.QueryParam("kind", "Kind do objeto envolvido."),
+	openapi.QueryParam("name", "Nome do objeto envolvido."),
+	openapi.QueryParam("since", "Início do período, como duração (ex.: 2h) ou RFC3339."),
+}
+
+// routes é a tabela de rotas da API REST, usada tanto no ServeMux quanto na geração do documento OpenAPI.
+func (r *Router) routes() []route {
+	return []route{
+		{openapi.Route{Path: "/overview", Summary: "Resumo do cluster", Tag: "cluster", Response: models.OverviewResponse{}}, r.OverviewHandler},
+		{openapi.Route{Path: "/health-report", Summary: "Relatório de saúde do cluster", Tag: "cluster", Response: models.HealthReport{}}, r.HealthReportHandler},
+		{openapi.Route{Path: "/policy-report", Summary: "Relatório de boas práticas dos workloads", Tag: "cluster", Response: models.PolicyReport{},
+			Query: []openapi.Parameter{namespaceParam}}, r.PolicyReportHandler},
+		{openapi.Route{Path: "/rightsizing", Summary: "Recomendações de requests e limits a partir do uso observado", Tag: "cluster",
+			Response: models.RightsizingReport{}, Query: []openapi.Parameter{namespaceParam}}, r.RightsizingHandler},
+		{openapi.Route{Path: "/nodes", Summary: "Lista os nós", Tag: "cluster", Response: []models.NodeInfo{}, List: true}, r.NodesHandler},
+		{openapi.Route{Path: "/node-pools", Summary: "Lista os pools de nós", Tag: "cluster", Response: []models.NodePoolInfo{}, List: true}, r.NodePoolsHandler},
+		{openapi.Route{Path: "/pods", Summary: "Lista os pods", Tag: "workloads", Response: []models.PodInfo{}, List: true}, r.PodsHandler},
+		{openapi.Route{Path: "/services", Summary: "Lista os Services", Tag: "rede", Response: []models.ServiceInfo{}, List: true}, r.ServicesHandler},
+		{openapi.Route{Path: "/ingresses", Summary: "Lista os Ingresses", Tag: "rede", Response: []models.IngressInfo{}, List: true}, r.IngressesHandler},
+		{openapi.Route{Path: "/topology", Summary: "Grafo de topologia do tráfego", Tag: "rede", Response: models.TopologyGraph{},
+			Query: []openapi.Parameter{namespaceParam}}, r.TopologyHandler},
+		{openapi.Route{Path: "/gateways", Summary: "Recursos da Gateway API", Tag: "rede", Response: models.GatewayAPIInfo{},
+			Query: []openapi.Parameter{namespaceParam}}, r.GatewaysHandler},
+		{openapi.Route{Path: "/pvcs", Summary: "Lista os PersistentVolumeClaims", Tag: "armazenamento", Response: []models.PvcInfo{}, List: true}, r.PvcsHandler},
+		{openapi.Route{Path: "/pvs", Summary: "Lista os PersistentVolumes", Tag: "armazenamento", Response: []models.PersistentVolumeInfo{}, List: true}, r.PersistentVolumesHandler},
+		{openapi.Route{Path: "/storageclasses", Summary: "Lista os StorageClasses", Tag: "armazenamento", Response: []models.StorageClassInfo{}, List: true}, r.StorageClassesHandler},
+		{openapi.Route{Path: "/disk-usage", Summary: "Ocupação de disco dos nós, volumes e contêineres", Tag: "armazenamento", Response: models.DiskUsageReport{}}, r.DiskUsageHandler},
+		{openapi.Route{Path: "/events", Summary: "Feed de eventos agregados", Tag: "eventos", Response: models.EventPage{}, List: true,
+			Query: eventFilterParams}, r.EventsHandler},
+		{openapi.Route{Path: "/events/history", Summary: "Histórico local de eventos", Tag: "eventos", Response: models.EventPage{}, List: true,
+			Query: append(append([]openapi.Parameter{}, eventFilterParams...),
+				openapi.QueryParam("until", "Fim do período, em RFC3339.")),
+			Errors: []int{http.StatusNotFound}}, r.EventHistoryHandler},
+		{openapi.Route{Path: "/namespaces", Summary: "Lista os namespaces com resumo de saúde", Tag: "cluster", Response: []models.NamespaceInfo{}, List: true}, r.NamespacesHandler},
+		{openapi.Route{Path: "/resourcequotas", Summary: "Lista os ResourceQuotas", Tag: "cluster", Response: []models.ResourceQuotaInfo{}, List: true,
+			Query: []openapi.Parameter{namespaceParam}}, r.ResourceQuotasHandler},
+		{openapi.Route{Path: "/limitranges", Summary: "Lista os LimitRanges", Tag: "cluster", Response: []models.LimitRangeInfo{}, List: true,
+			Query: []openapi.Parameter{namespaceParam}}, r.LimitRangesHandler},
+		{openapi.Route{Path: "/configmaps", Summary: "Lista os ConfigMaps", Tag: "configuração", Response: []models.ConfigMapInfo{}, List: true,
+			Query: []openapi.Parameter{namespaceParam}}, r.ConfigMapsHandler},
+		{openapi.Route{Path: "/configmaps/{namespace}/{name}", Summary: "Detalhe de um ConfigMap", Tag: "configuração", Response: models.ConfigMapInfo{},
+			Errors: []int{http.StatusNotFound}}, r.ConfigMapDetailHandler},
+		{openapi.Route{Path: "/secrets", Summary: "Lista os Secrets, com os valores ocultos", Tag: "configuração", Response: []models.SecretInfo{}, List: true,
+			Query: []openapi.Parameter{namespaceParam}}, r.SecretsHandler},
+		{openapi.Route{Path: "/secrets/{namespace}/{name}", Summary: "Detalhe de um Secret", Tag: "configuração", Response: models.SecretInfo{},
+			Query:  []openapi.Parameter{openapi.QueryParam("reveal", "Com true, revela os valores (requer -allow-secret-reveal e um usuário autenticado pelo proxy).")},
+			Errors: []int{http.StatusForbidden, http.StatusNotFound}}, r.SecretDetailHandler},
+		{openapi.Route{Path: "/resources", Summary: "Tipos de recurso descobertos na API, incluindo CRDs", Tag: "recursos", Response: []models.APIResourceInfo{}, List: true}, r.APIResourcesHandler},
+		{openapi.Route{Path: "/resources/{group}/{version}/{resource}", Summary: "Lista objetos de um tipo de recurso (grupo core como \"core\")", Tag: "recursos",
+			Response: models.ResourceListInfo{}, List: true, Query: []openapi.Parameter{namespaceParam}, Errors: []int{http.StatusNotFound}}, r.ResourceListHandler},
+		{openapi.Route{Path: "/resources/{group}/{version}/{resource}/{name}", Summary: "Objeto completo de um recurso", Tag: "recursos",
+			Query: []openapi.Parameter{namespaceParam}, Errors: []int{http.StatusBadRequest, http.StatusNotFound}}, r.ResourceDetailHandler},
+		{openapi.Route{Path: "/openapi.json", Summary: "Este documento OpenAPI", Tag: "meta"}, nil},
 	}
 }
 
-// RegisterRoutes registra todos os handlers da aplicação.
-func (r *Router) RegisterRoutes() {
-	// Handlers da API REST
-	http.HandleFunc("/api/overview", r.OverviewHandler)
-	http.HandleFunc("/api/nodes", r.NodesHandler)
-	http.HandleFunc("/api/pods", r.PodsHandler)
-	http.HandleFunc("/api/services", r.ServicesHandler)
-	http.HandleFunc("/api/ingresses", r.IngressesHandler)
-	http.HandleFunc("/api/pvcs", r.PvcsHandler)
-	http.HandleFunc("/api/events", r.EventsHandler)
+// Handler monta um ServeMux próprio com todas as rotas da aplicação, envolvido pela cadeia de middlewares.
+// Cada chamada retorna um handler independente, o que permite testar as rotas isoladamente.
+func (r *Router) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	routes := r.routes()
+	docRoutes := make([]openapi.Route, 0, len(routes))
+	for _, rt := range routes {
+		docRoutes = append(docRoutes, rt.Route)
+	}
+	spec := openapi.Build(openapi.Info{
+		Title:       "KubeOwl API",
+		Version:     "v1",
+		Description: "API REST do dashboard KubeOwl. Erros usam o envelope ErrorResponse com códigos estáveis; a mensagem segue o Accept-Language (pt-BR, en ou es).",
+	}, APIPrefix, docRoutes)
+
+	// Handlers da API REST, sob o prefixo versionado e, por compatibilidade, sem versão.
+	for _, rt := range routes {
+		handler := rt.handler
+		if handler == nil {
+			handler = openAPIHandler(spec)
+		}
+		mux.HandleFunc("GET "+APIPrefix+rt.Path, handler)
+		mux.HandleFunc("GET "+legacyAPIPrefix+rt.Path, deprecatedHandler(handler))
+	}
+	mux.HandleFunc("GET "+legacyAPIPrefix+"/", func(w http.ResponseWriter, req *http.Request) {
+		jsonErrorResponse(w, req, models.ErrorCodeRouteNotFound, i18n.M(i18n.MsgRouteNotFound, req.URL.Path), http.StatusNotFound)
+	})
 
 	// Handler do WebSocket
-	http.HandleFunc("/ws", r.ServeWs)
+	mux.HandleFunc("GET /ws", r.ServeWs)
 
 	// Servidor de arquivos estáticos
-	fs := http.FileServer(http.Dir("./web/static"))
-	http.Handle("/", fs)
+	mux.Handle("GET /", http.FileServer(http.Dir(r.StaticDir)))
+
+	return middleware.Chain(mux,
+		middleware.RequestID,
+		middleware.Language,
+		middleware.Logging,
+		middleware.Recover,
+		middleware.CORS(r.AllowedOrigins),
+		middleware.Timeout(r.RequestTimeout),
+		middleware.Gzip,
+	)
+}
+
+// openAPIHandler serve o documento OpenAPI, gerado uma única vez na montagem das rotas.
+func openAPIHandler(spec *openapi.Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		jsonResponse(w, spec, http.StatusOK)
+	}
+}
+
+// deprecatedHandler serve uma rota sem versão, indicando a rota equivalente em APIPrefix.
+func deprecatedHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		successor := APIPrefix + strings.TrimPrefix(req.URL.Path, legacyAPIPrefix)
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+		next(w, req)
+	}
 }
@@ -0,0 +1,155 @@
+// Package i18n traduz as mensagens do servidor (pt-BR, en e es) e negocia o idioma de cada requisição,
+// a partir da preferência salva pelo usuário ou do cabeçalho Accept-Language.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idiomas suportados.
+const (
+	PortugueseBR = "pt-BR"
+	English      = "en"
+	Spanish      = "es"
+
+	// DefaultLanguage é usado quando nenhum idioma aceito pelo cliente é suportado, e também nos logs.
+	DefaultLanguage = PortugueseBR
+)
+
+// Languages lista os idiomas suportados.
+var Languages = []string{PortugueseBR, English, Spanish}
+
+// Key identifica uma mensagem do catálogo.
+type Key string
+
+// Message é uma mensagem do catálogo com os argumentos de formatação. Argumentos que também são mensagens
+// são traduzidos no mesmo idioma.
+type Message struct {
+	Key  Key
+	Args []any
+}
+
+// M cria uma mensagem do catálogo.
+func M(key Key, args ...any) Message {
+	return Message{Key: key, Args: args}
+}
+
+// Text cria uma mensagem fora do catálogo, exibida sem tradução em qualquer idioma.
+func Text(text string) Message {
+	return Message{Key: Key(text)}
+}
+
+// In retorna a mensagem traduzida; sem tradução no idioma pedido, usa o idioma padrão e, por fim, a própria chave.
+func (m Message) In(lang string) string {
+	translations := catalog[m.Key]
+	format, ok := translations[lang]
+	if !ok {
+		format, ok = translations[DefaultLanguage]
+	}
+	if !ok {
+		format = string(m.Key)
+	}
+	if len(m.Args) == 0 {
+		return format
+	}
+	args := make([]any, len(m.Args))
+	for i, arg := range m.Args {
+		if nested, ok := arg.(Message); ok {
+			arg = nested.In(lang)
+		}
+		args[i] = arg
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// String retorna a mensagem no idioma padrão.
+func (m Message) String() string {
+	return m.In(DefaultLanguage)
+}
+
+// Error é um erro com mensagem traduzível, usado por pacotes cujos erros chegam ao cliente da API.
+type Error struct {
+	Message
+}
+
+// Errorf cria um erro traduzível.
+func Errorf(key Key, args ...any) error {
+	return &Error{Message: M(key, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.In(DefaultLanguage)
+}
+
+// MessageOf extrai a mensagem traduzível de um erro, se houver.
+func MessageOf(err error) (Message, bool) {
+	var localized *Error
+	if errors.As(err, &localized) {
+		return localized.Message, true
+	}
+	return Message{}, false
+}
+
+// Match retorna o idioma suportado correspondente a uma tag de idioma (ex.: en-US → en, pt → pt-BR).
+func Match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	base, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	switch base {
+	case "pt":
+		return PortugueseBR, true
+	case "en":
+		return English, true
+	case "es":
+		return Spanish, true
+	}
+	return "", false
+}
+
+// Negotiate escolhe o idioma suportado de maior preferência no cabeçalho Accept-Language.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+		order   int
+	}
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if lang, ok := Match(tag); ok && quality > 0 {
+			candidates = append(candidates, candidate{lang, quality, i})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
+
+type languageKey struct{}
+
+// WithLanguage anexa o idioma da requisição ao contexto.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext retorna o idioma da requisição, ou o idioma padrão.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
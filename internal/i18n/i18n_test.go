@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                             PortugueseBR,
+		"en-US,en;q=0.9":               English,
+		"fr-FR, es;q=0.8, en;q=0.5":    Spanish,
+		"de;q=1.0, en;q=0.2, es;q=0.2": English,
+		"en;q=0, es;q=0.1":             Spanish,
+		"pt-PT":                        PortugueseBR,
+		"fr, de":                       PortugueseBR,
+		"en;q=abc":                     PortugueseBR,
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, Negotiate(header), header)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	lang, ok := Match("ES_mx")
+	assert.True(t, ok)
+	assert.Equal(t, Spanish, lang)
+
+	_, ok = Match("fr")
+	assert.False(t, ok)
+}
+
+func TestMessage_In(t *testing.T) {
+	assert.Equal(t, "ConfigMap not found", M(MsgConfigMapNotFound).In(English))
+	assert.Equal(t, "ConfigMap não encontrado", M(MsgConfigMapNotFound).In("fr"))
+	assert.Equal(t, "chave_desconhecida", M("chave_desconhecida").In(English))
+	assert.Equal(t, `Parámetro limit inválido: "x"`, M(MsgInvalidParameter, "limit", "x").In(Spanish))
+	assert.Equal(t, "Failed to fetch pod data: request timed out", M(MsgTimeout, M(MsgPodsFailed)).In(English))
+}
+
+func TestCatalog_Complete(t *testing.T) {
+	for key, translations := range catalog {
+		for _, lang := range Languages {
+			assert.NotEmpty(t, translations[lang], "%s sem tradução em %s", key, lang)
+		}
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	err := fmt.Errorf("envolvido: %w", Errorf(MsgUnknownSortField, "idade"))
+	assert.Equal(t, `envolvido: Campo de ordenação desconhecido: "idade"`, err.Error())
+
+	msg, ok := MessageOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, `Unknown sort field: "idade"`, msg.In(English))
+
+	_, ok = MessageOf(fmt.Errorf("comum"))
+	assert.False(t, ok)
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, DefaultLanguage, FromContext(context.Background()))
+	assert.Equal(t, English, FromContext(WithLanguage(context.Background(), English)))
+}
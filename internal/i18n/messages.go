@@ -0,0 +1,404 @@
+package i18n
+
+// Chaves das mensagens do servidor.
+const (
+	MsgInternalError        Key = "internal_error"
+	MsgTimeout              Key = "timeout"
+	MsgRouteNotFound        Key = "route_not_found"
+	MsgInvalidParameter     Key = "invalid_parameter"
+	MsgInvalidSelector      Key = "invalid_selector"
+	MsgInvalidContinue      Key = "invalid_continue"
+	MsgUnknownSortField     Key = "unknown_sort_field"
+	MsgUnsortableField      Key = "unsortable_field"
+	MsgEventArchiveDisabled Key = "event_archive_disabled"
+	MsgSecretRevealDisabled Key = "secret_reveal_disabled"
+
+	MsgSecretRevealUnauthenticated Key = "secret_reveal_unauthenticated"
+
+	MsgConfigMapNotFound    Key = "configmap_not_found"
+	MsgSecretNotFound       Key = "secret_not_found"
+	MsgResourceTypeNotFound Key = "resource_type_not_found"
+	MsgResourceNotFound     Key = "resource_not_found"
+
+	MsgOverviewFailed       Key = "overview_failed"
+	MsgNodesFailed          Key = "nodes_failed"
+	MsgNodePoolsFailed      Key = "node_pools_failed"
+	MsgPodsFailed           Key = "pods_failed"
+	MsgServicesFailed       Key = "services_failed"
+	MsgIngressesFailed      Key = "ingresses_failed"
+	MsgTopologyFailed       Key = "topology_failed"
+	MsgGatewaysFailed       Key = "gateways_failed"
+	MsgPvcsFailed           Key = "pvcs_failed"
+	MsgPersistentVolsFailed Key = "persistent_volumes_failed"
+	MsgStorageClassesFailed Key = "storage_classes_failed"
+	MsgDiskUsageFailed      Key = "disk_usage_failed"
+	MsgEventsFailed         Key = "events_failed"
+	MsgNamespacesFailed     Key = "namespaces_failed"
+	MsgResourceQuotasFailed Key = "resource_quotas_failed"
+	MsgLimitRangesFailed    Key = "limit_ranges_failed"
+	MsgConfigMapsFailed     Key = "configmaps_failed"
+	MsgConfigMapFailed      Key = "configmap_failed"
+	MsgSecretsFailed        Key = "secrets_failed"
+	MsgSecretFailed         Key = "secret_failed"
+	MsgAPIResourcesFailed   Key = "api_resources_failed"
+	MsgResourceListFailed   Key = "resource_list_failed"
+	MsgResourceFailed       Key = "resource_failed"
+	MsgHealthReportFailed   Key = "health_report_failed"
+	MsgPolicyReportFailed   Key = "policy_report_failed"
+	MsgRightsizingFailed    Key = "rightsizing_failed"
+)
+
+// Chaves das mensagens dos achados do relatório de saúde.
+const (
+	MsgFindingCrashLoop             Key = "finding_crash_loop"
+	MsgFindingImagePull             Key = "finding_image_pull"
+	MsgFindingPodPending            Key = "finding_pod_pending"
+	MsgFindingDeploymentUnavailable Key = "finding_deployment_unavailable"
+	MsgFindingServiceNoEndpoints    Key = "finding_service_no_endpoints"
+	MsgFindingPvcPending            Key = "finding_pvc_pending"
+	MsgFindingNodeNotReady          Key = "finding_node_not_ready"
+	MsgFindingNodePressure          Key = "finding_node_pressure"
+	MsgFindingMissingRequests       Key = "finding_missing_requests"
+)
+
+// Chaves das descrições e violações das regras de boas práticas.
+const (
+	MsgRuleResources          Key = "rule_resources"
+	MsgRuleProbes             Key = "rule_probes"
+	MsgRuleRunAsRoot          Key = "rule_run_as_root"
+	MsgRuleLatestImage        Key = "rule_latest_image"
+	MsgRulePrivileged         Key = "rule_privileged"
+	MsgRuleHostPath           Key = "rule_host_path"
+	MsgRuleSingleReplica      Key = "rule_single_replica"
+	MsgViolationMissing       Key = "violation_missing"
+	MsgViolationRootUser      Key = "violation_root_user"
+	MsgViolationRootAllowed   Key = "violation_root_allowed"
+	MsgViolationLatestImage   Key = "violation_latest_image"
+	MsgViolationPrivileged    Key = "violation_privileged"
+	MsgViolationHostPath      Key = "violation_host_path"
+	MsgViolationSingleReplica Key = "violation_single_replica"
+)
+
+// catalog guarda as traduções de cada mensagem, no formato de fmt.Sprintf.
+var catalog = map[Key]map[string]string{
+	MsgInternalError: {
+		PortugueseBR: "Erro interno do servidor",
+		English:      "Internal server error",
+		Spanish:      "Error interno del servidor",
+	},
+	MsgTimeout: {
+		PortugueseBR: "%s: tempo limite excedido",
+		English:      "%s: request timed out",
+		Spanish:      "%s: tiempo de espera agotado",
+	},
+	MsgRouteNotFound: {
+		PortugueseBR: "Rota não encontrada: %s",
+		English:      "Route not found: %s",
+		Spanish:      "Ruta no encontrada: %s",
+	},
+	MsgInvalidParameter: {
+		PortugueseBR: "Parâmetro %s inválido: %q",
+		English:      "Invalid %s parameter: %q",
+		Spanish:      "Parámetro %s inválido: %q",
+	},
+	MsgInvalidSelector: {
+		PortugueseBR: "%s inválido: %v",
+		English:      "Invalid %s: %v",
+		Spanish:      "%s inválido: %v",
+	},
+	MsgInvalidContinue: {
+		PortugueseBR: "Token de continuação inválido",
+		English:      "Invalid continue token",
+		Spanish:      "Token de continuación inválido",
+	},
+	MsgUnknownSortField: {
+		PortugueseBR: "Campo de ordenação desconhecido: %q",
+		English:      "Unknown sort field: %q",
+		Spanish:      "Campo de ordenación desconocido: %q",
+	},
+	MsgUnsortableField: {
+		PortugueseBR: "O campo %q não pode ser usado na ordenação",
+		English:      "Field %q cannot be used for sorting",
+		Spanish:      "El campo %q no se puede usar para ordenar",
+	},
+	MsgEventArchiveDisabled: {
+		PortugueseBR: "O arquivo de eventos não está habilitado neste servidor",
+		English:      "The event archive is not enabled on this server",
+		Spanish:      "El archivo de eventos no está habilitado en este servidor",
+	},
+	MsgSecretRevealDisabled: {
+		PortugueseBR: "A revelação de valores de Secrets não está habilitada neste servidor",
+		English:      "Revealing Secret values is not enabled on this server",
+		Spanish:      "La revelación de valores de Secrets no está habilitada en este servidor",
+	},
+	MsgSecretRevealUnauthenticated: {
+		PortugueseBR: "A revelação de valores de Secrets exige um usuário autenticado pelo proxy de autenticação",
+		English:      "Revealing Secret values requires a user authenticated by the auth proxy",
+		Spanish:      "La revelación de valores de Secrets requiere un usuario autenticado por el proxy de autenticación",
+	},
+	MsgConfigMapNotFound: {
+		PortugueseBR: "ConfigMap não encontrado",
+		English:      "ConfigMap not found",
+		Spanish:      "ConfigMap no encontrado",
+	},
+	MsgSecretNotFound: {
+		PortugueseBR: "Secret não encontrado",
+		English:      "Secret not found",
+		Spanish:      "Secret no encontrado",
+	},
+	MsgResourceTypeNotFound: {
+		PortugueseBR: "Tipo de recurso não encontrado",
+		English:      "Resource type not found",
+		Spanish:      "Tipo de recurso no encontrado",
+	},
+	MsgResourceNotFound: {
+		PortugueseBR: "Recurso não encontrado",
+		English:      "Resource not found",
+		Spanish:      "Recurso no encontrado",
+	},
+	MsgOverviewFailed: {
+		PortugueseBR: "Falha ao buscar dados da visão geral",
+		English:      "Failed to fetch overview data",
+		Spanish:      "Error al obtener los datos de la vista general",
+	},
+	MsgNodesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos nós",
+		English:      "Failed to fetch node data",
+		Spanish:      "Error al obtener los datos de los nodos",
+	},
+	MsgNodePoolsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos pools de nós",
+		English:      "Failed to fetch node pool data",
+		Spanish:      "Error al obtener los datos de los pools de nodos",
+	},
+	MsgPodsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos pods",
+		English:      "Failed to fetch pod data",
+		Spanish:      "Error al obtener los datos de los pods",
+	},
+	MsgServicesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos services",
+		English:      "Failed to fetch service data",
+		Spanish:      "Error al obtener los datos de los services",
+	},
+	MsgIngressesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos ingresses",
+		English:      "Failed to fetch ingress data",
+		Spanish:      "Error al obtener los datos de los ingresses",
+	},
+	MsgTopologyFailed: {
+		PortugueseBR: "Falha ao montar a topologia",
+		English:      "Failed to build the topology",
+		Spanish:      "Error al construir la topología",
+	},
+	MsgGatewaysFailed: {
+		PortugueseBR: "Falha ao buscar recursos da Gateway API",
+		English:      "Failed to fetch Gateway API resources",
+		Spanish:      "Error al obtener los recursos de la Gateway API",
+	},
+	MsgPvcsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos PVCs",
+		English:      "Failed to fetch PVC data",
+		Spanish:      "Error al obtener los datos de los PVCs",
+	},
+	MsgPersistentVolsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos PersistentVolumes",
+		English:      "Failed to fetch PersistentVolume data",
+		Spanish:      "Error al obtener los datos de los PersistentVolumes",
+	},
+	MsgStorageClassesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos StorageClasses",
+		English:      "Failed to fetch StorageClass data",
+		Spanish:      "Error al obtener los datos de los StorageClasses",
+	},
+	MsgDiskUsageFailed: {
+		PortugueseBR: "Falha ao buscar dados de ocupação de disco",
+		English:      "Failed to fetch disk usage data",
+		Spanish:      "Error al obtener los datos de uso de disco",
+	},
+	MsgEventsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos eventos",
+		English:      "Failed to fetch events",
+		Spanish:      "Error al obtener los eventos",
+	},
+	MsgNamespacesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos namespaces",
+		English:      "Failed to fetch namespace data",
+		Spanish:      "Error al obtener los datos de los namespaces",
+	},
+	MsgResourceQuotasFailed: {
+		PortugueseBR: "Falha ao buscar dados dos ResourceQuotas",
+		English:      "Failed to fetch ResourceQuota data",
+		Spanish:      "Error al obtener los datos de los ResourceQuotas",
+	},
+	MsgLimitRangesFailed: {
+		PortugueseBR: "Falha ao buscar dados dos LimitRanges",
+		English:      "Failed to fetch LimitRange data",
+		Spanish:      "Error al obtener los datos de los LimitRanges",
+	},
+	MsgConfigMapsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos ConfigMaps",
+		English:      "Failed to fetch ConfigMap data",
+		Spanish:      "Error al obtener los datos de los ConfigMaps",
+	},
+	MsgConfigMapFailed: {
+		PortugueseBR: "Falha ao buscar dados do ConfigMap",
+		English:      "Failed to fetch the ConfigMap",
+		Spanish:      "Error al obtener el ConfigMap",
+	},
+	MsgSecretsFailed: {
+		PortugueseBR: "Falha ao buscar dados dos Secrets",
+		English:      "Failed to fetch Secret data",
+		Spanish:      "Error al obtener los datos de los Secrets",
+	},
+	MsgSecretFailed: {
+		PortugueseBR: "Falha ao buscar dados do Secret",
+		English:      "Failed to fetch the Secret",
+		Spanish:      "Error al obtener el Secret",
+	},
+	MsgAPIResourcesFailed: {
+		PortugueseBR: "Falha ao listar os tipos de recurso da API",
+		English:      "Failed to list the API resource types",
+		Spanish:      "Error al listar los tipos de recurso de la API",
+	},
+	MsgResourceListFailed: {
+		PortugueseBR: "Falha ao listar os recursos",
+		English:      "Failed to list the resources",
+		Spanish:      "Error al listar los recursos",
+	},
+	MsgResourceFailed: {
+		PortugueseBR: "Falha ao buscar o recurso",
+		English:      "Failed to fetch the resource",
+		Spanish:      "Error al obtener el recurso",
+	},
+	MsgHealthReportFailed: {
+		PortugueseBR: "Falha ao gerar o relatório de saúde",
+		English:      "Failed to build the health report",
+		Spanish:      "Error al generar el informe de salud",
+	},
+	MsgFindingCrashLoop: {
+		PortugueseBR: "Contêiner %s em CrashLoopBackOff (%d reinícios)",
+		English:      "Container %s in CrashLoopBackOff (%d restarts)",
+		Spanish:      "Contenedor %s en CrashLoopBackOff (%d reinicios)",
+	},
+	MsgFindingImagePull: {
+		PortugueseBR: "Contêiner %s não consegue baixar a imagem %s (%s)",
+		English:      "Container %s cannot pull image %s (%s)",
+		Spanish:      "El contenedor %s no puede descargar la imagen %s (%s)",
+	},
+	MsgFindingPodPending: {
+		PortugueseBR: "Pod pendente há %s",
+		English:      "Pod pending for %s",
+		Spanish:      "Pod pendiente desde hace %s",
+	},
+	MsgFindingDeploymentUnavailable: {
+		PortugueseBR: "%d de %d réplicas indisponíveis",
+		English:      "%d of %d replicas unavailable",
+		Spanish:      "%d de %d réplicas no disponibles",
+	},
+	MsgFindingServiceNoEndpoints: {
+		PortugueseBR: "Nenhum endpoint pronto para o seletor %s",
+		English:      "No ready endpoints for selector %s",
+		Spanish:      "Ningún endpoint listo para el selector %s",
+	},
+	MsgFindingPvcPending: {
+		PortugueseBR: "PersistentVolumeClaim pendente há %s",
+		English:      "PersistentVolumeClaim pending for %s",
+		Spanish:      "PersistentVolumeClaim pendiente desde hace %s",
+	},
+	MsgFindingNodeNotReady: {
+		PortugueseBR: "Nó não está pronto: %s",
+		English:      "Node is not ready: %s",
+		Spanish:      "El nodo no está listo: %s",
+	},
+	MsgFindingNodePressure: {
+		PortugueseBR: "Nó sob pressão: %s",
+		English:      "Node under pressure: %s",
+		Spanish:      "Nodo bajo presión: %s",
+	},
+	MsgFindingMissingRequests: {
+		PortugueseBR: "Contêineres sem requests de CPU ou memória: %s",
+		English:      "Containers without CPU or memory requests: %s",
+		Spanish:      "Contenedores sin requests de CPU o memoria: %s",
+	},
+	MsgPolicyReportFailed: {
+		PortugueseBR: "Falha ao gerar o relatório de boas práticas",
+		English:      "Failed to build the policy report",
+		Spanish:      "Error al generar el informe de buenas prácticas",
+	},
+	MsgRightsizingFailed: {
+		PortugueseBR: "Falha ao calcular as recomendações de dimensionamento",
+		English:      "Failed to compute the right-sizing recommendations",
+		Spanish:      "Error al calcular las recomendaciones de dimensionamiento",
+	},
+	MsgRuleResources: {
+		PortugueseBR: "Contêineres devem declarar requests e limits de CPU e memória",
+		English:      "Containers must declare CPU and memory requests and limits",
+		Spanish:      "Los contenedores deben declarar requests y limits de CPU y memoria",
+	},
+	MsgRuleProbes: {
+		PortugueseBR: "Contêineres de serviços devem declarar readinessProbe e livenessProbe",
+		English:      "Service containers must declare a readinessProbe and a livenessProbe",
+		Spanish:      "Los contenedores de servicios deben declarar readinessProbe y livenessProbe",
+	},
+	MsgRuleRunAsRoot: {
+		PortugueseBR: "Contêineres não devem executar como root",
+		English:      "Containers must not run as root",
+		Spanish:      "Los contenedores no deben ejecutarse como root",
+	},
+	MsgRuleLatestImage: {
+		PortugueseBR: "Imagens devem usar uma tag fixa em vez de :latest",
+		English:      "Images must use a pinned tag instead of :latest",
+		Spanish:      "Las imágenes deben usar una etiqueta fija en lugar de :latest",
+	},
+	MsgRulePrivileged: {
+		PortugueseBR: "Contêineres não devem executar em modo privilegiado",
+		English:      "Containers must not run privileged",
+		Spanish:      "Los contenedores no deben ejecutarse en modo privilegiado",
+	},
+	MsgRuleHostPath: {
+		PortugueseBR: "Pods não devem montar diretórios do nó (hostPath)",
+		English:      "Pods must not mount node directories (hostPath)",
+		Spanish:      "Los pods no deben montar directorios del nodo (hostPath)",
+	},
+	MsgRuleSingleReplica: {
+		PortugueseBR: "Deployments com uma única réplica precisam de um PodDisruptionBudget",
+		English:      "Single-replica Deployments need a PodDisruptionBudget",
+		Spanish:      "Los Deployments con una sola réplica necesitan un PodDisruptionBudget",
+	},
+	MsgViolationMissing: {
+		PortugueseBR: "Sem %s",
+		English:      "Missing %s",
+		Spanish:      "Sin %s",
+	},
+	MsgViolationRootUser: {
+		PortugueseBR: "Executa com runAsUser 0",
+		English:      "Runs with runAsUser 0",
+		Spanish:      "Se ejecuta con runAsUser 0",
+	},
+	MsgViolationRootAllowed: {
+		PortugueseBR: "Não define runAsNonRoot nem um runAsUser diferente de 0",
+		English:      "Sets neither runAsNonRoot nor a non-zero runAsUser",
+		Spanish:      "No define runAsNonRoot ni un runAsUser distinto de 0",
+	},
+	MsgViolationLatestImage: {
+		PortugueseBR: "A imagem %s não tem uma tag fixa",
+		English:      "Image %s is not pinned to a tag",
+		Spanish:      "La imagen %s no tiene una etiqueta fija",
+	},
+	MsgViolationPrivileged: {
+		PortugueseBR: "Executa em modo privilegiado",
+		English:      "Runs privileged",
+		Spanish:      "Se ejecuta en modo privilegiado",
+	},
+	MsgViolationHostPath: {
+		PortugueseBR: "O volume %s monta o caminho %s do nó",
+		English:      "Volume %s mounts the node path %s",
+		Spanish:      "El volumen %s monta la ruta %s del nodo",
+	},
+	MsgViolationSingleReplica: {
+		PortugueseBR: "Uma única réplica e nenhum PodDisruptionBudget",
+		English:      "A single replica and no PodDisruptionBudget",
+		Spanish:      "Una sola réplica y ningún PodDisruptionBudget",
+	},
+}
@@ -2,10 +2,9 @@ package k8s
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
+	"log/slog"
 
+	"k8s.io/client-go/dynamic"    // Fornece a interface dynamic.Interface
 	"k8s.io/client-go/kubernetes" // Fornece a interface kubernetes.Interface
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -16,6 +15,9 @@ var (
 	// Clientset permite interagir com os recursos principais do Kubernetes.
 	Clientset kubernetes.Interface
 
+	// DynamicClient permite listar recursos sem tipos gerados, como os CRDs da Gateway API.
+	DynamicClient dynamic.Interface
+
 	// MetricsClientset permite buscar métricas de uso de recursos.
 	MetricsClientset versioned.Interface
 
@@ -30,19 +32,15 @@ func InitClient() error {
 
 	// Tenta usar a configuração de dentro do cluster através da nossa variável de função.
 	if config, err = InClusterConfigFunc(); err != nil {
-		log.Println("Não está em um cluster. Usando o kubeconfig local.")
-		homeDir, errHome := os.UserHomeDir()
-		if errHome != nil {
-			return fmt.Errorf("falha ao encontrar o diretório home: %w", errHome)
-		}
-		kubeconfig := filepath.Join(homeDir, ".kube", "config")
-		// Usa o kubeconfig local como fallback
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		// Usa o kubeconfig local como fallback, com as mesmas regras do kubectl (KUBECONFIG com vários caminhos ou ~/.kube/config).
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		slog.Info("fora de um cluster; usando o kubeconfig local", "kubeconfig", loadingRules.GetLoadingPrecedence())
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
 		if err != nil {
 			return fmt.Errorf("falha ao carregar kubeconfig: %w", err)
 		}
 	} else {
-		log.Println("Rodando dentro do cluster.")
+		slog.Info("rodando dentro do cluster")
 	}
 
 	// Cria o clientset principal.
@@ -51,6 +49,12 @@ func InitClient() error {
 		return fmt.Errorf("falha ao criar clientset do Kubernetes: %w", err)
 	}
 
+	// Cria o cliente dinâmico para recursos opcionais (CRDs).
+	DynamicClient, err = dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("falha ao criar cliente dinâmico do Kubernetes: %w", err)
+	}
+
 	// Cria o clientset para métricas.
 	MetricsClientset, err = versioned.NewForConfig(config)
 	if err != nil {
@@ -58,4 +62,4 @@ func InitClient() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
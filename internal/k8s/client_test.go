@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/client-go/rest"
@@ -110,4 +111,54 @@ func TestInitClient_NoConfigAvailable(t *testing.T) {
 	if err == nil {
 		t.Error("Esperado um erro quando nenhuma configuração do kubernetes está disponível, mas não ocorreu")
 	}
-}
\ No newline at end of file
+}
+
+// TestInitClient_KubeconfigList valida que KUBECONFIG com vários caminhos é mesclado como no kubectl.
+func TestInitClient_KubeconfigList(t *testing.T) {
+	originalInClusterConfig := InClusterConfigFunc
+	defer func() { InClusterConfigFunc = originalInClusterConfig }()
+
+	InClusterConfigFunc = func() (*rest.Config, error) {
+		return nil, fmt.Errorf("forçado: não está em um cluster")
+	}
+
+	// O cluster e o contexto ficam em um arquivo e o usuário em outro; um caminho inexistente é ignorado.
+	dir := t.TempDir()
+	clusters := filepath.Join(dir, "clusters")
+	users := filepath.Join(dir, "users")
+	files := map[string]string{
+		clusters: `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: http://localhost:8080
+  name: test-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: test-user
+  name: test-context
+current-context: test-context
+`,
+		users: `
+apiVersion: v1
+kind: Config
+users:
+- name: test-user
+  user:
+    token: abc
+`,
+	}
+	for path, data := range files {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Falha ao criar arquivo kubeconfig falso: %v", err)
+		}
+	}
+	missing := filepath.Join(dir, "missing")
+	t.Setenv("KUBECONFIG", strings.Join([]string{missing, clusters, users}, string(filepath.ListSeparator)))
+
+	if err := InitClient(); err != nil {
+		t.Errorf("InitClient retornou um erro inesperado com KUBECONFIG de vários caminhos: %v", err)
+	}
+}
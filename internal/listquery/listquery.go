@@ -0,0 +1,327 @@
+// Package listquery implementa a camada de consulta comum aos endpoints de listagem: paginação com
+// limit/continue, ordenação por campos, busca textual e repasse de labelSelector/fieldSelector à API do Kubernetes.
+package listquery
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"kubeowl/internal/i18n"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SortKey é um campo de ordenação, identificado pelo nome JSON (com pontos para campos aninhados).
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Query descreve os parâmetros de uma listagem.
+type Query struct {
+	// Limit é o tamanho da página; zero retorna todos os itens.
+	Limit         int
+	Sort          []SortKey
+	Search        string
+	LabelSelector string
+	FieldSelector string
+	offset        int
+}
+
+// Result é uma página de itens. Continue fica vazio na última página.
+type Result[T any] struct {
+	Items    []T
+	Total    int
+	Continue string
+}
+
+// Parse lê limit, continue, sort (ex.: sort=namespace,-cpuUsage), q, labelSelector e fieldSelector.
+func Parse(values url.Values) (Query, error) {
+	q := Query{
+		Search:        strings.TrimSpace(values.Get("q")),
+		LabelSelector: values.Get("labelSelector"),
+		FieldSelector: values.Get("fieldSelector"),
+	}
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			return q, i18n.Errorf(i18n.MsgInvalidParameter, "limit", raw)
+		}
+		q.Limit = limit
+	}
+	if raw := values.Get("sort"); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			field = strings.TrimSpace(field)
+			key := SortKey{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+			if key.Field == "" {
+				return q, i18n.Errorf(i18n.MsgInvalidParameter, "sort", raw)
+			}
+			q.Sort = append(q.Sort, key)
+		}
+	}
+	if q.LabelSelector != "" {
+		if _, err := labels.Parse(q.LabelSelector); err != nil {
+			return q, i18n.Errorf(i18n.MsgInvalidSelector, "labelSelector", err)
+		}
+	}
+	if q.FieldSelector != "" {
+		if _, err := fields.ParseSelector(q.FieldSelector); err != nil {
+			return q, i18n.Errorf(i18n.MsgInvalidSelector, "fieldSelector", err)
+		}
+	}
+	if token := values.Get("continue"); token != "" {
+		offset, err := q.decodeContinue(token)
+		if err != nil {
+			return q, err
+		}
+		q.offset = offset
+	}
+	return q, nil
+}
+
+// fingerprint identifica a ordenação, a busca e os seletores, para que um token de continuação
+// não seja usado com uma consulta diferente da que o gerou.
+func (q Query) fingerprint() uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%v|%s|%s|%s", q.Sort, q.Search, q.LabelSelector, q.FieldSelector)
+	return h.Sum32()
+}
+
+func (q Query) encodeContinue(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", offset, q.fingerprint())))
+}
+
+func (q Query) decodeContinue(token string) (int, error) {
+	invalid := i18n.Errorf(i18n.MsgInvalidContinue)
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, invalid
+	}
+	offsetPart, fingerprintPart, ok := strings.Cut(string(raw), ":")
+	if !ok || fingerprintPart != strconv.FormatUint(uint64(q.fingerprint()), 10) {
+		return 0, invalid
+	}
+	offset, err := strconv.Atoi(offsetPart)
+	if err != nil || offset < 0 {
+		return 0, invalid
+	}
+	return offset, nil
+}
+
+type contextKey struct{}
+
+// NewContext anexa a consulta ao contexto, para que o serviço repasse os seletores à API do Kubernetes.
+func NewContext(ctx context.Context, q Query) context.Context {
+	return context.WithValue(ctx, contextKey{}, q)
+}
+
+// ListOptions retorna as opções de listagem com os seletores da consulta presente no contexto.
+// Deve ser usada apenas na listagem do recurso principal de cada endpoint.
+func ListOptions(ctx context.Context) metav1.ListOptions {
+	q, _ := ctx.Value(contextKey{}).(Query)
+	return metav1.ListOptions{LabelSelector: q.LabelSelector, FieldSelector: q.FieldSelector}
+}
+
+// Apply aplica a busca, a ordenação e a paginação aos itens. A ordenação é estável, preservando a ordem
+// original entre itens iguais.
+func Apply[T any](items []T, q Query) (Result[T], error) {
+	itemType := reflect.TypeOf((*T)(nil)).Elem()
+	accessors := make([][]int, 0, len(q.Sort))
+	for _, key := range q.Sort {
+		index, err := fieldIndex(itemType, key.Field)
+		if err != nil {
+			return Result[T]{}, err
+		}
+		accessors = append(accessors, index)
+	}
+
+	var filtered []T
+	if q.Search != "" {
+		term := strings.ToLower(q.Search)
+		filtered = make([]T, 0, len(items))
+		for _, item := range items {
+			if containsText(reflect.ValueOf(item), term, 0) {
+				filtered = append(filtered, item)
+			}
+		}
+	} else {
+		filtered = append([]T(nil), items...)
+	}
+
+	if len(q.Sort) > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := reflect.ValueOf(filtered[i]), reflect.ValueOf(filtered[j])
+			for k, key := range q.Sort {
+				c := compare(fieldByIndex(a, accessors[k]), fieldByIndex(b, accessors[k]))
+				if c == 0 {
+					continue
+				}
+				if key.Desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+
+	result := Result[T]{Total: len(filtered), Items: []T{}}
+	start := min(q.offset, len(filtered))
+	end := len(filtered)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+		result.Continue = q.encodeContinue(end)
+	}
+	result.Items = append(result.Items, filtered[start:end]...)
+	return result, nil
+}
+
+// fieldIndex resolve um caminho de nomes JSON (ex.: "metrics.cpu") para os índices dos campos da struct.
+func fieldIndex(t reflect.Type, path string) ([]int, error) {
+	var index []int
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, i18n.Errorf(i18n.MsgUnknownSortField, path)
+		}
+		found := false
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if jsonName(field) == name {
+				index = append(index, i)
+				t = field.Type
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i18n.Errorf(i18n.MsgUnknownSortField, path)
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return index, nil
+	}
+	return nil, i18n.Errorf(i18n.MsgUnsortableField, path)
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldByIndex percorre os índices, tratando ponteiros nulos como valor inválido.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compare retorna -1, 0 ou 1. Valores ausentes ficam antes dos presentes; textos não diferenciam maiúsculas.
+func compare(a, b reflect.Value) int {
+	if !a.IsValid() || !b.IsValid() {
+		switch {
+		case a.IsValid():
+			return 1
+		case b.IsValid():
+			return -1
+		}
+		return 0
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		if b.Bool() {
+			return -1
+		}
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmpOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmpOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmpOrdered(a.Float(), b.Float())
+	}
+	return 0
+}
+
+func cmpOrdered[N int64 | uint64 | float64](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// maxSearchDepth limita a recursão da busca textual em estruturas aninhadas.
+const maxSearchDepth = 4
+
+// containsText procura o termo (já em minúsculas) em qualquer texto do item, inclusive em listas,
+// mapas (chaves e valores) e structs aninhadas.
+func containsText(v reflect.Value, term string, depth int) bool {
+	if depth > maxSearchDepth || !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strings.Contains(strings.ToLower(v.String()), term)
+	case reflect.Pointer, reflect.Interface:
+		return !v.IsNil() && containsText(v.Elem(), term, depth)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && containsText(v.Field(i), term, depth+1) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if containsText(v.Index(i), term, depth+1) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if containsText(iter.Key(), term, depth+1) || containsText(iter.Value(), term, depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package listquery
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetrics struct {
+	CPU float64 `json:"cpu"`
+}
+
+type testItem struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Restarts  int32             `json:"restarts"`
+	Ready     bool              `json:"ready"`
+	Labels    map[string]string `json:"labels"`
+	Metrics   *testMetrics      `json:"metrics"`
+}
+
+var testItems = []testItem{
+	{Name: "web-1", Namespace: "shop", Restarts: 3, Metrics: &testMetrics{CPU: 0.5}},
+	{Name: "api-0", Namespace: "orders", Restarts: 0, Ready: true, Labels: map[string]string{"tier": "backend"}},
+	{Name: "Web-0", Namespace: "shop", Restarts: 3, Ready: true, Metrics: &testMetrics{CPU: 1.5}},
+}
+
+func names(items []testItem) []string {
+	out := []string{}
+	for _, item := range items {
+		out = append(out, item.Name)
+	}
+	return out
+}
+
+func mustParse(t *testing.T, raw string) Query {
+	values, _ := url.ParseQuery(raw)
+	q, err := Parse(values)
+	if err != nil {
+		t.Fatalf("consulta %q inválida: %v", raw, err)
+	}
+	return q
+}
+
+func TestApply_Sort(t *testing.T) {
+	result, err := Apply(testItems, mustParse(t, "sort=-restarts,name"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Web-0", "web-1", "api-0"}, names(result.Items))
+
+	// Ponteiros nulos ficam antes dos valores presentes.
+	result, err = Apply(testItems, mustParse(t, "sort=metrics.cpu"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api-0", "web-1", "Web-0"}, names(result.Items))
+
+	_, err = Apply(testItems, mustParse(t, "sort=unknown"))
+	assert.Error(t, err)
+	_, err = Apply(testItems, mustParse(t, "sort=labels"))
+	assert.Error(t, err)
+
+	// A lista original não é alterada.
+	assert.Equal(t, "web-1", testItems[0].Name)
+}
+
+func TestApply_Search(t *testing.T) {
+	result, err := Apply(testItems, mustParse(t, "q=WEB"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+
+	result, err = Apply(testItems, mustParse(t, "q=backend"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api-0"}, names(result.Items))
+}
+
+func TestApply_Pagination(t *testing.T) {
+	first, err := Apply(testItems, mustParse(t, "limit=2&sort=name"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, first.Total)
+	assert.Equal(t, []string{"api-0", "Web-0"}, names(first.Items))
+	assert.NotEmpty(t, first.Continue)
+
+	second, err := Apply(testItems, mustParse(t, "limit=2&sort=name&continue="+first.Continue))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web-1"}, names(second.Items))
+	assert.Empty(t, second.Continue)
+
+	// O token só vale para a mesma ordenação, busca e seletores.
+	values, _ := url.ParseQuery("limit=2&sort=-name&continue=" + first.Continue)
+	_, err = Parse(values)
+	assert.Error(t, err)
+
+	all, err := Apply(testItems, Query{})
+	assert.NoError(t, err)
+	assert.Len(t, all.Items, 3)
+	assert.Empty(t, all.Continue)
+
+	empty, err := Apply([]testItem(nil), Query{})
+	assert.NoError(t, err)
+	assert.NotNil(t, empty.Items)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, raw := range []string{"limit=-1", "limit=abc", "sort=,", "labelSelector=app in (", "fieldSelector=a==b==c", "continue=???"} {
+		values, _ := url.ParseQuery(raw)
+		_, err := Parse(values)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	assert.Empty(t, ListOptions(context.Background()).LabelSelector)
+
+	ctx := NewContext(context.Background(), mustParse(t, "labelSelector=app%3Dweb&fieldSelector=status.phase%3DRunning"))
+	opts := ListOptions(ctx)
+	assert.Equal(t, "app=web", opts.LabelSelector)
+	assert.Equal(t, "status.phase=Running", opts.FieldSelector)
+}
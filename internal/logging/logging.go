@@ -0,0 +1,108 @@
+// Package logging configura o log estruturado (log/slog) da aplicação e propaga o identificador da requisição
+// pelo contexto, para que os logs dos handlers, serviços e watchers possam ser correlacionados.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Chaves padronizadas dos campos de log.
+const (
+	KeyRequestID = "request_id"
+	KeyResource  = "resource"
+	KeyNamespace = "namespace"
+	KeyDuration  = "duration_ms"
+	KeyError     = "error"
+)
+
+// Formatos de saída suportados.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID anexa o identificador da requisição ao contexto.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID retorna o identificador da requisição, ou vazio fora de uma requisição HTTP.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Err padroniza o campo de erro.
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
+
+// Duration padroniza o campo de duração, em milissegundos.
+func Duration(d time.Duration) slog.Attr {
+	return slog.Float64(KeyDuration, float64(d.Microseconds())/1000)
+}
+
+// ParseLevel converte debug, info, warn ou error no nível correspondente.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		return l, fmt.Errorf("nível de log inválido: %q", level)
+	}
+	return l, nil
+}
+
+// New cria um logger no formato informado (text ou json) que inclui o request_id presente no contexto.
+func New(w io.Writer, level slog.Level, format string) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("formato de log inválido: %q (use text ou json)", format)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// Setup configura o logger padrão, usado também pelas mensagens que ainda passam pelo pacote log.
+func Setup(w io.Writer, level, format string) error {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	logger, err := New(w, l, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// contextHandler acrescenta o request_id do contexto a cada registro.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String(KeyRequestID, id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	for input, expected := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn, " error ": slog.LevelError} {
+		level, err := ParseLevel(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, level, input)
+	}
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, slog.LevelInfo, "json")
+	if err != nil {
+		t.Fatalf("falha ao criar o logger: %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	logger.With(KeyResource, "pods").InfoContext(ctx, "listagem", KeyNamespace, "shop", Duration(1500*time.Microsecond), Err(errors.New("falhou")))
+	logger.DebugContext(ctx, "ignorada abaixo do nível")
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "listagem", record["msg"])
+	assert.Equal(t, "abc123", record[KeyRequestID])
+	assert.Equal(t, "pods", record[KeyResource])
+	assert.Equal(t, "shop", record[KeyNamespace])
+	assert.Equal(t, 1.5, record[KeyDuration])
+	assert.Equal(t, "falhou", record[KeyError])
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, slog.LevelDebug, "text")
+	if err != nil {
+		t.Fatalf("falha ao criar o logger: %v", err)
+	}
+	logger.Debug("sem requisição")
+	assert.Contains(t, buf.String(), `msg="sem requisição"`)
+	assert.NotContains(t, buf.String(), KeyRequestID)
+
+	_, err = New(&buf, slog.LevelInfo, "xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,305 @@
+// Package middleware reúne os tratamentos transversais aplicados a todas as requisições HTTP:
+// identificação, idioma, log, recuperação de pânicos, timeout, CORS e compressão.
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Middleware envolve um handler com um comportamento adicional.
+type Middleware func(http.Handler) http.Handler
+
+// Chain aplica os middlewares ao handler; o primeiro da lista é o mais externo.
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// RequestIDHeader é o cabeçalho usado para receber e devolver o identificador da requisição.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limita o tamanho de identificadores recebidos de proxies.
+const maxRequestIDLength = 128
+
+// RequestID reaproveita o X-Request-ID recebido (por exemplo, do ingress) ou gera um novo,
+// devolvendo-o na resposta e disponibilizando-o no contexto.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, req.WithContext(logging.WithRequestID(req.Context(), id)))
+	})
+}
+
+// RequestIDFromContext retorna o identificador da requisição, ou vazio fora de uma requisição HTTP.
+func RequestIDFromContext(ctx context.Context) string {
+	return logging.RequestID(ctx)
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
+
+// LanguageCookie guarda o idioma escolhido pelo usuário no dashboard; tem precedência sobre o Accept-Language.
+const LanguageCookie = "kubeowl-lang"
+
+// Language resolve o idioma das mensagens da requisição, pelo cookie LanguageCookie ou pelo cabeçalho
+// Accept-Language, e o disponibiliza no contexto.
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		lang := i18n.Negotiate(req.Header.Get("Accept-Language"))
+		if cookie, err := req.Cookie(LanguageCookie); err == nil {
+			if preferred, ok := i18n.Match(cookie.Value); ok {
+				lang = preferred
+			}
+		}
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, req.WithContext(i18n.WithLanguage(req.Context(), lang)))
+	})
+}
+
+// statusRecorder guarda o status da resposta para o log, preservando Hijack (WebSocket) e Flush.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("a resposta não suporta hijack")
+	}
+	if r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Logging registra método, caminho, status, tamanho e duração de cada requisição. O request_id vem do contexto.
+// Respostas 5xx são registradas como erro.
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, req)
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(req.Context(), level, "requisição HTTP",
+			"method", req.Method,
+			"path", req.URL.RequestURI(),
+			"status", recorder.status,
+			"bytes", recorder.bytes,
+			logging.Duration(time.Since(start)),
+		)
+	})
+}
+
+// Recover converte um pânico em um handler em resposta 500, registrando a pilha no log.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+				slog.ErrorContext(req.Context(), "pânico ao tratar a requisição",
+					"method", req.Method, "path", req.URL.Path, "panic", err, "stack", string(debug.Stack()))
+				lang := i18n.FromContext(req.Context())
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Content-Language", lang)
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(models.ErrorResponse{Error: models.APIError{
+					Code:      models.ErrorCodeInternal,
+					Message:   i18n.M(i18n.MsgInternalError).In(lang),
+					RequestID: RequestIDFromContext(req.Context()),
+				}})
+			}
+		}()
+		next.ServeHTTP(w, req)
+	})
+}
+
+func isWebSocketUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
+
+// Timeout limita a duração do contexto da requisição, cancelando as chamadas ao cluster que demorarem demais.
+// Conexões WebSocket não são afetadas. Um timeout zero desabilita o middleware.
+func Timeout(timeout time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if isWebSocketUpgrade(req) {
+				next.ServeHTTP(w, req)
+				return
+			}
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// CORS libera o acesso de outras origens permitidas (use "*" para qualquer origem) e responde às requisições
+// de preflight. Sem origens configuradas, apenas a própria origem do dashboard é aceita pelo navegador.
+func CORS(allowedOrigins []string) Middleware {
+	allowAll := false
+	allowed := map[string]bool{}
+	for _, origin := range allowedOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			allowAll = true
+		}
+		if origin != "" {
+			allowed[origin] = true
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		if len(allowed) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+			if origin == "" || !(allowAll || allowed[origin]) {
+				next.ServeHTTP(w, req)
+				return
+			}
+			h := w.Header()
+			h.Set("Access-Control-Allow-Origin", origin)
+			h.Add("Vary", "Origin")
+			h.Set("Access-Control-Expose-Headers", "X-Request-ID, X-Total-Count, X-Continue")
+			if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+				h.Set("Access-Control-Allow-Headers", "Content-Type, X-Request-ID")
+				h.Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// gzipResponseWriter comprime o corpo da resposta. Respostas sem corpo (204, 304) seguem sem compressão.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+	compress    bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+	if status != http.StatusNoContent && status != http.StatusNotModified {
+		g.compress = true
+		// O tamanho original não vale para o corpo comprimido.
+		g.Header().Del("Content-Length")
+		g.Header().Set("Content-Encoding", "gzip")
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(http.StatusOK)
+	}
+	if !g.compress {
+		return g.ResponseWriter.Write(b)
+	}
+	return g.gz.Write(b)
+}
+
+func (g *gzipResponseWriter) Flush() {
+	if g.compress {
+		_ = g.gz.Flush()
+	}
+	if flusher, ok := g.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Gzip comprime as respostas quando o cliente aceita gzip. WebSocket e requisições com Range não são comprimidos.
+func Gzip(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if isWebSocketUpgrade(req) || req.Header.Get("Range") != "" || !acceptsGzip(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		gz := gzip.NewWriter(w)
+		writer := &gzipResponseWriter{ResponseWriter: w, gz: gz}
+		next.ServeHTTP(writer, req)
+		if writer.compress {
+			_ = gz.Close()
+		}
+	})
+}
+
+func acceptsGzip(req *http.Request) bool {
+	for _, encoding := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.EqualFold(name, "gzip") {
+			return true
+		}
+	}
+	return false
+}
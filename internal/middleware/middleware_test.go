@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"kubeowl/internal/i18n"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMain silencia a saída de log durante os testes deste pacote.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestChain_Order(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, req)
+			})
+		}
+	}
+	handler := Chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { order = append(order, "handler") }), mark("a"), mark("b"))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, []string{"a", "b", "handler"}, order)
+}
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = RequestIDFromContext(req.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Len(t, seen, 16)
+	assert.Equal(t, seen, rr.Header().Get(RequestIDHeader))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "do-ingress")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "do-ingress", seen)
+
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+func TestLanguage(t *testing.T) {
+	var seen string
+	handler := Language(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = i18n.FromContext(req.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, i18n.PortugueseBR, seen)
+	assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "es-AR,es;q=0.9,en;q=0.8")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, i18n.Spanish, seen)
+
+	req.AddCookie(&http.Cookie{Name: LanguageCookie, Value: "en"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, i18n.English, seen)
+}
+
+func TestRecover(t *testing.T) {
+	handler := Logging(Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("falha") })))
+	rr := httptest.NewRecorder()
+	assert.NotPanics(t, func() { handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/pods", nil)) })
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"internal_error"`)
+
+	req := httptest.NewRequest("GET", "/api/pods", nil)
+	req.Header.Set("Accept-Language", "en")
+	rr = httptest.NewRecorder()
+	Language(handler).ServeHTTP(rr, req)
+	assert.Contains(t, rr.Body.String(), `"message":"Internal server error"`)
+	assert.Equal(t, i18n.English, rr.Header().Get("Content-Language"))
+}
+
+func TestTimeout(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		deadline, hasDeadline = req.Context().Deadline()
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/pods", nil))
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, time.Second)
+
+	req := httptest.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Upgrade", "websocket")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.False(t, hasDeadline)
+}
+
+func TestCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := CORS([]string{"https://grafana.example.com"})(next)
+
+	preflight := httptest.NewRequest("OPTIONS", "/api/pods", nil)
+	preflight.Header.Set("Origin", "https://grafana.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", "GET")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, preflight)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://grafana.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+
+	other := httptest.NewRequest("GET", "/api/pods", nil)
+	other.Header.Set("Origin", "https://evil.example.com")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, other)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+
+	rr = httptest.NewRecorder()
+	CORS([]string{"*"})(next).ServeHTTP(rr, other)
+	assert.Equal(t, "https://evil.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestGzip(t *testing.T) {
+	body := strings.Repeat(`{"name":"pod"}`, 100)
+	handler := Gzip(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Length", "1400")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest("GET", "/api/pods", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.9")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	assert.Empty(t, rr.Header().Get("Content-Length"))
+	reader, err := gzip.NewReader(rr.Body)
+	if assert.NoError(t, err) {
+		decoded, _ := io.ReadAll(reader)
+		assert.Equal(t, body, string(decoded))
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/pods", nil))
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, rr.Body.String())
+
+	notModified := Gzip(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { w.WriteHeader(http.StatusNotModified) }))
+	rr = httptest.NewRecorder()
+	notModified.ServeHTTP(rr, req)
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Zero(t, rr.Body.Len())
+}
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"cmp"
+	"slices"
+)
+
 // OverviewResponse contém os dados para a tela principal do dashboard.
 type OverviewResponse struct {
 	IsRunningInCluster bool                `json:"isRunningInCluster"`
@@ -7,37 +12,142 @@ type OverviewResponse struct {
 	NamespaceCount     int                 `json:"namespaceCount"`
 	NodeCount          int                 `json:"nodeCount"`
 	Capacity           ClusterCapacityInfo `json:"capacity"`
+	QuotaAlerts        []QuotaAlert        `json:"quotaAlerts"`
+	// Health resume o relatório de saúde; ausente quando o relatório não pôde ser gerado.
+	Health *HealthSummary `json:"health,omitempty"`
 }
 
 // ServiceInfo contém informações formatadas sobre um Service.
 type ServiceInfo struct {
-	Name       string `json:"name"`
-	Namespace  string `json:"namespace"`
-	Type       string `json:"type"`
-	ClusterIP  string `json:"clusterIp"`
-	ExternalIP string `json:"externalIp"`
-	Ports      string `json:"ports"`
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Type              string            `json:"type"`
+	ClusterIP         string            `json:"clusterIp"`
+	ExternalIPs       []string          `json:"externalIps"`
+	ExternalName      string            `json:"externalName,omitempty"`
+	Ports             []ServicePortInfo `json:"ports"`
+	Selector          map[string]string `json:"selector"`
+	SessionAffinity   string            `json:"sessionAffinity"`
+	ReadyEndpoints    int               `json:"readyEndpoints"`
+	NotReadyEndpoints int               `json:"notReadyEndpoints"`
+}
+
+// ServicePortInfo descreve uma porta de um Service.
+type ServicePortInfo struct {
+	Name       string `json:"name,omitempty"`
+	Protocol   string `json:"protocol"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"targetPort"`
+	NodePort   int32  `json:"nodePort,omitempty"`
 }
 
 // IngressInfo contém informações sobre um Ingress.
 type IngressInfo struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Hosts     string `json:"hosts"`
-	Service   string `json:"service"`
+	Name            string              `json:"name"`
+	Namespace       string              `json:"namespace"`
+	IngressClass    string              `json:"ingressClass"`
+	ClassController string              `json:"classController,omitempty"`
+	Hosts           []string            `json:"hosts"`
+	Rules           []IngressRuleInfo   `json:"rules"`
+	DefaultBackend  *IngressBackendInfo `json:"defaultBackend,omitempty"`
+	TLS             []IngressTLSInfo    `json:"tls"`
+	Addresses       []string            `json:"addresses"`
+}
+
+// IngressRuleInfo descreve uma regra de um Ingress com todos os seus caminhos.
+type IngressRuleInfo struct {
+	Host  string            `json:"host"`
+	Paths []IngressPathInfo `json:"paths"`
+}
+
+// IngressPathInfo descreve um caminho HTTP de uma regra de Ingress.
+type IngressPathInfo struct {
+	Path     string             `json:"path"`
+	PathType string             `json:"pathType"`
+	Backend  IngressBackendInfo `json:"backend"`
+}
+
+// IngressBackendInfo descreve o destino de um caminho: um Service (com porta) ou um recurso arbitrário.
+type IngressBackendInfo struct {
+	Service  string `json:"service,omitempty"`
+	Port     string `json:"port,omitempty"`
+	Resource string `json:"resource,omitempty"`
+}
+
+// IngressTLSInfo descreve uma entrada TLS de um Ingress.
+type IngressTLSInfo struct {
+	Hosts      []string `json:"hosts"`
+	SecretName string   `json:"secretName"`
 }
 
 // NodeInfo contém informações sobre um nó do cluster.
 type NodeInfo struct {
-	Name                  string  `json:"name"`
-	Role                  string  `json:"role"`
-	PodCount              int     `json:"podCount"`
-	TotalCPU              string  `json:"totalCpu"`
-	UsedCPU               string  `json:"usedCpu"`
-	CPUUsagePercentage    float64 `json:"cpuUsagePercentage"`
-	TotalMemory           string  `json:"totalMemory"`
-	UsedMemory            string  `json:"usedMemory"`
-	MemoryUsagePercentage float64 `json:"memoryUsagePercentage"`
+	Name                  string              `json:"name"`
+	Role                  string              `json:"role"`
+	Roles                 []string            `json:"roles"`
+	Pool                  string              `json:"pool"`
+	PodCount              int                 `json:"podCount"`
+	TotalCPU              string              `json:"totalCpu"`
+	UsedCPU               string              `json:"usedCpu"`
+	CPUUsagePercentage    float64             `json:"cpuUsagePercentage"`
+	TotalMemory           string              `json:"totalMemory"`
+	UsedMemory            string              `json:"usedMemory"`
+	MemoryUsagePercentage float64             `json:"memoryUsagePercentage"`
+	Filesystem            *FilesystemUsage    `json:"filesystem,omitempty"`
+	ImageFilesystem       *FilesystemUsage    `json:"imageFilesystem,omitempty"`
+	Ready                 bool                `json:"ready"`
+	MemoryPressure        bool                `json:"memoryPressure"`
+	DiskPressure          bool                `json:"diskPressure"`
+	PIDPressure           bool                `json:"pidPressure"`
+	Unschedulable         bool                `json:"unschedulable"`
+	Conditions            []NodeConditionInfo `json:"conditions"`
+	Taints                []TaintInfo         `json:"taints"`
+	KubeletVersion        string              `json:"kubeletVersion"`
+	KernelVersion         string              `json:"kernelVersion"`
+	ContainerRuntime      string              `json:"containerRuntime"`
+	OSImage               string              `json:"osImage"`
+	OperatingSystem       string              `json:"operatingSystem"`
+	Architecture          string              `json:"architecture"`
+	CreatedAt             string              `json:"createdAt"`
+	Age                   string              `json:"age"`
+	InternalIPs           []string            `json:"internalIps"`
+	ExternalIPs           []string            `json:"externalIps"`
+	Hostname              string              `json:"hostname"`
+	Zone                  string              `json:"zone"`
+	Region                string              `json:"region"`
+	AllocatablePods       int64               `json:"allocatablePods"`
+	RunningPods           int                 `json:"runningPods"`
+}
+
+// NodePoolInfo agrupa os nós de um mesmo pool (node group) e resume sua capacidade.
+type NodePoolInfo struct {
+	Name                  string   `json:"name"`
+	NodeCount             int      `json:"nodeCount"`
+	ReadyNodes            int      `json:"readyNodes"`
+	Roles                 []string `json:"roles"`
+	Nodes                 []string `json:"nodes"`
+	TotalCPU              int64    `json:"totalCpu"`
+	UsedCPU               int64    `json:"usedCpu"`
+	CPUUsagePercentage    float64  `json:"cpuUsagePercentage"`
+	TotalMemory           int64    `json:"totalMemory"`
+	UsedMemory            int64    `json:"usedMemory"`
+	MemoryUsagePercentage float64  `json:"memoryUsagePercentage"`
+}
+
+// NodeConditionInfo descreve uma condição reportada pelo kubelet (Ready, MemoryPressure, etc.).
+type NodeConditionInfo struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+}
+
+// TaintInfo descreve um taint aplicado a um nó.
+type TaintInfo struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
 }
 
 // PodInfo contém informações sobre um pod.
@@ -53,21 +163,343 @@ type PodInfo struct {
 	UsedMemoryBytes int64  `json:"usedMemoryBytes"`
 }
 
-// EventInfo contém informações sobre um evento do cluster.
+// EventInfo contém informações sobre um evento do cluster. Eventos repetidos são agregados:
+// Count soma as ocorrências e FirstSeen/LastSeen delimitam o intervalo observado.
 type EventInfo struct {
-	Timestamp string `json:"timestamp"`
-	Type      string `json:"type"`
-	Reason    string `json:"reason"`
-	Object    string `json:"object"`
-	Message   string `json:"message"`
+	Timestamp           string         `json:"timestamp"`
+	Type                string         `json:"type"`
+	Reason              string         `json:"reason"`
+	Object              string         `json:"object"`
+	Message             string         `json:"message"`
+	Namespace           string         `json:"namespace"`
+	Count               int32          `json:"count"`
+	FirstSeen           string         `json:"firstSeen"`
+	LastSeen            string         `json:"lastSeen"`
+	ReportingController string         `json:"reportingController"`
+	ReportingInstance   string         `json:"reportingInstance"`
+	InvolvedObject      EventObjectRef `json:"involvedObject"`
+}
+
+// EventObjectRef identifica o objeto ao qual um evento se refere.
+type EventObjectRef struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	UID        string `json:"uid"`
+}
+
+// EventPage é uma página de eventos filtrados, com o total antes da paginação. Continue é o token da próxima
+// página, vazio na última; Limit é o tamanho de página pedido, com zero para todos os eventos.
+type EventPage struct {
+	Items    []EventInfo `json:"items"`
+	Total    int         `json:"total"`
+	Limit    int         `json:"limit"`
+	Continue string      `json:"continue,omitempty"`
 }
 
 // PvcInfo contém informações sobre um PersistentVolumeClaim.
 type PvcInfo struct {
+	Name          string           `json:"name"`
+	Namespace     string           `json:"namespace"`
+	Status        string           `json:"status"`
+	Capacity      string           `json:"capacity"`
+	BoundCapacity string           `json:"boundCapacity"`
+	VolumeName    string           `json:"volumeName"`
+	StorageClass  string           `json:"storageClass"`
+	AccessModes   []string         `json:"accessModes"`
+	VolumeMode    string           `json:"volumeMode"`
+	ReclaimPolicy string           `json:"reclaimPolicy"`
+	UsedBy        []string         `json:"usedBy"`
+	Usage         *FilesystemUsage `json:"usage,omitempty"`
+}
+
+// PersistentVolumeInfo contém informações sobre um PersistentVolume.
+type PersistentVolumeInfo struct {
+	Name          string   `json:"name"`
+	Status        string   `json:"status"`
+	Capacity      string   `json:"capacity"`
+	AccessModes   []string `json:"accessModes"`
+	VolumeMode    string   `json:"volumeMode"`
+	ReclaimPolicy string   `json:"reclaimPolicy"`
+	StorageClass  string   `json:"storageClass"`
+	Claim         string   `json:"claim"`
+	Source        string   `json:"source"`
+	Reason        string   `json:"reason"`
+}
+
+// StorageClassInfo contém informações sobre um StorageClass.
+type StorageClassInfo struct {
+	Name                 string            `json:"name"`
+	Provisioner          string            `json:"provisioner"`
+	ReclaimPolicy        string            `json:"reclaimPolicy"`
+	VolumeBindingMode    string            `json:"volumeBindingMode"`
+	AllowVolumeExpansion bool              `json:"allowVolumeExpansion"`
+	IsDefault            bool              `json:"isDefault"`
+	Parameters           map[string]string `json:"parameters"`
+	VolumeCount          int               `json:"volumeCount"`
+}
+
+// NamespaceInfo resume a saúde de um namespace do cluster.
+type NamespaceInfo struct {
+	Name            string              `json:"name"`
+	Phase           string              `json:"phase"`
+	Labels          map[string]string   `json:"labels"`
+	PodCount        int                 `json:"podCount"`
+	PodsByStatus    map[string]int      `json:"podsByStatus"`
+	UsedCPU         string              `json:"usedCpu"`
+	UsedCPUMilli    int64               `json:"usedCpuMilli"`
+	UsedMemory      string              `json:"usedMemory"`
+	UsedMemoryBytes int64               `json:"usedMemoryBytes"`
+	Quotas          []ResourceQuotaInfo `json:"quotas"`
+	LimitRanges     []LimitRangeInfo    `json:"limitRanges"`
+	WarningEvents   []EventInfo         `json:"warningEvents"`
+}
+
+// ResourceQuotaInfo contém o consumo de um ResourceQuota.
+type ResourceQuotaInfo struct {
+	Name      string               `json:"name"`
+	Namespace string               `json:"namespace"`
+	Resources []QuotaResourceUsage `json:"resources"`
+}
+
+// QuotaResourceUsage compara o limite (hard) e o uso de um recurso do quota.
+type QuotaResourceUsage struct {
+	Resource   string  `json:"resource"`
+	Hard       string  `json:"hard"`
+	Used       string  `json:"used"`
+	Percentage float64 `json:"percentage"`
+}
+
+// QuotaAlert sinaliza um recurso de ResourceQuota cujo uso ultrapassou o limite de alerta.
+type QuotaAlert struct {
+	Namespace  string  `json:"namespace"`
+	Quota      string  `json:"quota"`
+	Resource   string  `json:"resource"`
+	Hard       string  `json:"hard"`
+	Used       string  `json:"used"`
+	Percentage float64 `json:"percentage"`
+}
+
+// LimitRangeInfo contém as restrições definidas por um LimitRange.
+type LimitRangeInfo struct {
+	Name      string               `json:"name"`
+	Namespace string               `json:"namespace"`
+	Limits    []LimitRangeItemInfo `json:"limits"`
+}
+
+// LimitRangeItemInfo descreve os limites de um recurso para um tipo de objeto.
+type LimitRangeItemInfo struct {
+	Type                 string `json:"type"`
+	Resource             string `json:"resource"`
+	Min                  string `json:"min,omitempty"`
+	Max                  string `json:"max,omitempty"`
+	Default              string `json:"default,omitempty"`
+	DefaultRequest       string `json:"defaultRequest,omitempty"`
+	MaxLimitRequestRatio string `json:"maxLimitRequestRatio,omitempty"`
+}
+
+// ConfigMapInfo contém informações sobre um ConfigMap e os pods que o utilizam.
+type ConfigMapInfo struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	KeyCount  int              `json:"keyCount"`
+	TotalSize int              `json:"totalSize"`
+	Keys      []DataKeyInfo    `json:"keys"`
+	UsedBy    []ConfigUsageRef `json:"usedBy"`
+}
+
+// SecretInfo contém informações sobre um Secret e os pods que o utilizam. Os valores são omitidos por padrão.
+type SecretInfo struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Type      string           `json:"type"`
+	KeyCount  int              `json:"keyCount"`
+	TotalSize int              `json:"totalSize"`
+	Keys      []DataKeyInfo    `json:"keys"`
+	UsedBy    []ConfigUsageRef `json:"usedBy"`
+}
+
+// DataKeyInfo descreve uma chave de um ConfigMap ou Secret.
+type DataKeyInfo struct {
+	Name     string `json:"name"`
+	Size     int    `json:"size"`
+	Binary   bool   `json:"binary,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"`
+}
+
+// ConfigUsageRef indica como um pod referencia um ConfigMap ou Secret.
+type ConfigUsageRef struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	Kind      string `json:"kind"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// FilesystemUsage descreve a ocupação de um sistema de arquivos ou volume, conforme reportado pelo kubelet.
+type FilesystemUsage struct {
+	UsedBytes       int64   `json:"usedBytes"`
+	CapacityBytes   int64   `json:"capacityBytes"`
+	AvailableBytes  int64   `json:"availableBytes"`
+	UsagePercentage float64 `json:"usagePercentage"`
+	InodesUsed      int64   `json:"inodesUsed,omitempty"`
+	Inodes          int64   `json:"inodes,omitempty"`
+}
+
+// DiskUsageReport reúne a ocupação de disco de nós, volumes e contêineres para identificar pressão de disco.
+type DiskUsageReport struct {
+	Nodes      []NodeDiskUsage      `json:"nodes"`
+	Volumes    []VolumeDiskUsage    `json:"volumes"`
+	Containers []ContainerDiskUsage `json:"containers"`
+}
+
+// NodeDiskUsage contém a ocupação dos sistemas de arquivos de um nó.
+type NodeDiskUsage struct {
+	Node            string           `json:"node"`
+	DiskPressure    bool             `json:"diskPressure"`
+	StatsAvailable  bool             `json:"statsAvailable"`
+	Filesystem      *FilesystemUsage `json:"filesystem,omitempty"`
+	ImageFilesystem *FilesystemUsage `json:"imageFilesystem,omitempty"`
+}
+
+// VolumeDiskUsage contém a ocupação de um volume montado em um pod.
+type VolumeDiskUsage struct {
+	Namespace string          `json:"namespace"`
+	Pod       string          `json:"pod"`
+	Node      string          `json:"node"`
+	Volume    string          `json:"volume"`
+	Pvc       string          `json:"pvc,omitempty"`
+	Usage     FilesystemUsage `json:"usage"`
+}
+
+// ContainerDiskUsage contém a ocupação do sistema de arquivos raiz e dos logs de um contêiner.
+type ContainerDiskUsage struct {
+	Namespace string           `json:"namespace"`
+	Pod       string           `json:"pod"`
+	Node      string           `json:"node"`
+	Container string           `json:"container"`
+	Rootfs    *FilesystemUsage `json:"rootfs,omitempty"`
+	Logs      *FilesystemUsage `json:"logs,omitempty"`
+}
+
+// GatewayAPIInfo reúne os recursos da Gateway API (gateway.networking.k8s.io) encontrados no cluster.
+// Installed é falso quando os CRDs não estão presentes.
+type GatewayAPIInfo struct {
+	Installed      bool               `json:"installed"`
+	Version        string             `json:"version,omitempty"`
+	GatewayClasses []GatewayClassInfo `json:"gatewayClasses"`
+	Gateways       []GatewayInfo      `json:"gateways"`
+	HTTPRoutes     []RouteInfo        `json:"httpRoutes"`
+	GRPCRoutes     []RouteInfo        `json:"grpcRoutes"`
+}
+
+// GatewayClassInfo contém informações sobre uma GatewayClass.
+type GatewayClassInfo struct {
+	Name        string `json:"name"`
+	Controller  string `json:"controller"`
+	Description string `json:"description,omitempty"`
+	Accepted    string `json:"accepted"`
+}
+
+// GatewayInfo contém informações sobre um Gateway e seus listeners.
+type GatewayInfo struct {
+	Name           string                `json:"name"`
+	Namespace      string                `json:"namespace"`
+	GatewayClass   string                `json:"gatewayClass"`
+	Addresses      []string              `json:"addresses"`
+	Listeners      []GatewayListenerInfo `json:"listeners"`
+	AttachedRoutes int32                 `json:"attachedRoutes"`
+	Accepted       string                `json:"accepted"`
+	Programmed     string                `json:"programmed"`
+}
+
+// GatewayListenerInfo descreve um listener de um Gateway.
+type GatewayListenerInfo struct {
+	Name           string `json:"name"`
+	Protocol       string `json:"protocol"`
+	Port           int32  `json:"port"`
+	Hostname       string `json:"hostname,omitempty"`
+	AttachedRoutes int32  `json:"attachedRoutes"`
+	Programmed     string `json:"programmed"`
+}
+
+// RouteInfo contém informações sobre uma HTTPRoute ou GRPCRoute.
+type RouteInfo struct {
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Hostnames  []string          `json:"hostnames"`
+	ParentRefs []RouteParentInfo `json:"parentRefs"`
+	Backends   []string          `json:"backends"`
+}
+
+// RouteParentInfo descreve um Gateway ao qual a rota se associa e o estado dessa associação.
+type RouteParentInfo struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	SectionName  string `json:"sectionName,omitempty"`
+	Accepted     string `json:"accepted"`
+	ResolvedRefs string `json:"resolvedRefs"`
+}
+
+// APIResourceInfo descreve um tipo de recurso servido pelo API server, nativo ou definido por CRD.
+type APIResourceInfo struct {
+	Group      string `json:"group"`
+	Version    string `json:"version"`
+	Resource   string `json:"resource"`
+	Kind       string `json:"kind"`
+	Namespaced bool   `json:"namespaced"`
+	Custom     bool   `json:"custom"`
+}
+
+// PrinterColumn é uma coluna de exibição, vinda do additionalPrinterColumns do CRD.
+type PrinterColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	JSONPath    string `json:"jsonPath"`
+	Description string `json:"description,omitempty"`
+}
+
+// ResourceListInfo contém os objetos de um tipo de recurso com os valores das colunas de exibição.
+type ResourceListInfo struct {
+	Resource APIResourceInfo `json:"resource"`
+	Columns  []PrinterColumn `json:"columns"`
+	Items    []ResourceRow   `json:"items"`
+}
+
+// ResourceRow é um objeto listado no navegador genérico de recursos.
+type ResourceRow struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+	Age       string   `json:"age"`
+	Cells     []string `json:"cells"`
+}
+
+// TopologyGraph representa o caminho do tráfego Ingress → Service → EndpointSlice → Pod → Nó, com os workloads donos dos pods.
+type TopologyGraph struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+// TopologyNode é um vértice do grafo de topologia.
+type TopologyNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
 	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Status    string `json:"status"`
-	Capacity  string `json:"capacity"`
+	Namespace string `json:"namespace,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Broken    bool   `json:"broken"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// TopologyEdge liga dois vértices do grafo de topologia.
+type TopologyEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Label  string `json:"label,omitempty"`
+	Broken bool   `json:"broken"`
 }
 
 // ClusterCapacityInfo resume o uso de recursos de todo o cluster.
@@ -80,8 +512,201 @@ type ClusterCapacityInfo struct {
 	MemoryUsagePercentage float64 `json:"memoryUsagePercentage"`
 }
 
+// Severidades dos achados do relatório de saúde, da mais grave para a menos grave.
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// Severities lista as severidades dos achados.
+var Severities = []string{SeverityCritical, SeverityWarning, SeverityInfo}
+
+// SeverityRank retorna a posição da severidade em Severities, com 0 para a mais grave. Severidades
+// desconhecidas ficam depois de todas as outras.
+func SeverityRank(severity string) int {
+	for i, known := range Severities {
+		if known == severity {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+// CompareRanked compara dois itens de relatório pela classificação (menor primeiro) e, no empate, pelas
+// chaves do objeto na ordem informada, em geral namespace, kind e nome.
+func CompareRanked(rankA, rankB int, keysA, keysB []string) int {
+	return cmp.Or(cmp.Compare(rankA, rankB), slices.Compare(keysA, keysB))
+}
+
+// Estados gerais do cluster no relatório de saúde.
+const (
+	HealthStatusHealthy  = "healthy"
+	HealthStatusDegraded = "degraded"
+	HealthStatusCritical = "critical"
+)
+
+// HealthStatuses lista os estados gerais do cluster.
+var HealthStatuses = []string{HealthStatusHealthy, HealthStatusDegraded, HealthStatusCritical}
+
+// Verificações do relatório de saúde, estáveis para consumo por ferramentas.
+const (
+	CheckPodCrashLoop          = "pod-crash-loop"
+	CheckPodImagePull          = "pod-image-pull"
+	CheckPodPending            = "pod-pending"
+	CheckDeploymentUnavailable = "deployment-unavailable"
+	CheckServiceNoEndpoints    = "service-no-endpoints"
+	CheckPvcPending            = "pvc-pending"
+	CheckNodeNotReady          = "node-not-ready"
+	CheckNodePressure          = "node-pressure"
+	CheckMissingRequests       = "missing-requests"
+)
+
+// HealthChecks lista todas as verificações do relatório de saúde.
+var HealthChecks = []string{
+	CheckPodCrashLoop, CheckPodImagePull, CheckPodPending, CheckDeploymentUnavailable, CheckServiceNoEndpoints,
+	CheckPvcPending, CheckNodeNotReady, CheckNodePressure, CheckMissingRequests,
+}
+
+// HealthFinding é um problema encontrado em um objeto do cluster.
+type HealthFinding struct {
+	Check     string `json:"check"`
+	Severity  string `json:"severity"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
+// HealthSummary resume o relatório de saúde: a pontuação de 0 a 100, o estado geral e os achados por severidade.
+type HealthSummary struct {
+	Score    int    `json:"score"`
+	Status   string `json:"status"`
+	Critical int    `json:"critical"`
+	Warning  int    `json:"warning"`
+	Info     int    `json:"info"`
+}
+
+// HealthReport é o relatório de saúde do cluster, com os achados do mais grave para o menos grave.
+type HealthReport struct {
+	Summary  HealthSummary   `json:"summary"`
+	Findings []HealthFinding `json:"findings"`
+}
+
+// PolicyRuleInfo descreve uma regra de boas práticas e a configuração aplicada a ela.
+type PolicyRuleInfo struct {
+	ID               string   `json:"id"`
+	Severity         string   `json:"severity"`
+	Description      string   `json:"description"`
+	Enabled          bool     `json:"enabled"`
+	ExemptNamespaces []string `json:"exemptNamespaces"`
+}
+
+// PolicyViolation é uma violação de regra em um workload; Container fica vazio nas regras que avaliam o workload inteiro.
+type PolicyViolation struct {
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Container string `json:"container,omitempty"`
+	Message   string `json:"message"`
+}
+
+// PolicyReport é o resultado da avaliação das regras sobre os workloads, com as violações da mais grave para a menos grave.
+type PolicyReport struct {
+	Workloads  int               `json:"workloads"`
+	Rules      []PolicyRuleInfo  `json:"rules"`
+	Violations []PolicyViolation `json:"violations"`
+}
+
+// Classificações das recomendações de dimensionamento, da mais urgente para a menos urgente.
+const (
+	RightsizingUnderProvisioned = "under-provisioned"
+	RightsizingOverProvisioned  = "over-provisioned"
+	RightsizingNoRequest        = "no-request"
+	RightsizingOK               = "ok"
+	RightsizingInsufficientData = "insufficient-data"
+)
+
+// RightsizingStatuses lista as classificações das recomendações de dimensionamento.
+var RightsizingStatuses = []string{
+	RightsizingUnderProvisioned, RightsizingOverProvisioned, RightsizingNoRequest, RightsizingOK, RightsizingInsufficientData,
+}
+
+// ResourceRecommendation compara o uso observado de um recurso com o request e o limit configurados.
+// Os valores são em milicores para CPU e em bytes para memória; zero em Request ou Limit indica que não estão definidos.
+type ResourceRecommendation struct {
+	Request            int64  `json:"request"`
+	Limit              int64  `json:"limit"`
+	P50                int64  `json:"p50"`
+	P95                int64  `json:"p95"`
+	Max                int64  `json:"max"`
+	RecommendedRequest string `json:"recommendedRequest"`
+	RecommendedLimit   string `json:"recommendedLimit,omitempty"`
+	Status             string `json:"status"`
+}
+
+// ContainerRecommendation é a recomendação para um contêiner, calculada sobre as amostras de todas as réplicas do workload.
+type ContainerRecommendation struct {
+	Container string `json:"container"`
+	// Samples conta as coletas com uso do contêiner; cada uma guarda o maior uso entre as réplicas.
+	Samples int `json:"samples"`
+	// ObservedWindow é o período coberto pelas amostras, menor que a janela do relatório quando o histórico
+	// ainda está sendo formado ou foi limitado; vazio sem amostras.
+	ObservedWindow string                 `json:"observedWindow,omitempty"`
+	CPU            ResourceRecommendation `json:"cpu"`
+	Memory         ResourceRecommendation `json:"memory"`
+}
+
+// WorkloadRecommendation agrupa as recomendações dos contêineres de um workload; Status é o mais urgente entre eles.
+type WorkloadRecommendation struct {
+	Kind       string                    `json:"kind"`
+	Namespace  string                    `json:"namespace"`
+	Name       string                    `json:"name"`
+	Pods       int                       `json:"pods"`
+	Status     string                    `json:"status"`
+	Containers []ContainerRecommendation `json:"containers"`
+}
+
+// RightsizingReport reúne as recomendações de dimensionamento calculadas sobre a janela de uso observada.
+type RightsizingReport struct {
+	Window     string                   `json:"window"`
+	MinSamples int                      `json:"minSamples"`
+	Workloads  []WorkloadRecommendation `json:"workloads"`
+}
+
 // WSMessage define a estrutura da mensagem enviada pelo WebSocket.
 type WSMessage struct {
 	Type    string      `json:"type"`
 	Payload interface{} `json:"payload"`
 }
+
+// Códigos de erro da API, estáveis para consumo por ferramentas.
+const (
+	ErrorCodeInvalidParameter = "invalid_parameter"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeRouteNotFound    = "route_not_found"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeFeatureDisabled  = "feature_disabled"
+	ErrorCodeTimeout          = "timeout"
+	ErrorCodeInternal         = "internal_error"
+)
+
+// ErrorCodes lista todos os códigos de erro que a API pode retornar.
+var ErrorCodes = []string{
+	ErrorCodeInvalidParameter, ErrorCodeNotFound, ErrorCodeRouteNotFound, ErrorCodeForbidden,
+	ErrorCodeFeatureDisabled, ErrorCodeTimeout, ErrorCodeInternal,
+}
+
+// ErrorResponse é o envelope retornado por todas as respostas de erro da API.
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// APIError descreve um erro da API: Code é estável e Message é destinada a pessoas.
+type APIError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+}
@@ -0,0 +1,345 @@
+// Package openapi gera o documento OpenAPI 3 da API REST a partir da tabela de rotas e dos tipos de internal/models.
+// Os schemas são derivados por reflexão das structs, seguindo as tags json, para que o documento acompanhe os modelos.
+package openapi
+
+import (
+	"kubeowl/internal/models"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version é a versão OpenAPI do documento gerado.
+const Version = "3.0.3"
+
+// Document é a raiz do documento OpenAPI.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info identifica a API descrita.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server é a URL base das rotas do documento.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem agrupa as operações de um caminho por método HTTP (em minúsculas).
+type PathItem map[string]*Operation
+
+// Components guarda os schemas reutilizados pelas operações.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation descreve uma operação da API.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter é um parâmetro de caminho ou de query.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Response descreve uma resposta de uma operação.
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header descreve um cabeçalho de resposta.
+type Header struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType associa um schema a um tipo de conteúdo.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema é o subconjunto de JSON Schema usado pelo OpenAPI 3.0.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Route descreve uma rota GET da API para o documento. Path é relativo à URL base e usa {nome} para os
+// parâmetros de caminho, como os padrões do http.ServeMux.
+type Route struct {
+	Path    string
+	Summary string
+	Tag     string
+	// Response é um valor de exemplo do tipo retornado; nil indica um objeto JSON livre.
+	Response any
+	// List indica um endpoint de listagem com a consulta comum (limit, continue, sort, q e seletores).
+	List bool
+	// Export indica que a rota também responde em CSV, NDJSON e YAML; as listagens sempre respondem.
+	Export bool
+	Query  []Parameter
+	// Errors lista os status de erro específicos da rota; 500 e 504 são incluídos em todas.
+	Errors []int
+}
+
+// QueryParam cria um parâmetro de query opcional do tipo texto.
+func QueryParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+// IntQueryParam cria um parâmetro de query opcional inteiro e não negativo.
+func IntQueryParam(name, description string) Parameter {
+	zero := 0.0
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "integer", Minimum: &zero}}
+}
+
+// listParams são os parâmetros da consulta comum das listagens (ver internal/listquery).
+var listParams = []Parameter{
+	IntQueryParam("limit", "Tamanho da página; zero ou ausente retorna todos os itens."),
+	QueryParam("continue", "Token da próxima página, recebido no cabeçalho X-Continue."),
+	QueryParam("sort", "Campos de ordenação separados por vírgula, com '-' para ordem decrescente (ex.: namespace,-restarts)."),
+	QueryParam("q", "Busca textual em todos os campos de texto do item."),
+	QueryParam("labelSelector", "Seletor de labels repassado à API do Kubernetes."),
+	QueryParam("fieldSelector", "Seletor de campos repassado à API do Kubernetes."),
+}
+
+// formatParam escolhe o formato de exportação; o cabeçalho Accept tem o mesmo efeito (ver internal/export).
+var formatParam = Parameter{Name: "format", In: "query", Description: "Formato da resposta; o padrão é JSON. Equivale a enviar o tipo no cabeçalho Accept.",
+	Schema: &Schema{Type: "string", Enum: []string{"json", "csv", "ndjson", "yaml"}}}
+
+// exportTypes são os tipos de conteúdo dos formatos de exportação, que trazem apenas os itens da listagem.
+var exportTypes = []string{"text/csv", "application/x-ndjson", "application/yaml"}
+
+var listHeaders = map[string]*Header{
+	"X-Total-Count": {Description: "Total de itens após a busca, antes da paginação.", Schema: &Schema{Type: "integer"}},
+	"X-Continue":    {Description: "Token da próxima página; ausente na última página.", Schema: &Schema{Type: "string"}},
+}
+
+// modelTypes lista todos os tipos de internal/models, descritos no documento mesmo quando nenhuma rota os retorna
+// diretamente (como as mensagens do WebSocket).
+var modelTypes = []any{
+	models.OverviewResponse{}, models.ServiceInfo{}, models.ServicePortInfo{}, models.IngressInfo{},
+	models.IngressRuleInfo{}, models.IngressPathInfo{}, models.IngressBackendInfo{}, models.IngressTLSInfo{},
+	models.NodeInfo{}, models.NodePoolInfo{}, models.NodeConditionInfo{}, models.TaintInfo{}, models.PodInfo{},
+	models.EventInfo{}, models.EventObjectRef{}, models.EventPage{}, models.PvcInfo{}, models.PersistentVolumeInfo{},
+	models.StorageClassInfo{}, models.NamespaceInfo{}, models.ResourceQuotaInfo{}, models.QuotaResourceUsage{},
+	models.QuotaAlert{}, models.LimitRangeInfo{}, models.LimitRangeItemInfo{}, models.ConfigMapInfo{},
+	models.SecretInfo{}, models.DataKeyInfo{}, models.ConfigUsageRef{}, models.FilesystemUsage{},
+	models.DiskUsageReport{}, models.NodeDiskUsage{}, models.VolumeDiskUsage{}, models.ContainerDiskUsage{},
+	models.GatewayAPIInfo{}, models.GatewayClassInfo{}, models.GatewayInfo{}, models.GatewayListenerInfo{},
+	models.RouteInfo{}, models.RouteParentInfo{}, models.APIResourceInfo{}, models.PrinterColumn{},
+	models.ResourceListInfo{}, models.ResourceRow{}, models.TopologyGraph{}, models.TopologyNode{},
+	models.TopologyEdge{}, models.ClusterCapacityInfo{}, models.HealthReport{}, models.HealthSummary{},
+	models.HealthFinding{}, models.PolicyReport{}, models.PolicyRuleInfo{}, models.PolicyViolation{},
+	models.RightsizingReport{}, models.WorkloadRecommendation{}, models.ContainerRecommendation{},
+	models.ResourceRecommendation{}, models.WSMessage{}, models.ErrorResponse{}, models.APIError{},
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build gera o documento com as rotas informadas, servidas a partir de baseURL.
+func Build(info Info, baseURL string, routes []Route) *Document {
+	g := &generator{schemas: map[string]*Schema{}}
+	for _, model := range modelTypes {
+		g.schemaFor(reflect.TypeOf(model))
+	}
+	// O código do erro e as severidades e estados dos relatórios são conjuntos fechados, descritos como enum.
+	g.schemas["APIError"].Properties["code"].Enum = models.ErrorCodes
+	g.schemas["HealthFinding"].Properties["check"].Enum = models.HealthChecks
+	g.schemas["HealthFinding"].Properties["severity"].Enum = models.Severities
+	g.schemas["HealthSummary"].Properties["status"].Enum = models.HealthStatuses
+	g.schemas["PolicyRuleInfo"].Properties["severity"].Enum = models.Severities
+	g.schemas["PolicyViolation"].Properties["severity"].Enum = models.Severities
+	g.schemas["WorkloadRecommendation"].Properties["status"].Enum = models.RightsizingStatuses
+	g.schemas["ResourceRecommendation"].Properties["status"].Enum = models.RightsizingStatuses
+
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Servers:    []Server{{URL: baseURL}},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: g.schemas},
+	}
+	for _, route := range routes {
+		doc.Paths[route.Path] = PathItem{"get": g.operation(route)}
+	}
+	return doc
+}
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+func (g *generator) operation(route Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(route.Path),
+		Summary:     route.Summary,
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	op.Parameters = append(op.Parameters, route.Query...)
+
+	ok := &Response{Description: "Sucesso", Content: map[string]MediaType{"application/json": {Schema: g.responseSchema(route.Response)}}}
+	statuses := append([]int{}, route.Errors...)
+	if route.List {
+		op.Parameters = append(op.Parameters, listParams...)
+		ok.Headers = listHeaders
+		statuses = append(statuses, http.StatusBadRequest)
+	}
+	if route.List || route.Export {
+		op.Parameters = append(op.Parameters, formatParam)
+		for _, contentType := range exportTypes {
+			ok.Content[contentType] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+		if !route.List {
+			statuses = append(statuses, http.StatusBadRequest)
+		}
+	}
+	op.Responses["200"] = ok
+
+	statuses = append(statuses, http.StatusInternalServerError, http.StatusGatewayTimeout)
+	errorSchema := &Schema{Ref: "#/components/schemas/ErrorResponse"}
+	for _, status := range statuses {
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+	return op
+}
+
+func (g *generator) responseSchema(response any) *Schema {
+	if response == nil {
+		return &Schema{Type: "object", AdditionalProperties: &Schema{}}
+	}
+	return g.schemaFor(reflect.TypeOf(response))
+}
+
+// operationID deriva um identificador estável do caminho, ex.: /configmaps/{namespace}/{name} → getConfigmapsNamespaceName.
+func operationID(path string) string {
+	var b strings.Builder
+	b.WriteString("get")
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor retorna o schema do tipo; structs são registradas em components e referenciadas por $ref.
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		schema := g.schemaFor(t.Elem())
+		if schema.Ref != "" {
+			// $ref não admite campos irmãos no OpenAPI 3.0; a referência anulável é feita com allOf.
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int32, reflect.Int16, reflect.Int8, reflect.Uint16, reflect.Uint8:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	// Listas e mapas nulos são codificados como null.
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem()), Nullable: true}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	// interface{} e demais tipos aceitam qualquer valor JSON.
+	return &Schema{}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+	if _, ok := g.schemas[t.Name()]; ok {
+		return ref
+	}
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	// Registra antes de percorrer os campos para suportar tipos recursivos.
+	g.schemas[t.Name()] = schema
+	g.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return ref
+}
+
+// addFields adiciona as propriedades da struct ao schema, incorporando os campos de structs embutidas.
+// Campos sem omitempty estão sempre presentes no JSON e são marcados como obrigatórios.
+func (g *generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
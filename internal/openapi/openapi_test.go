@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"kubeowl/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuild_CoversAllModels garante que toda struct exportada de internal/models está descrita no documento.
+func TestBuild_CoversAllModels(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), "../models", nil, 0)
+	if err != nil {
+		t.Fatalf("falha ao ler o pacote models: %v", err)
+	}
+	doc := Build(Info{Title: "teste", Version: "v1"}, "/api/v1", nil)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if _, isStruct := typeSpec.Type.(*ast.StructType); isStruct && typeSpec.Name.IsExported() {
+						assert.Contains(t, doc.Components.Schemas, typeSpec.Name.Name, "modelo sem schema; inclua-o em modelTypes")
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBuild_Schemas(t *testing.T) {
+	doc := Build(Info{Title: "teste", Version: "v1"}, "/api/v1", nil)
+
+	event := doc.Components.Schemas["EventInfo"]
+	assert.Equal(t, "integer", event.Properties["count"].Type)
+	assert.Equal(t, "int32", event.Properties["count"].Format)
+	assert.Equal(t, "#/components/schemas/EventObjectRef", event.Properties["involvedObject"].Ref)
+	assert.Contains(t, event.Required, "lastSeen")
+
+	service := doc.Components.Schemas["ServiceInfo"]
+	assert.Equal(t, "object", service.Properties["selector"].Type)
+	assert.Equal(t, "string", service.Properties["selector"].AdditionalProperties.Type)
+	assert.NotContains(t, service.Required, "externalName")
+
+	page := doc.Components.Schemas["EventPage"]
+	assert.Equal(t, "array", page.Properties["items"].Type)
+	assert.Equal(t, "#/components/schemas/EventInfo", page.Properties["items"].Items.Ref)
+
+	assert.Equal(t, models.ErrorCodes, doc.Components.Schemas["APIError"].Properties["code"].Enum)
+}
+
+func TestBuild_Paths(t *testing.T) {
+	doc := Build(Info{Title: "teste", Version: "v1"}, "/api/v1", []Route{
+		{Path: "/pods", Summary: "Pods", Response: []models.PodInfo{}, List: true},
+		{Path: "/configmaps/{namespace}/{name}", Summary: "ConfigMap", Response: models.ConfigMapInfo{}, Errors: []int{404}},
+		{Path: "/resources/{group}/{version}/{resource}/{name}", Summary: "Objeto"},
+	})
+	assert.Equal(t, "/api/v1", doc.Servers[0].URL)
+
+	pods := doc.Paths["/pods"]["get"]
+	assert.Equal(t, "getPods", pods.OperationID)
+	assert.Equal(t, "#/components/schemas/PodInfo", pods.Responses["200"].Content["application/json"].Schema.Items.Ref)
+	assert.Contains(t, pods.Responses["200"].Headers, "X-Continue")
+	assert.Contains(t, pods.Responses, "400")
+	assert.Contains(t, pods.Responses, "500")
+	assert.Contains(t, pods.Responses["200"].Content, "text/csv")
+	assert.Equal(t, "format", pods.Parameters[len(pods.Parameters)-1].Name)
+
+	configMap := doc.Paths["/configmaps/{namespace}/{name}"]["get"]
+	assert.Equal(t, "getConfigmapsNamespaceName", configMap.OperationID)
+	if assert.Len(t, configMap.Parameters, 2) {
+		assert.Equal(t, Parameter{Name: "namespace", In: "path", Required: true, Schema: &Schema{Type: "string"}}, configMap.Parameters[0])
+	}
+	assert.Equal(t, "#/components/schemas/ErrorResponse", configMap.Responses["404"].Content["application/json"].Schema.Ref)
+	assert.NotContains(t, configMap.Responses, "400")
+	assert.NotContains(t, configMap.Responses["200"].Content, "text/csv")
+
+	object := doc.Paths["/resources/{group}/{version}/{resource}/{name}"]["get"]
+	assert.Equal(t, "object", object.Responses["200"].Content["application/json"].Schema.Type)
+
+	// O documento é serializável e usa as chaves do OpenAPI.
+	raw, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"$ref":"#/components/schemas/PodInfo"`)
+}
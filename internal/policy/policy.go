@@ -0,0 +1,245 @@
+// Package policy avalia os workloads do cluster contra regras de boas práticas configuráveis.
+package policy
+
+import (
+	"fmt"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/models"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Workload é um objeto que cria pods, com o template avaliado pelas regras. Pods sem controlador
+// também são workloads, com o próprio spec como template.
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Replicas é nil para workloads sem contagem de réplicas (DaemonSets, Jobs, pods avulsos).
+	Replicas *int32
+	Template v1.PodTemplateSpec
+	// DisruptionBudgets são os PodDisruptionBudgets cujo seletor cobre os pods do workload.
+	DisruptionBudgets []string
+}
+
+// Batch indica se o workload executa até terminar, caso em que probes não se aplicam.
+func (w Workload) Batch() bool {
+	return w.Kind == "Job" || w.Kind == "CronJob"
+}
+
+// Violation é uma violação encontrada por uma regra; Container fica vazio quando ela se refere ao workload inteiro.
+type Violation struct {
+	Container string
+	Message   i18n.Message
+}
+
+// Rule é uma regra de boas práticas. Regras próprias podem ser registradas com WithRules.
+type Rule interface {
+	// ID identifica a regra na configuração e no relatório.
+	ID() string
+	Severity() string
+	Description() i18n.Message
+	Check(w Workload) []Violation
+}
+
+// Exemption isenta um namespace de uma regra, ou de todas quando Rule está vazio.
+type Exemption struct {
+	Namespace string
+	Rule      string
+}
+
+// Linter aplica as regras habilitadas aos workloads, respeitando as isenções por namespace.
+type Linter struct {
+	rules      []Rule
+	disabled   map[string]bool
+	exemptions map[string]map[string]bool
+}
+
+// Option personaliza o Linter.
+type Option func(*Linter)
+
+// WithRules registra regras além das padrão. Uma regra com o mesmo ID de outra já registrada a substitui.
+func WithRules(rules ...Rule) Option {
+	return func(l *Linter) {
+		for _, rule := range rules {
+			replaced := false
+			for i, existing := range l.rules {
+				if existing.ID() == rule.ID() {
+					l.rules[i] = rule
+					replaced = true
+				}
+			}
+			if !replaced {
+				l.rules = append(l.rules, rule)
+			}
+		}
+	}
+}
+
+// WithDisabledRules desabilita as regras informadas.
+func WithDisabledRules(ids ...string) Option {
+	return func(l *Linter) {
+		for _, id := range ids {
+			l.disabled[id] = true
+		}
+	}
+}
+
+// WithExemptions isenta namespaces de regras específicas ou de todas as regras.
+func WithExemptions(exemptions ...Exemption) Option {
+	return func(l *Linter) {
+		for _, exemption := range exemptions {
+			if l.exemptions[exemption.Namespace] == nil {
+				l.exemptions[exemption.Namespace] = map[string]bool{}
+			}
+			l.exemptions[exemption.Namespace][exemption.Rule] = true
+		}
+	}
+}
+
+// New cria um Linter com as regras padrão.
+func New(opts ...Option) *Linter {
+	l := &Linter{
+		rules:      DefaultRules(),
+		disabled:   map[string]bool{},
+		exemptions: map[string]map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Without retorna uma cópia do Linter com as regras informadas desabilitadas, para avaliações em que
+// faltam os dados de que elas dependem. O Linter original não é alterado.
+func (l *Linter) Without(ids ...string) *Linter {
+	disabled := make(map[string]bool, len(l.disabled)+len(ids))
+	for id := range l.disabled {
+		disabled[id] = true
+	}
+	for _, id := range ids {
+		disabled[id] = true
+	}
+	return &Linter{rules: l.rules, disabled: disabled, exemptions: l.exemptions}
+}
+
+// RuleIDs lista os IDs das regras registradas, padrão e próprias, na ordem de avaliação.
+func (l *Linter) RuleIDs() []string {
+	ids := make([]string, 0, len(l.rules))
+	for _, rule := range l.rules {
+		ids = append(ids, rule.ID())
+	}
+	return ids
+}
+
+// ParseRuleIDs interpreta uma lista de IDs separados por vírgula, aceitando apenas regras registradas no Linter.
+func (l *Linter) ParseRuleIDs(spec string) ([]string, error) {
+	ids := []string{}
+	for _, id := range strings.Split(spec, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if !l.registered(id) {
+			return nil, fmt.Errorf("regra desconhecida %q: use uma de %s", id, strings.Join(l.RuleIDs(), ", "))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ParseExemptions interpreta uma lista separada por vírgulas no formato "namespace[:regra]", por exemplo
+// "legacy,monitoring:run-as-root". Sem a regra, o namespace fica isento de todas; com ela, a regra precisa
+// estar registrada no Linter.
+func (l *Linter) ParseExemptions(spec string) ([]Exemption, error) {
+	exemptions := []Exemption{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, rule, hasRule := strings.Cut(entry, ":")
+		if namespace == "" || (hasRule && rule == "") {
+			return nil, fmt.Errorf("isenção inválida %q: use namespace[:regra]", entry)
+		}
+		if hasRule && !l.registered(rule) {
+			return nil, fmt.Errorf("isenção inválida %q: regra desconhecida %q", entry, rule)
+		}
+		exemptions = append(exemptions, Exemption{Namespace: namespace, Rule: rule})
+	}
+	return exemptions, nil
+}
+
+func (l *Linter) registered(id string) bool {
+	for _, rule := range l.rules {
+		if rule.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// exempt indica se o namespace está isento da regra.
+func (l *Linter) exempt(namespace, rule string) bool {
+	rules := l.exemptions[namespace]
+	return rules[""] || rules[rule]
+}
+
+// exemptNamespaces lista os namespaces isentos da regra, em ordem alfabética.
+func (l *Linter) exemptNamespaces(rule string) []string {
+	namespaces := []string{}
+	for namespace := range l.exemptions {
+		if l.exempt(namespace, rule) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// Lint avalia os workloads e monta o relatório, com as mensagens no idioma informado.
+func (l *Linter) Lint(workloads []Workload, lang string) models.PolicyReport {
+	report := models.PolicyReport{
+		Workloads:  len(workloads),
+		Rules:      make([]models.PolicyRuleInfo, 0, len(l.rules)),
+		Violations: []models.PolicyViolation{},
+	}
+	for _, rule := range l.rules {
+		enabled := !l.disabled[rule.ID()]
+		report.Rules = append(report.Rules, models.PolicyRuleInfo{
+			ID:               rule.ID(),
+			Severity:         rule.Severity(),
+			Description:      rule.Description().In(lang),
+			Enabled:          enabled,
+			ExemptNamespaces: l.exemptNamespaces(rule.ID()),
+		})
+		if !enabled {
+			continue
+		}
+		for _, workload := range workloads {
+			if l.exempt(workload.Namespace, rule.ID()) {
+				continue
+			}
+			for _, violation := range rule.Check(workload) {
+				report.Violations = append(report.Violations, models.PolicyViolation{
+					Rule:      rule.ID(),
+					Severity:  rule.Severity(),
+					Kind:      workload.Kind,
+					Namespace: workload.Namespace,
+					Name:      workload.Name,
+					Container: violation.Container,
+					Message:   violation.Message.In(lang),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		return models.CompareRanked(models.SeverityRank(a.Severity), models.SeverityRank(b.Severity),
+			[]string{a.Namespace, a.Kind, a.Name, a.Rule}, []string{b.Namespace, b.Kind, b.Name, b.Rule}) < 0
+	})
+	return report
+}
@@ -0,0 +1,216 @@
+package policy
+
+import (
+	"io"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/models"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// TestMain silencia a saída de log durante os testes deste pacote.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// compliantContainer atende a todas as regras padrão.
+func compliantContainer(name string) v1.Container {
+	quantities := v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("64Mi")}
+	probe := &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz"}}}
+	return v1.Container{
+		Name:            name,
+		Image:           "registry.example.com:5000/team/" + name + ":1.4.2",
+		Resources:       v1.ResourceRequirements{Requests: quantities, Limits: quantities},
+		ReadinessProbe:  probe,
+		LivenessProbe:   probe,
+		SecurityContext: &v1.SecurityContext{RunAsNonRoot: ptr(true)},
+	}
+}
+
+func workload(kind, namespace, name string, replicas *int32, containers ...v1.Container) Workload {
+	w := Workload{Kind: kind, Namespace: namespace, Name: name, Replicas: replicas}
+	w.Template.Spec.Containers = containers
+	return w
+}
+
+func violationsByRule(report models.PolicyReport) map[string][]models.PolicyViolation {
+	result := map[string][]models.PolicyViolation{}
+	for _, violation := range report.Violations {
+		result[violation.Rule] = append(result[violation.Rule], violation)
+	}
+	return result
+}
+
+func TestLint_DefaultRules(t *testing.T) {
+	insecure := compliantContainer("agent")
+	insecure.Image = "agent"
+	insecure.SecurityContext = &v1.SecurityContext{Privileged: ptr(true), RunAsUser: ptr(int64(0))}
+	insecure.Resources = v1.ResourceRequirements{}
+	insecure.LivenessProbe = nil
+
+	sidecar := compliantContainer("proxy")
+	sidecar.Image = "envoyproxy/envoy:latest"
+	sidecar.SecurityContext = nil
+
+	daemon := workload("DaemonSet", "infra", "agent", nil, insecure)
+	daemon.Template.Spec.Volumes = []v1.Volume{{Name: "logs", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log"}}}}
+	// O pod não permite root, mas o contêiner sobrescreve com runAsUser 0.
+	daemon.Template.Spec.SecurityContext = &v1.PodSecurityContext{RunAsNonRoot: ptr(true)}
+
+	workloads := []Workload{
+		daemon,
+		workload("Deployment", "app", "api", ptr(int32(1)), compliantContainer("api"), sidecar),
+		workload("Deployment", "app", "web", ptr(int32(1)), compliantContainer("web")),
+		workload("Deployment", "app", "worker", ptr(int32(3)), compliantContainer("worker")),
+		workload("CronJob", "app", "report", nil, v1.Container{
+			Name: "report", Image: "report@sha256:0123", Resources: compliantContainer("report").Resources,
+			SecurityContext: &v1.SecurityContext{RunAsUser: ptr(int64(1000))},
+		}),
+	}
+	workloads[2].DisruptionBudgets = []string{"web"}
+
+	report := New().Lint(workloads, i18n.PortugueseBR)
+	assert.Equal(t, 5, report.Workloads)
+	assert.Len(t, report.Rules, len(DefaultRules()))
+
+	rules := violationsByRule(report)
+	if assert.Len(t, rules[RulePrivileged], 1) {
+		assert.Equal(t, "agent", rules[RulePrivileged][0].Container)
+	}
+	if assert.Len(t, rules[RuleHostPath], 1) {
+		assert.Equal(t, "O volume logs monta o caminho /var/log do nó", rules[RuleHostPath][0].Message)
+	}
+	if assert.Len(t, rules[RuleRunAsRoot], 2) {
+		assert.Equal(t, "proxy", rules[RuleRunAsRoot][0].Container)
+		assert.Equal(t, "Executa com runAsUser 0", rules[RuleRunAsRoot][1].Message)
+	}
+	if assert.Len(t, rules[RuleLatestImage], 2) {
+		assert.Equal(t, "proxy", rules[RuleLatestImage][0].Container)
+		assert.Equal(t, "agent", rules[RuleLatestImage][1].Container)
+	}
+	if assert.Len(t, rules[RuleResources], 1) {
+		assert.Equal(t, "Sem requests.cpu, requests.memory, limits.cpu, limits.memory", rules[RuleResources][0].Message)
+	}
+	// O CronJob não precisa de probes.
+	if assert.Len(t, rules[RuleProbes], 1) {
+		assert.Equal(t, "Sem livenessProbe", rules[RuleProbes][0].Message)
+	}
+	// O Deployment web tem um PodDisruptionBudget; o worker tem três réplicas.
+	if assert.Len(t, rules[RuleSingleReplica], 1) {
+		assert.Equal(t, "api", rules[RuleSingleReplica][0].Name)
+		assert.Empty(t, rules[RuleSingleReplica][0].Container)
+	}
+
+	assert.Equal(t, models.SeverityCritical, report.Violations[0].Severity)
+	assert.Equal(t, models.SeverityWarning, report.Violations[len(report.Violations)-1].Severity)
+}
+
+func TestLint_DisabledRulesAndExemptions(t *testing.T) {
+	latest := compliantContainer("app")
+	latest.Image = "app:latest"
+	latest.ReadinessProbe = nil
+	workloads := []Workload{
+		workload("Deployment", "app", "a", ptr(int32(2)), latest),
+		workload("Deployment", "legacy", "b", ptr(int32(2)), latest),
+		workload("Deployment", "sandbox", "c", ptr(int32(2)), latest),
+	}
+
+	linter := New(
+		WithDisabledRules(RuleProbes),
+		WithExemptions(Exemption{Namespace: "legacy"}, Exemption{Namespace: "sandbox", Rule: RuleLatestImage}),
+	)
+	report := linter.Lint(workloads, i18n.English)
+
+	rules := violationsByRule(report)
+	assert.Empty(t, rules[RuleProbes])
+	if assert.Len(t, rules[RuleLatestImage], 1) {
+		assert.Equal(t, "app", rules[RuleLatestImage][0].Namespace)
+		assert.Equal(t, "Image app:latest is not pinned to a tag", rules[RuleLatestImage][0].Message)
+	}
+
+	for _, rule := range report.Rules {
+		switch rule.ID {
+		case RuleProbes:
+			assert.False(t, rule.Enabled)
+		case RuleLatestImage:
+			assert.True(t, rule.Enabled)
+			assert.Equal(t, []string{"legacy", "sandbox"}, rule.ExemptNamespaces)
+		default:
+			assert.Equal(t, []string{"legacy"}, rule.ExemptNamespaces, rule.ID)
+		}
+	}
+}
+
+// annotationRule é uma regra própria, registrada além das padrão.
+type annotationRule struct{}
+
+func (annotationRule) ID() string                { return "owner-annotation" }
+func (annotationRule) Severity() string          { return models.SeverityInfo }
+func (annotationRule) Description() i18n.Message { return i18n.M(i18n.MsgViolationMissing, "owner") }
+func (annotationRule) Check(w Workload) []Violation {
+	if w.Template.Annotations["owner"] == "" {
+		return []Violation{{Message: i18n.M(i18n.MsgViolationMissing, "owner")}}
+	}
+	return nil
+}
+
+func TestLint_CustomRule(t *testing.T) {
+	report := New(WithRules(annotationRule{})).Lint([]Workload{workload("Deployment", "app", "a", ptr(int32(2)), compliantContainer("a"))}, i18n.English)
+
+	assert.Len(t, report.Rules, len(DefaultRules())+1)
+	if assert.Len(t, report.Violations, 1) {
+		assert.Equal(t, "owner-annotation", report.Violations[0].Rule)
+		assert.Equal(t, models.SeverityInfo, report.Violations[0].Severity)
+	}
+}
+
+func TestPinnedImage(t *testing.T) {
+	cases := map[string]bool{
+		"nginx":                             false,
+		"nginx:latest":                      false,
+		"nginx:1.27":                        true,
+		"registry.local:5000/nginx":         false,
+		"registry.local:5000/nginx:1.27":    true,
+		"nginx@sha256:abcdef":               true,
+		"ghcr.io/org/app:latest@sha256:abc": true,
+	}
+	for image, expected := range cases {
+		assert.Equal(t, expected, pinnedImage(image), image)
+	}
+}
+
+func TestParseExemptions(t *testing.T) {
+	linter := New()
+	exemptions, err := linter.ParseExemptions(" legacy , monitoring:run-as-root,")
+	assert.NoError(t, err)
+	assert.Equal(t, []Exemption{{Namespace: "legacy"}, {Namespace: "monitoring", Rule: RuleRunAsRoot}}, exemptions)
+
+	for _, spec := range []string{":probes", "app:", "app:unknown"} {
+		_, err := linter.ParseExemptions(spec)
+		assert.Error(t, err, spec)
+	}
+
+	ids, err := linter.ParseRuleIDs("probes, latest-image")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{RuleProbes, RuleLatestImage}, ids)
+	_, err = linter.ParseRuleIDs("probes,unknown")
+	assert.Error(t, err)
+
+	// Regras próprias registradas com WithRules também podem ser desabilitadas e isentadas.
+	custom := New(WithRules(annotationRule{}))
+	ids, err = custom.ParseRuleIDs("owner-annotation")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner-annotation"}, ids)
+	_, err = custom.ParseExemptions("legacy:owner-annotation")
+	assert.NoError(t, err)
+	_, err = linter.ParseRuleIDs("owner-annotation")
+	assert.Error(t, err)
+}
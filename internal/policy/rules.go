@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/models"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// IDs das regras padrão.
+const (
+	RuleResources     = "resources"
+	RuleProbes        = "probes"
+	RuleRunAsRoot     = "run-as-root"
+	RuleLatestImage   = "latest-image"
+	RulePrivileged    = "privileged"
+	RuleHostPath      = "host-path"
+	RuleSingleReplica = "single-replica-pdb"
+)
+
+// rule é uma regra definida por uma função de verificação.
+type rule struct {
+	id          string
+	severity    string
+	description i18n.Key
+	check       func(w Workload) []Violation
+}
+
+func (r rule) ID() string                   { return r.id }
+func (r rule) Severity() string             { return r.severity }
+func (r rule) Description() i18n.Message    { return i18n.M(r.description) }
+func (r rule) Check(w Workload) []Violation { return r.check(w) }
+
+// DefaultRules retorna as regras padrão, em ordem de exibição.
+func DefaultRules() []Rule {
+	return []Rule{
+		rule{RulePrivileged, models.SeverityCritical, i18n.MsgRulePrivileged, checkPrivileged},
+		rule{RuleHostPath, models.SeverityCritical, i18n.MsgRuleHostPath, checkHostPath},
+		rule{RuleRunAsRoot, models.SeverityWarning, i18n.MsgRuleRunAsRoot, checkRunAsRoot},
+		rule{RuleLatestImage, models.SeverityWarning, i18n.MsgRuleLatestImage, checkLatestImage},
+		rule{RuleResources, models.SeverityWarning, i18n.MsgRuleResources, checkResources},
+		rule{RuleProbes, models.SeverityWarning, i18n.MsgRuleProbes, checkProbes},
+		rule{RuleSingleReplica, models.SeverityWarning, i18n.MsgRuleSingleReplica, checkSingleReplica},
+	}
+}
+
+// containers lista os contêineres de inicialização e os comuns do template.
+func containers(w Workload) []v1.Container {
+	return append(append([]v1.Container{}, w.Template.Spec.InitContainers...), w.Template.Spec.Containers...)
+}
+
+func checkPrivileged(w Workload) []Violation {
+	var violations []Violation
+	for _, c := range containers(w) {
+		if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationPrivileged)})
+		}
+	}
+	return violations
+}
+
+func checkHostPath(w Workload) []Violation {
+	var violations []Violation
+	for _, volume := range w.Template.Spec.Volumes {
+		if volume.HostPath != nil {
+			violations = append(violations, Violation{Message: i18n.M(i18n.MsgViolationHostPath, volume.Name, volume.HostPath.Path)})
+		}
+	}
+	return violations
+}
+
+// checkRunAsRoot sinaliza contêineres que executam como UID 0 ou que não impedem isso: sem runAsUser
+// diferente de 0 nem runAsNonRoot, o contêiner usa o usuário da imagem, que costuma ser root.
+// As configurações do contêiner têm prioridade sobre as do pod.
+func checkRunAsRoot(w Workload) []Violation {
+	var violations []Violation
+	podContext := w.Template.Spec.SecurityContext
+	for _, c := range containers(w) {
+		var runAsUser *int64
+		var runAsNonRoot *bool
+		if podContext != nil {
+			runAsUser, runAsNonRoot = podContext.RunAsUser, podContext.RunAsNonRoot
+		}
+		if c.SecurityContext != nil {
+			if c.SecurityContext.RunAsUser != nil {
+				runAsUser = c.SecurityContext.RunAsUser
+			}
+			if c.SecurityContext.RunAsNonRoot != nil {
+				runAsNonRoot = c.SecurityContext.RunAsNonRoot
+			}
+		}
+		switch {
+		case runAsUser != nil && *runAsUser == 0:
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationRootUser)})
+		case runAsUser == nil && (runAsNonRoot == nil || !*runAsNonRoot):
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationRootAllowed)})
+		}
+	}
+	return violations
+}
+
+func checkLatestImage(w Workload) []Violation {
+	var violations []Violation
+	for _, c := range containers(w) {
+		if !pinnedImage(c.Image) {
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationLatestImage, c.Image)})
+		}
+	}
+	return violations
+}
+
+// pinnedImage indica se a imagem tem um digest ou uma tag diferente de latest. A tag fica depois do último
+// ":" do último segmento do caminho, para não confundir a porta do registry com uma tag.
+func pinnedImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	return found && tag != "" && tag != "latest"
+}
+
+func checkResources(w Workload) []Violation {
+	var violations []Violation
+	for _, c := range containers(w) {
+		var missing []string
+		for _, resource := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if _, ok := c.Resources.Requests[resource]; !ok {
+				missing = append(missing, "requests."+string(resource))
+			}
+		}
+		for _, resource := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if _, ok := c.Resources.Limits[resource]; !ok {
+				missing = append(missing, "limits."+string(resource))
+			}
+		}
+		if len(missing) > 0 {
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationMissing, strings.Join(missing, ", "))})
+		}
+	}
+	return violations
+}
+
+// checkProbes avalia apenas os contêineres comuns de workloads de longa duração: contêineres de
+// inicialização não aceitam probes, e Jobs terminam por conta própria.
+func checkProbes(w Workload) []Violation {
+	if w.Batch() {
+		return nil
+	}
+	var violations []Violation
+	for _, c := range w.Template.Spec.Containers {
+		var missing []string
+		if c.ReadinessProbe == nil {
+			missing = append(missing, "readinessProbe")
+		}
+		if c.LivenessProbe == nil {
+			missing = append(missing, "livenessProbe")
+		}
+		if len(missing) > 0 {
+			violations = append(violations, Violation{Container: c.Name, Message: i18n.M(i18n.MsgViolationMissing, strings.Join(missing, ", "))})
+		}
+	}
+	return violations
+}
+
+func checkSingleReplica(w Workload) []Violation {
+	if w.Kind != "Deployment" || w.Replicas == nil || *w.Replicas != 1 || len(w.DisruptionBudgets) > 0 {
+		return nil
+	}
+	return []Violation{{Message: i18n.M(i18n.MsgViolationSingleReplica)}}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"encoding/base64"
+	"kubeowl/internal/models"
+	"sort"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Tipos de referência de um pod a um ConfigMap ou Secret.
+const (
+	usageKindVolume          = "volume"
+	usageKindEnvFrom         = "envFrom"
+	usageKindEnv             = "env"
+	usageKindImagePullSecret = "imagePullSecret"
+)
+
+// configUsage agrupa as referências dos pods a ConfigMaps e Secrets, indexadas por "namespace/nome".
+type configUsage struct {
+	configMaps map[string][]models.ConfigUsageRef
+	secrets    map[string][]models.ConfigUsageRef
+}
+
+// collectConfigUsage percorre os pods e registra cada volume, envFrom e env valueFrom que aponta para um ConfigMap ou Secret.
+func collectConfigUsage(pods *v1.PodList) configUsage {
+	usage := configUsage{
+		configMaps: map[string][]models.ConfigUsageRef{},
+		secrets:    map[string][]models.ConfigUsageRef{},
+	}
+	if pods == nil {
+		return usage
+	}
+
+	for _, pod := range pods.Items {
+		addConfigMap := func(name string, ref models.ConfigUsageRef) {
+			ref.Pod = pod.Name
+			key := pod.Namespace + "/" + name
+			usage.configMaps[key] = append(usage.configMaps[key], ref)
+		}
+		addSecret := func(name string, ref models.ConfigUsageRef) {
+			ref.Pod = pod.Name
+			key := pod.Namespace + "/" + name
+			usage.secrets[key] = append(usage.secrets[key], ref)
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			ref := models.ConfigUsageRef{Kind: usageKindVolume, Detail: volume.Name}
+			if volume.ConfigMap != nil {
+				addConfigMap(volume.ConfigMap.Name, ref)
+			}
+			if volume.Secret != nil {
+				addSecret(volume.Secret.SecretName, ref)
+			}
+			if volume.Projected != nil {
+				for _, source := range volume.Projected.Sources {
+					if source.ConfigMap != nil {
+						addConfigMap(source.ConfigMap.Name, ref)
+					}
+					if source.Secret != nil {
+						addSecret(source.Secret.Name, ref)
+					}
+				}
+			}
+		}
+
+		containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				ref := models.ConfigUsageRef{Container: container.Name, Kind: usageKindEnvFrom}
+				if envFrom.ConfigMapRef != nil {
+					addConfigMap(envFrom.ConfigMapRef.Name, ref)
+				}
+				if envFrom.SecretRef != nil {
+					addSecret(envFrom.SecretRef.Name, ref)
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				ref := models.ConfigUsageRef{Container: container.Name, Kind: usageKindEnv, Detail: env.Name}
+				if env.ValueFrom.ConfigMapKeyRef != nil {
+					addConfigMap(env.ValueFrom.ConfigMapKeyRef.Name, ref)
+				}
+				if env.ValueFrom.SecretKeyRef != nil {
+					addSecret(env.ValueFrom.SecretKeyRef.Name, ref)
+				}
+			}
+		}
+
+		for _, pullSecret := range pod.Spec.ImagePullSecrets {
+			addSecret(pullSecret.Name, models.ConfigUsageRef{Kind: usageKindImagePullSecret})
+		}
+	}
+	return usage
+}
+
+// processConfigMaps formata os ConfigMaps com suas chaves e os pods que os referenciam.
+// Os valores só são incluídos quando includeValues é verdadeiro.
+func processConfigMaps(configMaps *v1.ConfigMapList, pods *v1.PodList, userNamespaces map[string]bool, includeValues bool) []models.ConfigMapInfo {
+	configMapInfoList := []models.ConfigMapInfo{}
+	if configMaps == nil {
+		return configMapInfoList
+	}
+
+	usage := collectConfigUsage(pods)
+	for _, cm := range configMaps.Items {
+		if !userNamespaces[cm.Namespace] {
+			continue
+		}
+
+		keys := []models.DataKeyInfo{}
+		totalSize := 0
+		for name, value := range cm.Data {
+			key := models.DataKeyInfo{Name: name, Size: len(value)}
+			if includeValues {
+				key.Value = value
+			}
+			keys = append(keys, key)
+			totalSize += len(value)
+		}
+		for name, value := range cm.BinaryData {
+			key := models.DataKeyInfo{Name: name, Size: len(value), Binary: true}
+			if includeValues {
+				key.Value = base64.StdEncoding.EncodeToString(value)
+			}
+			keys = append(keys, key)
+			totalSize += len(value)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+		usedBy := usage.configMaps[cm.Namespace+"/"+cm.Name]
+		if usedBy == nil {
+			usedBy = []models.ConfigUsageRef{}
+		}
+
+		configMapInfoList = append(configMapInfoList, models.ConfigMapInfo{
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+			KeyCount:  len(keys),
+			TotalSize: totalSize,
+			Keys:      keys,
+			UsedBy:    usedBy,
+		})
+	}
+	sort.Slice(configMapInfoList, func(i, j int) bool {
+		if configMapInfoList[i].Namespace == configMapInfoList[j].Namespace {
+			return configMapInfoList[i].Name < configMapInfoList[j].Name
+		}
+		return configMapInfoList[i].Namespace < configMapInfoList[j].Namespace
+	})
+	return configMapInfoList
+}
+
+// processSecrets formata os Secrets com suas chaves e os pods que os referenciam.
+// Os valores permanecem ocultos (Redacted) a menos que reveal seja verdadeiro.
+func processSecrets(secrets *v1.SecretList, pods *v1.PodList, userNamespaces map[string]bool, reveal bool) []models.SecretInfo {
+	secretInfoList := []models.SecretInfo{}
+	if secrets == nil {
+		return secretInfoList
+	}
+
+	usage := collectConfigUsage(pods)
+	for _, secret := range secrets.Items {
+		if !userNamespaces[secret.Namespace] {
+			continue
+		}
+
+		keys := []models.DataKeyInfo{}
+		totalSize := 0
+		for name, value := range secret.Data {
+			key := models.DataKeyInfo{Name: name, Size: len(value), Binary: !utf8.Valid(value)}
+			if reveal {
+				if key.Binary {
+					key.Value = base64.StdEncoding.EncodeToString(value)
+				} else {
+					key.Value = string(value)
+				}
+			} else {
+				key.Redacted = true
+			}
+			keys = append(keys, key)
+			totalSize += len(value)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+		usedBy := usage.secrets[secret.Namespace+"/"+secret.Name]
+		if usedBy == nil {
+			usedBy = []models.ConfigUsageRef{}
+		}
+
+		secretInfoList = append(secretInfoList, models.SecretInfo{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+			Type:      string(secret.Type),
+			KeyCount:  len(keys),
+			TotalSize: totalSize,
+			Keys:      keys,
+			UsedBy:    usedBy,
+		})
+	}
+	sort.Slice(secretInfoList, func(i, j int) bool {
+		if secretInfoList[i].Namespace == secretInfoList[j].Namespace {
+			return secretInfoList[i].Name < secretInfoList[j].Name
+		}
+		return secretInfoList[i].Namespace < secretInfoList[j].Namespace
+	})
+	return secretInfoList
+}
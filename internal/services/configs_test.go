@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestCollectConfigUsage(t *testing.T) {
+	pods := &v1.PodList{Items: []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "app-ns"},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}}},
+				{Name: "projected", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+					{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "tls"}}},
+				}}}},
+			},
+			Containers: []v1.Container{{
+				Name:    "api",
+				EnvFrom: []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "db-credentials"}}}},
+				Env: []v1.EnvVar{{Name: "LOG_LEVEL", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}, Key: "level",
+				}}}},
+			}},
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+		},
+	}}}
+
+	usage := collectConfigUsage(pods)
+	assert.Len(t, usage.configMaps["app-ns/app-config"], 2)
+	assert.Equal(t, usageKindVolume, usage.configMaps["app-ns/app-config"][0].Kind)
+	assert.Equal(t, "LOG_LEVEL", usage.configMaps["app-ns/app-config"][1].Detail)
+	assert.Equal(t, usageKindVolume, usage.secrets["app-ns/tls"][0].Kind)
+	assert.Equal(t, "api", usage.secrets["app-ns/db-credentials"][0].Container)
+	assert.Equal(t, usageKindImagePullSecret, usage.secrets["app-ns/registry"][0].Kind)
+}
+
+func TestProcessSecrets_Redaction(t *testing.T) {
+	secrets := &v1.SecretList{Items: []v1.Secret{{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "app-ns"},
+		Type:       v1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("s3cr3t"), "cert": {0xff, 0xfe}},
+	}}}
+	userNamespaces := map[string]bool{"app-ns": true}
+
+	redacted := processSecrets(secrets, nil, userNamespaces, false)
+	assert.Len(t, redacted, 1)
+	assert.Equal(t, "Opaque", redacted[0].Type)
+	assert.Equal(t, 8, redacted[0].TotalSize)
+	for _, key := range redacted[0].Keys {
+		assert.True(t, key.Redacted)
+		assert.Empty(t, key.Value)
+	}
+
+	revealed := processSecrets(secrets, nil, userNamespaces, true)
+	assert.Equal(t, "cert", revealed[0].Keys[0].Name)
+	assert.True(t, revealed[0].Keys[0].Binary)
+	assert.Equal(t, "//4=", revealed[0].Keys[0].Value)
+	assert.Equal(t, "s3cr3t", revealed[0].Keys[1].Value)
+}
+
+func TestProcessConfigMaps(t *testing.T) {
+	configMaps := &v1.ConfigMapList{Items: []v1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "app-ns"}, Data: map[string]string{"level": "debug"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "kube-system"}},
+	}}
+	userNamespaces := map[string]bool{"app-ns": true}
+
+	list := processConfigMaps(configMaps, nil, userNamespaces, false)
+	assert.Len(t, list, 1)
+	assert.Equal(t, 5, list[0].TotalSize)
+	assert.Empty(t, list[0].Keys[0].Value)
+	assert.NotNil(t, list[0].UsedBy)
+
+	detail := processConfigMaps(configMaps, nil, userNamespaces, true)
+	assert.Equal(t, "debug", detail[0].Keys[0].Value)
+}
+
+func TestProcessConfigs_NilInput(t *testing.T) {
+	assert.NotPanics(t, func() { collectConfigUsage(nil) })
+	assert.NotPanics(t, func() { processConfigMaps(nil, nil, nil, false) })
+	assert.NotPanics(t, func() { processSecrets(nil, nil, nil, false) })
+}
+
+func TestGetConfigDetail_SystemNamespaceHidden(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token", Namespace: "kube-system"}, Data: map[string][]byte{"token": []byte("s3cr3t")}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kubeadm-config", Namespace: "kube-system"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "app-ns"}, Data: map[string][]byte{"password": []byte("s3cr3t")}},
+	)
+	service := NewK8sService(clientset, metricsfake.NewSimpleClientset())
+
+	_, err := service.GetSecretDetail(context.Background(), "kube-system", "bootstrap-token", true)
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = service.GetConfigMapDetail(context.Background(), "kube-system", "kubeadm-config")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	secret, err := service.GetSecretDetail(context.Background(), "app-ns", "db-credentials", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", secret.Keys[0].Value)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/listquery"
+	"kubeowl/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetEventInfo coleta os eventos da API events.k8s.io/v1, agrega as repetições e aplica os filtros.
+func (s *k8sService) GetEventInfo(ctx context.Context, filter eventinfo.Filter) ([]models.EventInfo, error) {
+	events, err := s.clientset.EventsV1().Events(filter.Namespace).List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+	return processEvents(events, userNamespaces, filter), nil
+}
+
+// eventAggregate acumula as ocorrências de um mesmo evento.
+type eventAggregate struct {
+	info      models.EventInfo
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// processEvents agrega eventos repetidos (mesmo objeto, tipo, motivo, mensagem e controlador),
+// aplica os filtros e ordena do mais recente para o mais antigo.
+func processEvents(events *eventsv1.EventList, userNamespaces map[string]bool, filter eventinfo.Filter) []models.EventInfo {
+	eventInfoList := []models.EventInfo{}
+	if events == nil {
+		return eventInfoList
+	}
+
+	aggregates := map[string]*eventAggregate{}
+	for _, event := range events.Items {
+		if !userNamespaces[event.Namespace] && event.Namespace != "" {
+			continue
+		}
+		info, firstSeen, lastSeen := eventinfo.FromEvent(event)
+		if !filter.Matches(info) {
+			continue
+		}
+		key := strings.Join([]string{
+			info.Namespace, info.InvolvedObject.Kind, info.InvolvedObject.Namespace, info.InvolvedObject.Name,
+			info.Type, info.Reason, info.Message, info.ReportingController,
+		}, "|")
+		aggregate, ok := aggregates[key]
+		if !ok {
+			aggregates[key] = &eventAggregate{info: info, firstSeen: firstSeen, lastSeen: lastSeen}
+			continue
+		}
+		aggregate.info.Count += info.Count
+		if firstSeen.Before(aggregate.firstSeen) {
+			aggregate.firstSeen = firstSeen
+		}
+		if lastSeen.After(aggregate.lastSeen) {
+			aggregate.lastSeen = lastSeen
+			aggregate.info.ReportingInstance = info.ReportingInstance
+		}
+	}
+
+	sorted := make([]*eventAggregate, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		if !filter.Since.IsZero() && aggregate.lastSeen.Before(filter.Since) {
+			continue
+		}
+		sorted = append(sorted, aggregate)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].lastSeen.Equal(sorted[j].lastSeen) {
+			return sorted[i].info.Object < sorted[j].info.Object
+		}
+		return sorted[i].lastSeen.After(sorted[j].lastSeen)
+	})
+
+	for _, aggregate := range sorted {
+		info := aggregate.info
+		info.Timestamp = aggregate.lastSeen.Format(time.RFC822)
+		info.FirstSeen = aggregate.firstSeen.Format(time.RFC3339)
+		info.LastSeen = aggregate.lastSeen.Format(time.RFC3339)
+		eventInfoList = append(eventInfoList, info)
+	}
+	return eventInfoList
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/eventinfo"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+var eventBaseTime = time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+
+func testEvent(name, namespace, eventType, reason, kind, object, note string, at time.Time) eventsv1.Event {
+	return eventsv1.Event{
+		ObjectMeta:          metav1.ObjectMeta{Name: name, Namespace: namespace},
+		EventTime:           metav1.NewMicroTime(at),
+		Type:                eventType,
+		Reason:              reason,
+		Note:                note,
+		ReportingController: "kubelet",
+		Regarding:           v1.ObjectReference{Kind: kind, Namespace: namespace, Name: object},
+	}
+}
+
+func TestProcessEvents(t *testing.T) {
+	backOff := testEvent("e1", "app", "Warning", "BackOff", "Pod", "web-0", "Back-off restarting", eventBaseTime)
+	backOff.Series = &eventsv1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(eventBaseTime.Add(10 * time.Minute))}
+	// Evento criado pela API core/v1: sem eventTime, com os campos deprecated.
+	legacy := eventsv1.Event{
+		ObjectMeta:               metav1.ObjectMeta{Name: "e2", Namespace: "app"},
+		Type:                     "Normal",
+		Reason:                   "Scheduled",
+		Note:                     "Assigned app/web-1",
+		Regarding:                v1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "web-1"},
+		DeprecatedSource:         v1.EventSource{Component: "default-scheduler"},
+		DeprecatedFirstTimestamp: metav1.NewTime(eventBaseTime.Add(-time.Hour)),
+		DeprecatedLastTimestamp:  metav1.NewTime(eventBaseTime.Add(5 * time.Minute)),
+		DeprecatedCount:          2,
+	}
+	duplicate := testEvent("e3", "app", "Warning", "BackOff", "Pod", "web-0", "Back-off restarting", eventBaseTime.Add(20*time.Minute))
+	system := testEvent("e4", "kube-system", "Warning", "BackOff", "Pod", "coredns", "Back-off", eventBaseTime)
+	events := &eventsv1.EventList{Items: []eventsv1.Event{backOff, legacy, duplicate, system}}
+	userNamespaces := map[string]bool{"app": true}
+
+	all := processEvents(events, userNamespaces, eventinfo.Filter{})
+	if assert.Len(t, all, 2) {
+		aggregated := all[0]
+		assert.Equal(t, "Pod/web-0", aggregated.Object)
+		assert.Equal(t, int32(5), aggregated.Count)
+		assert.Equal(t, eventBaseTime.Format(time.RFC3339), aggregated.FirstSeen)
+		assert.Equal(t, eventBaseTime.Add(20*time.Minute).Format(time.RFC3339), aggregated.LastSeen)
+		assert.Equal(t, "kubelet", aggregated.ReportingController)
+		assert.Equal(t, "web-0", aggregated.InvolvedObject.Name)
+
+		scheduled := all[1]
+		assert.Equal(t, int32(2), scheduled.Count)
+		assert.Equal(t, "default-scheduler", scheduled.ReportingController)
+		assert.Equal(t, eventBaseTime.Add(-time.Hour).Format(time.RFC3339), scheduled.FirstSeen)
+		assert.Equal(t, eventBaseTime.Add(5*time.Minute).Format(time.RFC3339), scheduled.LastSeen)
+		assert.Equal(t, "app", scheduled.Namespace)
+	}
+
+	filtered := processEvents(events, userNamespaces, eventinfo.Filter{Type: "warning", Kind: "pod", Name: "web-0"})
+	assert.Len(t, filtered, 1)
+
+	since := processEvents(events, userNamespaces, eventinfo.Filter{Since: eventBaseTime.Add(15 * time.Minute)})
+	if assert.Len(t, since, 1) {
+		assert.Equal(t, "BackOff", since[0].Reason)
+	}
+}
+
+func TestGetEventInfo(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	)
+	for _, event := range []eventsv1.Event{
+		testEvent("e1", "app", "Warning", "BackOff", "Pod", "web-0", "Back-off", eventBaseTime),
+		testEvent("e2", "other", "Normal", "Pulled", "Pod", "api-0", "Pulled", eventBaseTime),
+	} {
+		_, err := clientset.EventsV1().Events(event.Namespace).Create(context.Background(), &event, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	service := NewK8sService(clientset, metricsfake.NewSimpleClientset())
+
+	events, err := service.GetEventInfo(context.Background(), eventinfo.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	events, err = service.GetEventInfo(context.Background(), eventinfo.Filter{Namespace: "app"})
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "BackOff", events[0].Reason)
+	}
+}
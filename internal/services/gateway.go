@@ -0,0 +1,371 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kubeowl/internal/models"
+	"sort"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// gatewayAPIGroup é o grupo de API dos CRDs da Gateway API.
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// Recursos da Gateway API exibidos pelo KubeOwl.
+const (
+	gatewayClassesResource = "gatewayclasses"
+	gatewaysResource       = "gateways"
+	httpRoutesResource     = "httproutes"
+	grpcRoutesResource     = "grpcroutes"
+)
+
+// Tipos de condição da Gateway API usados para indicar o estado dos recursos.
+const (
+	conditionAccepted     = "Accepted"
+	conditionProgrammed   = "Programmed"
+	conditionResolvedRefs = "ResolvedRefs"
+)
+
+// WithDynamicClient define o cliente dinâmico usado para listar CRDs opcionais, como a Gateway API.
+func WithDynamicClient(client dynamic.Interface) Option {
+	return func(s *k8sService) {
+		s.dynamicClient = client
+	}
+}
+
+// Estruturas mínimas para decodificar os objetos não tipados da Gateway API.
+type gatewayCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+type gatewayParentRef struct {
+	Kind        string `json:"kind,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type gatewayClassObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ControllerName string `json:"controllerName"`
+		Description    string `json:"description,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []gatewayCondition `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+type gatewayObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+		Listeners        []struct {
+			Name     string `json:"name"`
+			Hostname string `json:"hostname,omitempty"`
+			Port     int32  `json:"port"`
+			Protocol string `json:"protocol"`
+		} `json:"listeners"`
+	} `json:"spec"`
+	Status struct {
+		Addresses []struct {
+			Value string `json:"value"`
+		} `json:"addresses,omitempty"`
+		Conditions []gatewayCondition `json:"conditions,omitempty"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []gatewayCondition `json:"conditions,omitempty"`
+		} `json:"listeners,omitempty"`
+	} `json:"status"`
+}
+
+type routeObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ParentRefs []gatewayParentRef `json:"parentRefs,omitempty"`
+		Hostnames  []string           `json:"hostnames,omitempty"`
+		Rules      []struct {
+			BackendRefs []struct {
+				Kind      string `json:"kind,omitempty"`
+				Namespace string `json:"namespace,omitempty"`
+				Name      string `json:"name"`
+				Port      *int32 `json:"port,omitempty"`
+			} `json:"backendRefs,omitempty"`
+		} `json:"rules,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef  gatewayParentRef   `json:"parentRef"`
+			Conditions []gatewayCondition `json:"conditions,omitempty"`
+		} `json:"parents,omitempty"`
+	} `json:"status"`
+}
+
+// conditionStatus retorna o status ("True", "False" ou "Unknown") da condição informada, ou "" se ausente.
+func conditionStatus(conditions []gatewayCondition, conditionType string) string {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return ""
+}
+
+// discoverGatewayAPI consulta o discovery do API server e retorna, para cada recurso da Gateway API,
+// a versão servida mais adequada (a preferida primeiro). O mapa fica vazio se os CRDs não estiverem instalados.
+func (s *k8sService) discoverGatewayAPI() (map[string]string, string, error) {
+	versions := map[string]string{}
+	groups, err := s.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != gatewayAPIGroup {
+			continue
+		}
+		ordered := []metav1.GroupVersionForDiscovery{group.PreferredVersion}
+		for _, version := range group.Versions {
+			if version.Version != group.PreferredVersion.Version {
+				ordered = append(ordered, version)
+			}
+		}
+		for _, version := range ordered {
+			resources, err := s.clientset.Discovery().ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				continue
+			}
+			for _, resource := range resources.APIResources {
+				if _, ok := versions[resource.Name]; !ok {
+					versions[resource.Name] = version.Version
+				}
+			}
+		}
+		return versions, group.PreferredVersion.Version, nil
+	}
+	return versions, "", nil
+}
+
+// listGatewayResource lista um recurso da Gateway API e decodifica cada item em out (um ponteiro para slice).
+func (s *k8sService) listGatewayResource(ctx context.Context, resource, version, namespace string, out interface{}) error {
+	gvr := schema.GroupVersionResource{Group: gatewayAPIGroup, Version: version, Resource: resource}
+	var list *unstructured.UnstructuredList
+	var err error
+	if resource == gatewayClassesResource {
+		list, err = s.dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = s.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("falha ao listar %s: %w", resource, err)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(list.UnstructuredContent(), out)
+}
+
+// GetGatewayAPIInfo lista GatewayClasses, Gateways, HTTPRoutes e GRPCRoutes quando a Gateway API está instalada.
+// Um namespace vazio retorna todos os namespaces.
+func (s *k8sService) GetGatewayAPIInfo(ctx context.Context, namespace string) (*models.GatewayAPIInfo, error) {
+	info := &models.GatewayAPIInfo{
+		GatewayClasses: []models.GatewayClassInfo{},
+		Gateways:       []models.GatewayInfo{},
+		HTTPRoutes:     []models.RouteInfo{},
+		GRPCRoutes:     []models.RouteInfo{},
+	}
+	if s.dynamicClient == nil {
+		return info, nil
+	}
+	versions, preferred, err := s.discoverGatewayAPI()
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return info, nil
+	}
+	info.Installed = true
+	info.Version = preferred
+
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+
+	if version, ok := versions[gatewayClassesResource]; ok {
+		var list struct {
+			Items []gatewayClassObject `json:"items"`
+		}
+		if err := s.listGatewayResource(ctx, gatewayClassesResource, version, "", &list); err != nil {
+			return nil, err
+		}
+		info.GatewayClasses = processGatewayClasses(list.Items)
+	}
+	if version, ok := versions[gatewaysResource]; ok {
+		var list struct {
+			Items []gatewayObject `json:"items"`
+		}
+		if err := s.listGatewayResource(ctx, gatewaysResource, version, namespace, &list); err != nil {
+			return nil, err
+		}
+		info.Gateways = processGateways(list.Items, userNamespaces)
+	}
+	for resource, kind := range map[string]string{httpRoutesResource: "HTTPRoute", grpcRoutesResource: "GRPCRoute"} {
+		version, ok := versions[resource]
+		if !ok {
+			continue
+		}
+		var list struct {
+			Items []routeObject `json:"items"`
+		}
+		if err := s.listGatewayResource(ctx, resource, version, namespace, &list); err != nil {
+			return nil, err
+		}
+		routes := processRoutes(kind, list.Items, userNamespaces)
+		if resource == httpRoutesResource {
+			info.HTTPRoutes = routes
+		} else {
+			info.GRPCRoutes = routes
+		}
+	}
+	return info, nil
+}
+
+// processGatewayClasses formata as GatewayClasses.
+func processGatewayClasses(classes []gatewayClassObject) []models.GatewayClassInfo {
+	classInfoList := []models.GatewayClassInfo{}
+	for _, class := range classes {
+		classInfoList = append(classInfoList, models.GatewayClassInfo{
+			Name:        class.Name,
+			Controller:  class.Spec.ControllerName,
+			Description: class.Spec.Description,
+			Accepted:    conditionStatus(class.Status.Conditions, conditionAccepted),
+		})
+	}
+	sort.Slice(classInfoList, func(i, j int) bool { return classInfoList[i].Name < classInfoList[j].Name })
+	return classInfoList
+}
+
+// processGateways formata os Gateways com seus listeners e o total de rotas associadas.
+func processGateways(gateways []gatewayObject, userNamespaces map[string]bool) []models.GatewayInfo {
+	gatewayInfoList := []models.GatewayInfo{}
+	for _, gateway := range gateways {
+		if !userNamespaces[gateway.Namespace] {
+			continue
+		}
+
+		info := models.GatewayInfo{
+			Name:         gateway.Name,
+			Namespace:    gateway.Namespace,
+			GatewayClass: gateway.Spec.GatewayClassName,
+			Addresses:    []string{},
+			Listeners:    []models.GatewayListenerInfo{},
+			Accepted:     conditionStatus(gateway.Status.Conditions, conditionAccepted),
+			Programmed:   conditionStatus(gateway.Status.Conditions, conditionProgrammed),
+		}
+		for _, address := range gateway.Status.Addresses {
+			info.Addresses = append(info.Addresses, address.Value)
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			listenerInfo := models.GatewayListenerInfo{
+				Name:     listener.Name,
+				Protocol: listener.Protocol,
+				Port:     listener.Port,
+				Hostname: listener.Hostname,
+			}
+			for _, status := range gateway.Status.Listeners {
+				if status.Name == listener.Name {
+					listenerInfo.AttachedRoutes = status.AttachedRoutes
+					listenerInfo.Programmed = conditionStatus(status.Conditions, conditionProgrammed)
+				}
+			}
+			info.AttachedRoutes += listenerInfo.AttachedRoutes
+			info.Listeners = append(info.Listeners, listenerInfo)
+		}
+		gatewayInfoList = append(gatewayInfoList, info)
+	}
+	sort.Slice(gatewayInfoList, func(i, j int) bool {
+		if gatewayInfoList[i].Namespace == gatewayInfoList[j].Namespace {
+			return gatewayInfoList[i].Name < gatewayInfoList[j].Name
+		}
+		return gatewayInfoList[i].Namespace < gatewayInfoList[j].Namespace
+	})
+	return gatewayInfoList
+}
+
+// processRoutes formata HTTPRoutes ou GRPCRoutes, associando cada parentRef ao estado reportado pelo controlador.
+func processRoutes(kind string, routes []routeObject, userNamespaces map[string]bool) []models.RouteInfo {
+	routeInfoList := []models.RouteInfo{}
+	for _, route := range routes {
+		if !userNamespaces[route.Namespace] {
+			continue
+		}
+
+		info := models.RouteInfo{
+			Kind:       kind,
+			Name:       route.Name,
+			Namespace:  route.Namespace,
+			Hostnames:  route.Spec.Hostnames,
+			ParentRefs: []models.RouteParentInfo{},
+			Backends:   []string{},
+		}
+		if info.Hostnames == nil {
+			info.Hostnames = []string{}
+		}
+
+		for _, ref := range route.Spec.ParentRefs {
+			// Um parentRef sem namespace aponta para o namespace da própria rota.
+			parentNamespace := ref.Namespace
+			if parentNamespace == "" {
+				parentNamespace = route.Namespace
+			}
+			parent := models.RouteParentInfo{Name: ref.Name, Namespace: parentNamespace, SectionName: ref.SectionName}
+			for _, status := range route.Status.Parents {
+				statusNamespace := status.ParentRef.Namespace
+				if statusNamespace == "" {
+					statusNamespace = route.Namespace
+				}
+				if status.ParentRef.Name == ref.Name && statusNamespace == parentNamespace && status.ParentRef.SectionName == ref.SectionName {
+					parent.Accepted = conditionStatus(status.Conditions, conditionAccepted)
+					parent.ResolvedRefs = conditionStatus(status.Conditions, conditionResolvedRefs)
+				}
+			}
+			info.ParentRefs = append(info.ParentRefs, parent)
+		}
+
+		seen := map[string]bool{}
+		for _, rule := range route.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				name := backend.Name
+				if backend.Namespace != "" && backend.Namespace != route.Namespace {
+					name = backend.Namespace + "/" + name
+				}
+				if backend.Kind != "" && backend.Kind != "Service" {
+					name = backend.Kind + "/" + name
+				}
+				if backend.Port != nil {
+					name += ":" + strconv.Itoa(int(*backend.Port))
+				}
+				if !seen[name] {
+					seen[name] = true
+					info.Backends = append(info.Backends, name)
+				}
+			}
+		}
+		routeInfoList = append(routeInfoList, info)
+	}
+	sort.Slice(routeInfoList, func(i, j int) bool {
+		if routeInfoList[i].Namespace == routeInfoList[j].Namespace {
+			return routeInfoList[i].Name < routeInfoList[j].Name
+		}
+		return routeInfoList[i].Namespace < routeInfoList[j].Namespace
+	})
+	return routeInfoList
+}
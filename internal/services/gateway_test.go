@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func gatewayAPIObject(kind, namespace, name string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": gatewayAPIGroup + "/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       spec,
+		"status":     status,
+	}}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	return obj
+}
+
+func newGatewayTestService(t *testing.T, withCRDs bool, objects ...*unstructured.Unstructured) Service {
+	clientset := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}})
+	if withCRDs {
+		clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: gatewayAPIGroup + "/v1",
+			APIResources: []metav1.APIResource{
+				{Name: gatewayClassesResource, Kind: "GatewayClass"},
+				{Name: gatewaysResource, Namespaced: true, Kind: "Gateway"},
+				{Name: httpRoutesResource, Namespaced: true, Kind: "HTTPRoute"},
+				{Name: grpcRoutesResource, Namespaced: true, Kind: "GRPCRoute"},
+			},
+		}}
+	}
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: gatewayAPIGroup, Version: "v1", Resource: gatewayClassesResource}: "GatewayClassList",
+		{Group: gatewayAPIGroup, Version: "v1", Resource: gatewaysResource}:       "GatewayList",
+		{Group: gatewayAPIGroup, Version: "v1", Resource: httpRoutesResource}:     "HTTPRouteList",
+		{Group: gatewayAPIGroup, Version: "v1", Resource: grpcRoutesResource}:     "GRPCRouteList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	// O cliente falso deduz o recurso a partir do Kind ("Gateway" viraria "gatewaies"), por isso os objetos
+	// são registrados com o GVR explícito.
+	resources := map[string]string{
+		"GatewayClass": gatewayClassesResource,
+		"Gateway":      gatewaysResource,
+		"HTTPRoute":    httpRoutesResource,
+		"GRPCRoute":    grpcRoutesResource,
+	}
+	for _, obj := range objects {
+		gvr := schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1", Resource: resources[obj.GetKind()]}
+		if err := dynamicClient.Tracker().Create(gvr, obj, obj.GetNamespace()); err != nil {
+			t.Fatalf("falha ao registrar %s: %v", obj.GetName(), err)
+		}
+	}
+	return NewK8sService(clientset, metricsfake.NewSimpleClientset(), WithDynamicClient(dynamicClient))
+}
+
+func TestGetGatewayAPIInfo(t *testing.T) {
+	service := newGatewayTestService(t, true,
+		gatewayAPIObject("GatewayClass", "", "envoy",
+			map[string]interface{}{"controllerName": "gateway.envoyproxy.io/controller"},
+			map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Accepted", "status": "True"}}},
+		),
+		gatewayAPIObject("Gateway", "app", "public",
+			map[string]interface{}{
+				"gatewayClassName": "envoy",
+				"listeners": []interface{}{
+					map[string]interface{}{"name": "http", "port": int64(80), "protocol": "HTTP"},
+					map[string]interface{}{"name": "https", "port": int64(443), "protocol": "HTTPS", "hostname": "*.example.com"},
+				},
+			},
+			map[string]interface{}{
+				"addresses": []interface{}{map[string]interface{}{"type": "IPAddress", "value": "203.0.113.5"}},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Accepted", "status": "True"},
+					map[string]interface{}{"type": "Programmed", "status": "False"},
+				},
+				"listeners": []interface{}{
+					map[string]interface{}{"name": "http", "attachedRoutes": int64(2), "conditions": []interface{}{map[string]interface{}{"type": "Programmed", "status": "True"}}},
+					map[string]interface{}{"name": "https", "attachedRoutes": int64(1)},
+				},
+			},
+		),
+		gatewayAPIObject("HTTPRoute", "app", "shop",
+			map[string]interface{}{
+				"parentRefs": []interface{}{map[string]interface{}{"name": "public", "sectionName": "http"}},
+				"hostnames":  []interface{}{"shop.example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{"backendRefs": []interface{}{map[string]interface{}{"name": "frontend", "port": int64(8080)}}},
+					map[string]interface{}{"backendRefs": []interface{}{map[string]interface{}{"name": "frontend", "port": int64(8080)}}},
+				},
+			},
+			map[string]interface{}{"parents": []interface{}{map[string]interface{}{
+				"parentRef":      map[string]interface{}{"name": "public", "sectionName": "http"},
+				"controllerName": "gateway.envoyproxy.io/controller",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Accepted", "status": "True"},
+					map[string]interface{}{"type": "ResolvedRefs", "status": "False"},
+				},
+			}}},
+		),
+		gatewayAPIObject("GRPCRoute", "app", "orders",
+			map[string]interface{}{"parentRefs": []interface{}{map[string]interface{}{"name": "public", "namespace": "app"}}},
+			map[string]interface{}{},
+		),
+		gatewayAPIObject("HTTPRoute", "kube-system", "hidden", map[string]interface{}{}, map[string]interface{}{}),
+	)
+
+	info, err := service.GetGatewayAPIInfo(context.Background(), "")
+	assert.NoError(t, err)
+	assert.True(t, info.Installed)
+	assert.Equal(t, "v1", info.Version)
+
+	assert.Equal(t, []models.GatewayClassInfo{{Name: "envoy", Controller: "gateway.envoyproxy.io/controller", Accepted: "True"}}, info.GatewayClasses)
+
+	if assert.Len(t, info.Gateways, 1) {
+		gateway := info.Gateways[0]
+		assert.Equal(t, "envoy", gateway.GatewayClass)
+		assert.Equal(t, []string{"203.0.113.5"}, gateway.Addresses)
+		assert.Equal(t, "True", gateway.Accepted)
+		assert.Equal(t, "False", gateway.Programmed)
+		assert.Equal(t, int32(3), gateway.AttachedRoutes)
+		assert.Equal(t, []models.GatewayListenerInfo{
+			{Name: "http", Protocol: "HTTP", Port: 80, AttachedRoutes: 2, Programmed: "True"},
+			{Name: "https", Protocol: "HTTPS", Port: 443, Hostname: "*.example.com", AttachedRoutes: 1},
+		}, gateway.Listeners)
+	}
+
+	assert.Equal(t, []models.RouteInfo{{
+		Kind:       "HTTPRoute",
+		Name:       "shop",
+		Namespace:  "app",
+		Hostnames:  []string{"shop.example.com"},
+		ParentRefs: []models.RouteParentInfo{{Name: "public", Namespace: "app", SectionName: "http", Accepted: "True", ResolvedRefs: "False"}},
+		Backends:   []string{"frontend:8080"},
+	}}, info.HTTPRoutes)
+
+	if assert.Len(t, info.GRPCRoutes, 1) {
+		assert.Equal(t, "GRPCRoute", info.GRPCRoutes[0].Kind)
+		assert.Equal(t, []models.RouteParentInfo{{Name: "public", Namespace: "app"}}, info.GRPCRoutes[0].ParentRefs)
+	}
+}
+
+func TestGetGatewayAPIInfo_NotInstalled(t *testing.T) {
+	info, err := newGatewayTestService(t, false).GetGatewayAPIInfo(context.Background(), "")
+	assert.NoError(t, err)
+	assert.False(t, info.Installed)
+	assert.Empty(t, info.Gateways)
+
+	withoutDynamic := NewK8sService(fake.NewSimpleClientset(), metricsfake.NewSimpleClientset())
+	info, err = withoutDynamic.GetGatewayAPIInfo(context.Background(), "")
+	assert.NoError(t, err)
+	assert.False(t, info.Installed)
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/models"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// pendingGracePeriod é por quanto tempo um pod ou PVC pode ficar pendente antes de ser sinalizado.
+	pendingGracePeriod = 5 * time.Minute
+	// maxPenaltyPerCheck limita os pontos descontados por uma única verificação, para que um problema
+	// repetido em muitos objetos não encubra os demais na pontuação.
+	maxPenaltyPerCheck = 30
+	// healthReportCacheTTL é por quanto tempo um relatório de saúde é reaproveitado. A visão geral e o
+	// relatório completo são pedidos juntos pela interface, e assim as listagens não se repetem.
+	healthReportCacheTTL = 15 * time.Second
+	// healthReportBuildTimeout limita o cálculo compartilhado de um relatório, que não é cancelado junto com o
+	// pedido que o iniciou.
+	healthReportBuildTimeout = 30 * time.Second
+)
+
+// severityPenalty são os pontos descontados da pontuação por achado de cada severidade.
+var severityPenalty = map[string]int{
+	models.SeverityCritical: 10,
+	models.SeverityWarning:  4,
+	models.SeverityInfo:     1,
+}
+
+// imagePullReasons são os motivos de espera de um contêiner que não consegue baixar a imagem.
+var imagePullReasons = map[string]bool{"ImagePullBackOff": true, "ErrImagePull": true, "InvalidImageName": true}
+
+// nodePressureConditions são as condições de pressão de recursos de um nó.
+var nodePressureConditions = []v1.NodeConditionType{v1.NodeMemoryPressure, v1.NodeDiskPressure, v1.NodePIDPressure}
+
+// healthInputs reúne os objetos analisados pelo relatório de saúde.
+type healthInputs struct {
+	nodes          *v1.NodeList
+	pods           *v1.PodList
+	deployments    *appsv1.DeploymentList
+	replicaSets    *appsv1.ReplicaSetList
+	services       *v1.ServiceList
+	endpointSlices *discoveryv1.EndpointSliceList
+	pvcs           *v1.PersistentVolumeClaimList
+	userNamespaces map[string]bool
+}
+
+// GetHealthReport analisa os recursos do cluster e retorna os problemas encontrados com a pontuação de saúde.
+// As mensagens dos achados seguem o idioma do contexto, e o relatório é reaproveitado por healthReportCacheTTL.
+func (s *k8sService) GetHealthReport(ctx context.Context) (*models.HealthReport, error) {
+	return s.healthReportCache.get(ctx, i18n.FromContext(ctx), s.buildHealthReport)
+}
+
+// healthReportCache guarda o último relatório de saúde de cada idioma, já que as mensagens dos achados são traduzidas.
+type healthReportCache struct {
+	mu       sync.Mutex
+	entries  map[string]healthReportEntry
+	inflight map[string]*healthReportCall
+}
+
+type healthReportEntry struct {
+	report  *models.HealthReport
+	fetched time.Time
+}
+
+// healthReportCall é um cálculo em andamento, aguardado por todos os pedidos do mesmo idioma.
+type healthReportCall struct {
+	done   chan struct{}
+	report *models.HealthReport
+	err    error
+}
+
+func newHealthReportCache() *healthReportCache {
+	return &healthReportCache{entries: map[string]healthReportEntry{}, inflight: map[string]*healthReportCall{}}
+}
+
+// get retorna o relatório guardado com menos de healthReportCacheTTL ou calcula um novo com build. Pedidos
+// simultâneos aguardam o mesmo cálculo, feito fora do lock e sem o cancelamento do pedido que o iniciou (limitado
+// por healthReportBuildTimeout); cada pedido ainda desiste ao fim do próprio contexto. Erros não são guardados, e
+// cada chamador recebe a própria cópia do relatório.
+func (c *healthReportCache) get(ctx context.Context, lang string, build func(context.Context) (*models.HealthReport, error)) (*models.HealthReport, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[lang]; ok && time.Since(entry.fetched) < healthReportCacheTTL {
+		c.mu.Unlock()
+		return cloneHealthReport(entry.report), nil
+	}
+	call, ok := c.inflight[lang]
+	if !ok {
+		call = &healthReportCall{done: make(chan struct{})}
+		c.inflight[lang] = call
+		go c.run(ctx, lang, call, build)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		return cloneHealthReport(call.report), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run calcula o relatório de um idioma e o guarda antes de liberar quem o aguarda.
+func (c *healthReportCache) run(ctx context.Context, lang string, call *healthReportCall, build func(context.Context) (*models.HealthReport, error)) {
+	buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), healthReportBuildTimeout)
+	defer cancel()
+	call.report, call.err = build(buildCtx)
+
+	c.mu.Lock()
+	delete(c.inflight, lang)
+	if call.err == nil {
+		c.entries[lang] = healthReportEntry{report: call.report, fetched: time.Now()}
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// cloneHealthReport copia o relatório, incluindo a lista de achados, para que quem o recebe possa alterá-lo.
+func cloneHealthReport(report *models.HealthReport) *models.HealthReport {
+	copied := *report
+	copied.Findings = slices.Clone(report.Findings)
+	return &copied
+}
+
+// buildHealthReport lista os objetos do cluster e monta o relatório de saúde.
+func (s *k8sService) buildHealthReport(ctx context.Context) (*models.HealthReport, error) {
+	var in healthInputs
+	var err error
+	if in.nodes, err = s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if in.pods, err = s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if in.deployments, err = s.clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if in.services, err = s.clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if in.pvcs, err = s.clientset.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, in.userNamespaces = processNamespaces(namespaces)
+	// Sem EndpointSlices a verificação de endpoints é omitida; sem ReplicaSets os pods são agrupados pelo ReplicaSet.
+	in.endpointSlices, _ = s.clientset.DiscoveryV1().EndpointSlices("").List(ctx, metav1.ListOptions{})
+	in.replicaSets, _ = s.clientset.AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+
+	report := processHealthReport(in, time.Now(), i18n.FromContext(ctx))
+	return &report, nil
+}
+
+// processHealthReport executa as verificações e calcula a pontuação.
+func processHealthReport(in healthInputs, now time.Time, lang string) models.HealthReport {
+	findings := []models.HealthFinding{}
+	add := func(check, severity, kind, namespace, name string, message i18n.Message) {
+		findings = append(findings, models.HealthFinding{
+			Check: check, Severity: severity, Kind: kind, Namespace: namespace, Name: name, Message: message.In(lang),
+		})
+	}
+
+	if in.nodes != nil {
+		for _, node := range in.nodes.Items {
+			checkNode(node, add)
+		}
+	}
+	if in.pods != nil {
+		missingRequests := map[workloadRef][]string{}
+		var workloads []workloadRef
+		replicaSets := map[string]appsv1.ReplicaSet{}
+		if in.replicaSets != nil {
+			for _, rs := range in.replicaSets.Items {
+				replicaSets[rs.Namespace+"/"+rs.Name] = rs
+			}
+		}
+		for _, pod := range in.pods.Items {
+			if !in.userNamespaces[pod.Namespace] {
+				continue
+			}
+			checkPod(pod, now, add)
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			if containers := containersWithoutRequests(pod); len(containers) > 0 {
+				ref := podWorkloadRef(pod, replicaSets)
+				if _, ok := missingRequests[ref]; !ok {
+					workloads = append(workloads, ref)
+				}
+				missingRequests[ref] = mergeNames(missingRequests[ref], containers)
+			}
+		}
+		for _, ref := range workloads {
+			add(models.CheckMissingRequests, models.SeverityInfo, ref.kind, ref.namespace, ref.name,
+				i18n.M(i18n.MsgFindingMissingRequests, strings.Join(missingRequests[ref], ", ")))
+		}
+	}
+	if in.deployments != nil {
+		for _, deployment := range in.deployments.Items {
+			if !in.userNamespaces[deployment.Namespace] {
+				continue
+			}
+			desired := int32(1)
+			if deployment.Spec.Replicas != nil {
+				desired = *deployment.Spec.Replicas
+			}
+			available := deployment.Status.AvailableReplicas
+			if desired == 0 || available >= desired {
+				continue
+			}
+			severity := models.SeverityWarning
+			if available == 0 {
+				severity = models.SeverityCritical
+			}
+			add(models.CheckDeploymentUnavailable, severity, "Deployment", deployment.Namespace, deployment.Name,
+				i18n.M(i18n.MsgFindingDeploymentUnavailable, desired-available, desired))
+		}
+	}
+	if in.services != nil && in.endpointSlices != nil {
+		endpoints := countServiceEndpoints(in.endpointSlices)
+		for _, service := range in.services.Items {
+			if !in.userNamespaces[service.Namespace] || len(service.Spec.Selector) == 0 || service.Spec.Type == v1.ServiceTypeExternalName {
+				continue
+			}
+			if endpoints[service.Namespace+"/"+service.Name].ready > 0 {
+				continue
+			}
+			add(models.CheckServiceNoEndpoints, models.SeverityWarning, "Service", service.Namespace, service.Name,
+				i18n.M(i18n.MsgFindingServiceNoEndpoints, labels.SelectorFromSet(service.Spec.Selector).String()))
+		}
+	}
+	if in.pvcs != nil {
+		for _, pvc := range in.pvcs.Items {
+			if !in.userNamespaces[pvc.Namespace] || pvc.Status.Phase != v1.ClaimPending {
+				continue
+			}
+			age := now.Sub(pvc.CreationTimestamp.Time)
+			if age < pendingGracePeriod {
+				continue
+			}
+			add(models.CheckPvcPending, models.SeverityWarning, "PersistentVolumeClaim", pvc.Namespace, pvc.Name,
+				i18n.M(i18n.MsgFindingPvcPending, formatAge(age)))
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		return models.CompareRanked(models.SeverityRank(a.Severity), models.SeverityRank(b.Severity),
+			[]string{a.Namespace, a.Kind, a.Name}, []string{b.Namespace, b.Kind, b.Name}) < 0
+	})
+	return models.HealthReport{Summary: summarizeHealth(findings), Findings: findings}
+}
+
+// summarizeHealth conta os achados e calcula a pontuação: cada achado desconta pontos conforme a severidade,
+// limitados a maxPenaltyPerCheck por verificação. Qualquer achado crítico torna o estado crítico, e qualquer
+// aviso o torna degradado; achados informativos não alteram o estado.
+func summarizeHealth(findings []models.HealthFinding) models.HealthSummary {
+	summary := models.HealthSummary{Score: 100, Status: models.HealthStatusHealthy}
+	penalties := map[string]int{}
+	for _, finding := range findings {
+		switch finding.Severity {
+		case models.SeverityCritical:
+			summary.Critical++
+		case models.SeverityWarning:
+			summary.Warning++
+		case models.SeverityInfo:
+			summary.Info++
+		}
+		penalties[finding.Check] += severityPenalty[finding.Severity]
+	}
+	for _, penalty := range penalties {
+		summary.Score -= min(penalty, maxPenaltyPerCheck)
+	}
+	summary.Score = max(summary.Score, 0)
+	switch {
+	case summary.Critical > 0:
+		summary.Status = models.HealthStatusCritical
+	case summary.Warning > 0:
+		summary.Status = models.HealthStatusDegraded
+	}
+	return summary
+}
+
+type addFinding func(check, severity, kind, namespace, name string, message i18n.Message)
+
+// checkNode sinaliza nós que não estão prontos ou que estão sob pressão de memória, disco ou PIDs.
+func checkNode(node v1.Node, add addFinding) {
+	ready := false
+	detail := "Unknown"
+	var pressures []string
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			ready = condition.Status == v1.ConditionTrue
+			if condition.Message != "" {
+				detail = condition.Message
+			} else if condition.Reason != "" {
+				detail = condition.Reason
+			}
+		}
+		for _, pressure := range nodePressureConditions {
+			if condition.Type == pressure && condition.Status == v1.ConditionTrue {
+				pressures = append(pressures, string(condition.Type))
+			}
+		}
+	}
+	if !ready {
+		add(models.CheckNodeNotReady, models.SeverityCritical, "Node", "", node.Name, i18n.M(i18n.MsgFindingNodeNotReady, detail))
+	}
+	if len(pressures) > 0 {
+		add(models.CheckNodePressure, models.SeverityWarning, "Node", "", node.Name, i18n.M(i18n.MsgFindingNodePressure, strings.Join(pressures, ", ")))
+	}
+}
+
+// checkPod sinaliza contêineres em crash loop ou sem conseguir baixar a imagem, e pods pendentes além do
+// período de tolerância.
+func checkPod(pod v1.Pod, now time.Time, add addFinding) {
+	imagePullFailed := false
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		reason := status.State.Waiting.Reason
+		switch {
+		case reason == "CrashLoopBackOff":
+			add(models.CheckPodCrashLoop, models.SeverityCritical, "Pod", pod.Namespace, pod.Name,
+				i18n.M(i18n.MsgFindingCrashLoop, status.Name, status.RestartCount))
+		case imagePullReasons[reason]:
+			imagePullFailed = true
+			add(models.CheckPodImagePull, models.SeverityCritical, "Pod", pod.Namespace, pod.Name,
+				i18n.M(i18n.MsgFindingImagePull, status.Name, status.Image, reason))
+		}
+	}
+	if pod.Status.Phase != v1.PodPending || imagePullFailed {
+		return
+	}
+	if age := now.Sub(pod.CreationTimestamp.Time); age >= pendingGracePeriod {
+		add(models.CheckPodPending, models.SeverityWarning, "Pod", pod.Namespace, pod.Name, i18n.M(i18n.MsgFindingPodPending, formatAge(age)))
+	}
+}
+
+// containersWithoutRequests lista os contêineres do pod sem request de CPU ou de memória.
+func containersWithoutRequests(pod v1.Pod) []string {
+	var names []string
+	for _, container := range pod.Spec.Containers {
+		_, hasCPU := container.Resources.Requests[v1.ResourceCPU]
+		_, hasMemory := container.Resources.Requests[v1.ResourceMemory]
+		if !hasCPU || !hasMemory {
+			names = append(names, container.Name)
+		}
+	}
+	return names
+}
+
+// workloadRef identifica o controlador de um pod, para que os achados de configuração sejam reportados
+// uma vez por workload em vez de uma vez por réplica.
+type workloadRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// mergeNames acrescenta os nomes ainda ausentes, preservando a ordem.
+func mergeNames(names, extra []string) []string {
+	for _, name := range extra {
+		found := false
+		for _, existing := range names {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+	return names
+}
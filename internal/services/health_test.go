@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/models"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func healthFixtures(now time.Time) []runtime.Object {
+	controller := true
+	replicas := int32(3)
+	old := metav1.NewTime(now.Add(-time.Hour))
+	recent := metav1.NewTime(now.Add(-time.Minute))
+	requests := v1.ResourceRequirements{Requests: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("100m"),
+		v1.ResourceMemory: resource.MustParse("64Mi"),
+	}}
+	return []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse},
+			}},
+		},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionUnknown, Reason: "NodeStatusUnknown"},
+			}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "app"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 2},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-5f6", Namespace: "app",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &controller}},
+		}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "api-5f6-a", Namespace: "app", CreationTimestamp: old,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5f6", Controller: &controller}},
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "api"}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{{
+				Name: "api", RestartCount: 12,
+				State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "api-5f6-b", Namespace: "app", CreationTimestamp: old,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5f6", Controller: &controller}},
+			},
+			Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "api"}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "app", CreationTimestamp: old},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "worker", Resources: requests}}},
+			Status: v1.PodStatus{Phase: v1.PodPending, ContainerStatuses: []v1.ContainerStatus{{
+				Name: "worker", Image: "registry.example.com/worker:missing",
+				State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "unscheduled", Namespace: "app", CreationTimestamp: old},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "main", Resources: requests}}},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "starting", Namespace: "app", CreationTimestamp: recent},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "main", Resources: requests}}},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		// Pods de namespaces do sistema não entram no relatório.
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system", CreationTimestamp: old},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "coredns"}}},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "app"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "api"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "app"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "orphan"}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "app"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: "api-abc", Namespace: "app", Labels: map[string]string{discoveryv1.LabelServiceName: "api"}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}}},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "app", CreationTimestamp: old},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "app", CreationTimestamp: recent},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+	}
+}
+
+func findingsByCheck(findings []models.HealthFinding) map[string][]models.HealthFinding {
+	result := map[string][]models.HealthFinding{}
+	for _, finding := range findings {
+		result[finding.Check] = append(result[finding.Check], finding)
+	}
+	return result
+}
+
+func TestGetHealthReport(t *testing.T) {
+	service := NewK8sService(fake.NewSimpleClientset(healthFixtures(time.Now())...), metricsfake.NewSimpleClientset())
+
+	report, err := service.GetHealthReport(context.Background())
+	assert.NoError(t, err)
+
+	checks := findingsByCheck(report.Findings)
+	if assert.Len(t, checks[models.CheckPodCrashLoop], 1) {
+		assert.Equal(t, "api-5f6-a", checks[models.CheckPodCrashLoop][0].Name)
+		assert.Equal(t, "Contêiner api em CrashLoopBackOff (12 reinícios)", checks[models.CheckPodCrashLoop][0].Message)
+	}
+	if assert.Len(t, checks[models.CheckPodImagePull], 1) {
+		assert.Equal(t, "worker", checks[models.CheckPodImagePull][0].Name)
+		assert.Contains(t, checks[models.CheckPodImagePull][0].Message, "registry.example.com/worker:missing")
+	}
+	// O pod com falha de imagem não é contado de novo como pendente, e o pod recente ainda está no período de tolerância.
+	if assert.Len(t, checks[models.CheckPodPending], 1) {
+		assert.Equal(t, "unscheduled", checks[models.CheckPodPending][0].Name)
+	}
+	if assert.Len(t, checks[models.CheckDeploymentUnavailable], 1) {
+		assert.Equal(t, models.SeverityWarning, checks[models.CheckDeploymentUnavailable][0].Severity)
+		assert.Equal(t, "1 de 3 réplicas indisponíveis", checks[models.CheckDeploymentUnavailable][0].Message)
+	}
+	if assert.Len(t, checks[models.CheckServiceNoEndpoints], 1) {
+		assert.Equal(t, "orphan", checks[models.CheckServiceNoEndpoints][0].Name)
+	}
+	if assert.Len(t, checks[models.CheckPvcPending], 1) {
+		assert.Equal(t, "data", checks[models.CheckPvcPending][0].Name)
+	}
+	if assert.Len(t, checks[models.CheckNodeNotReady], 1) {
+		assert.Equal(t, "node-2", checks[models.CheckNodeNotReady][0].Name)
+		assert.Equal(t, "Nó não está pronto: NodeStatusUnknown", checks[models.CheckNodeNotReady][0].Message)
+	}
+	if assert.Len(t, checks[models.CheckNodePressure], 1) {
+		assert.Equal(t, "Nó sob pressão: MemoryPressure", checks[models.CheckNodePressure][0].Message)
+	}
+	// As réplicas sem requests são agrupadas no Deployment dono.
+	if assert.Len(t, checks[models.CheckMissingRequests], 1) {
+		assert.Equal(t, "Deployment", checks[models.CheckMissingRequests][0].Kind)
+		assert.Equal(t, "api", checks[models.CheckMissingRequests][0].Name)
+	}
+
+	assert.Equal(t, models.SeverityCritical, report.Findings[0].Severity)
+	assert.Equal(t, models.SeverityInfo, report.Findings[len(report.Findings)-1].Severity)
+	assert.Equal(t, 3, report.Summary.Critical)
+	assert.Equal(t, 5, report.Summary.Warning)
+	assert.Equal(t, 1, report.Summary.Info)
+	assert.Equal(t, models.HealthStatusCritical, report.Summary.Status)
+	assert.Equal(t, 100-10-10-10-4-4-4-4-4-1, report.Summary.Score)
+}
+
+func TestGetHealthReport_Language(t *testing.T) {
+	service := NewK8sService(fake.NewSimpleClientset(healthFixtures(time.Now())...), metricsfake.NewSimpleClientset())
+
+	report, err := service.GetHealthReport(i18n.WithLanguage(context.Background(), i18n.English))
+	assert.NoError(t, err)
+	checks := findingsByCheck(report.Findings)
+	if assert.Len(t, checks[models.CheckDeploymentUnavailable], 1) {
+		assert.Equal(t, "1 of 3 replicas unavailable", checks[models.CheckDeploymentUnavailable][0].Message)
+	}
+}
+
+func TestGetHealthReport_HealthyCluster(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}},
+		},
+	)
+	service := NewK8sService(fakeClient, metricsfake.NewSimpleClientset())
+
+	report, err := service.GetHealthReport(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, report.Findings)
+	assert.Empty(t, report.Findings)
+	assert.Equal(t, models.HealthSummary{Score: 100, Status: models.HealthStatusHealthy}, report.Summary)
+
+	overview, err := service.GetOverviewData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &report.Summary, overview.Health)
+}
+
+// TestGetHealthReport_Cached verifica que a visão geral e o relatório pedidos em seguida compartilham as listagens,
+// e que cada idioma tem o próprio relatório.
+func TestGetHealthReport_Cached(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(healthFixtures(time.Now())...)
+	service := NewK8sService(fakeClient, metricsfake.NewSimpleClientset())
+	slices := func() int {
+		count := 0
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "endpointslices" {
+				count++
+			}
+		}
+		return count
+	}
+
+	overview, err := service.GetOverviewData(context.Background())
+	assert.NoError(t, err)
+	report, err := service.GetHealthReport(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &report.Summary, overview.Health)
+	assert.Equal(t, 1, slices())
+
+	english, err := service.GetHealthReport(i18n.WithLanguage(context.Background(), i18n.English))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, slices())
+	assert.NotEqual(t, report.Findings, english.Findings)
+}
+
+// TestHealthReportCache verifica que pedidos simultâneos compartilham um único cálculo, que o cancelamento de
+// um pedido não interrompe o cálculo dos demais e que cada chamador recebe a própria cópia do relatório.
+func TestHealthReportCache(t *testing.T) {
+	cache := newHealthReportCache()
+	release := make(chan struct{})
+	var builds atomic.Int32
+	build := func(ctx context.Context) (*models.HealthReport, error) {
+		builds.Add(1)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return &models.HealthReport{Findings: []models.HealthFinding{{Name: "web-0"}}}, nil
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	canceledErr := make(chan error)
+	go func() {
+		_, err := cache.get(canceled, i18n.DefaultLanguage, build)
+		canceledErr <- err
+	}()
+	assert.Eventually(t, func() bool { return builds.Load() == 1 }, time.Second, time.Millisecond)
+
+	waiting := make(chan *models.HealthReport)
+	go func() {
+		report, err := cache.get(context.Background(), i18n.DefaultLanguage, build)
+		assert.NoError(t, err)
+		waiting <- report
+	}()
+	cancel()
+	assert.ErrorIs(t, <-canceledErr, context.Canceled)
+	close(release)
+
+	report := <-waiting
+	assert.Equal(t, int32(1), builds.Load())
+	report.Findings[0].Name = "alterado"
+
+	cached, err := cache.get(context.Background(), i18n.DefaultLanguage, build)
+	assert.NoError(t, err)
+	assert.Equal(t, "web-0", cached.Findings[0].Name)
+	assert.Equal(t, int32(1), builds.Load())
+}
+
+func TestSummarizeHealth(t *testing.T) {
+	var findings []models.HealthFinding
+	for i := 0; i < 10; i++ {
+		findings = append(findings, models.HealthFinding{Check: models.CheckPodCrashLoop, Severity: models.SeverityCritical})
+	}
+	findings = append(findings, models.HealthFinding{Check: models.CheckMissingRequests, Severity: models.SeverityInfo})
+
+	// A penalidade de uma mesma verificação é limitada, para que um único problema repetido não zere a pontuação.
+	summary := summarizeHealth(findings)
+	assert.Equal(t, 100-maxPenaltyPerCheck-1, summary.Score)
+	assert.Equal(t, 10, summary.Critical)
+	assert.Equal(t, models.HealthStatusCritical, summary.Status)
+
+	summary = summarizeHealth([]models.HealthFinding{{Check: models.CheckMissingRequests, Severity: models.SeverityInfo}})
+	assert.Equal(t, models.HealthStatusHealthy, summary.Status)
+	assert.Equal(t, 99, summary.Score)
+
+	summary = summarizeHealth([]models.HealthFinding{{Check: models.CheckPvcPending, Severity: models.SeverityWarning}})
+	assert.Equal(t, models.HealthStatusDegraded, summary.Status)
+}
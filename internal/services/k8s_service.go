@@ -2,37 +2,98 @@ package services
 
 import (
 	"context"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/listquery"
+	"kubeowl/internal/logging"
 	"kubeowl/internal/models"
+	"kubeowl/internal/policy"
+	"log/slog"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	versioned "k8s.io/metrics/pkg/client/clientset/versioned"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // Service define a interface para interagir com o cluster.
 type Service interface {
 	GetOverviewData(ctx context.Context) (*models.OverviewResponse, error)
 	GetNodeInfo(ctx context.Context) ([]models.NodeInfo, error)
+	GetNodePoolInfo(ctx context.Context) ([]models.NodePoolInfo, error)
 	GetPodInfo(ctx context.Context) ([]models.PodInfo, error)
 	GetServiceInfo(ctx context.Context) ([]models.ServiceInfo, error)
 	GetIngressInfo(ctx context.Context) ([]models.IngressInfo, error)
+	GetTopology(ctx context.Context, namespace string) (*models.TopologyGraph, error)
+	GetHealthReport(ctx context.Context) (*models.HealthReport, error)
+	GetPolicyReport(ctx context.Context, namespace string) (*models.PolicyReport, error)
+	GetRightsizingReport(ctx context.Context, namespace string) (*models.RightsizingReport, error)
+	GetGatewayAPIInfo(ctx context.Context, namespace string) (*models.GatewayAPIInfo, error)
+	GetAPIResources(ctx context.Context) ([]models.APIResourceInfo, error)
+	GetResourceList(ctx context.Context, group, version, resource, namespace string) (*models.ResourceListInfo, error)
+	GetResourceDetail(ctx context.Context, group, version, resource, namespace, name string) (map[string]interface{}, error)
 	GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error)
-	GetEventInfo(ctx context.Context) ([]models.EventInfo, error)
+	GetPersistentVolumeInfo(ctx context.Context) ([]models.PersistentVolumeInfo, error)
+	GetStorageClassInfo(ctx context.Context) ([]models.StorageClassInfo, error)
+	GetDiskUsage(ctx context.Context) (*models.DiskUsageReport, error)
+	GetEventInfo(ctx context.Context, filter eventinfo.Filter) ([]models.EventInfo, error)
+	GetNamespaceInfo(ctx context.Context) ([]models.NamespaceInfo, error)
+	GetResourceQuotaInfo(ctx context.Context, namespace string) ([]models.ResourceQuotaInfo, error)
+	GetLimitRangeInfo(ctx context.Context, namespace string) ([]models.LimitRangeInfo, error)
+	GetConfigMapInfo(ctx context.Context, namespace string) ([]models.ConfigMapInfo, error)
+	GetConfigMapDetail(ctx context.Context, namespace, name string) (*models.ConfigMapInfo, error)
+	GetSecretInfo(ctx context.Context, namespace string) ([]models.SecretInfo, error)
+	GetSecretDetail(ctx context.Context, namespace, name string, reveal bool) (*models.SecretInfo, error)
 }
 
+// DefaultQuotaAlertThreshold é o percentual de uso a partir do qual um ResourceQuota é sinalizado na visão geral.
+const DefaultQuotaAlertThreshold = 80.0
+
 // k8sService é a implementação concreta da interface Service.
 type k8sService struct {
-	clientset        kubernetes.Interface
-	metricsClientset versioned.Interface
+	clientset           kubernetes.Interface
+	metricsClientset    versioned.Interface
+	dynamicClient       dynamic.Interface
+	quotaAlertThreshold float64
+	nodeLabels          nodeLabelConfig
+	// nodeStatsFunc busca o /stats/summary bruto de um nó; substituível nos testes e snapshots.
+	nodeStatsFunc     NodeStatsFunc
+	nodeStatsCache    *nodeStatsCache
+	healthReportCache *healthReportCache
+	policyLinter      *policy.Linter
+	usageHistory      *UsageHistory
+}
+
+// Option personaliza o comportamento do k8sService.
+type Option func(*k8sService)
+
+// WithQuotaAlertThreshold define o percentual de uso a partir do qual os quotas são sinalizados.
+func WithQuotaAlertThreshold(threshold float64) Option {
+	return func(s *k8sService) {
+		s.quotaAlertThreshold = threshold
+	}
 }
 
 // NewK8sService cria uma nova instância do k8sService.
-func NewK8sService(clientset kubernetes.Interface, metricsClientset versioned.Interface) Service {
-	return &k8sService{
-		clientset:        clientset,
-		metricsClientset: metricsClientset,
+func NewK8sService(clientset kubernetes.Interface, metricsClientset versioned.Interface, opts ...Option) Service {
+	s := &k8sService{
+		clientset:           clientset,
+		metricsClientset:    metricsClientset,
+		quotaAlertThreshold: DefaultQuotaAlertThreshold,
+		nodeLabels:          nodeLabelConfig{poolLabels: append([]string{}, defaultNodePoolLabels...)},
+		nodeStatsCache:      newNodeStatsCache(),
+		healthReportCache:   newHealthReportCache(),
+		policyLinter:        policy.New(),
+	}
+	s.nodeStatsFunc = s.fetchNodeStatsSummary
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // GetOverviewData coleta e processa os dados para a visão geral.
@@ -50,8 +111,9 @@ func (s *k8sService) GetOverviewData(ctx context.Context) (*models.OverviewRespo
 		return nil, err
 	}
 	nodeMetrics, _ := s.metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	quotas, _ := s.clientset.CoreV1().ResourceQuotas("").List(ctx, metav1.ListOptions{})
 
-	userNamespaceCount, _ := processNamespaces(namespaces)
+	userNamespaceCount, userNamespaces := processNamespaces(namespaces)
 	_, inClusterErr := rest.InClusterConfig()
 
 	response := &models.OverviewResponse{
@@ -60,25 +122,49 @@ func (s *k8sService) GetOverviewData(ctx context.Context) (*models.OverviewRespo
 		NamespaceCount:     userNamespaceCount,
 		NodeCount:          len(nodes.Items),
 		Capacity:           processClusterCapacity(nodes, nodeMetrics),
+		QuotaAlerts:        processQuotaAlerts(processResourceQuotas(quotas, userNamespaces), s.quotaAlertThreshold),
+	}
+	// A saúde é complementar: se o relatório falhar, a visão geral é retornada sem ela.
+	if report, err := s.GetHealthReport(ctx); err == nil {
+		response.Health = &report.Summary
+	} else {
+		slog.WarnContext(ctx, "falha ao calcular a saúde do cluster", logging.Err(err))
 	}
 	return response, nil
 }
 
-// GetNodeInfo coleta e processa informações dos nós.
+// GetNodeInfo coleta e processa informações dos nós. A ocupação de disco vem dos resumos do kubelet já guardados,
+// para que a listagem, consultada periodicamente pela interface, não espere pelos kubelets.
 func (s *k8sService) GetNodeInfo(ctx context.Context) ([]models.NodeInfo, error) {
-	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
 	pods, _ := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
 	nodeMetrics, _ := s.metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
 
-	return processNodeInfo(nodes, pods, nodeMetrics), nil
+	nodeInfoList := processNodeInfo(nodes, pods, nodeMetrics, s.nodeLabels)
+	nodeNames := make([]string, 0, len(nodeInfoList))
+	for _, node := range nodeInfoList {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	applyNodeFilesystemUsage(nodeInfoList, s.cachedNodeStats(ctx, nodeNames))
+	return nodeInfoList, nil
+}
+
+// GetNodePoolInfo agrupa os nós por pool.
+func (s *k8sService) GetNodePoolInfo(ctx context.Context) ([]models.NodePoolInfo, error) {
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	nodeMetrics, _ := s.metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	return processNodePools(nodes, nodeMetrics, s.nodeLabels), nil
 }
 
 // GetPodInfo coleta e processa informações dos pods.
 func (s *k8sService) GetPodInfo(ctx context.Context) ([]models.PodInfo, error) {
-	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +179,7 @@ func (s *k8sService) GetPodInfo(ctx context.Context) ([]models.PodInfo, error) {
 
 // GetServiceInfo coleta e processa informações dos services.
 func (s *k8sService) GetServiceInfo(ctx context.Context) ([]models.ServiceInfo, error) {
-	services, err := s.clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	services, err := s.clientset.CoreV1().Services("").List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -101,13 +187,14 @@ func (s *k8sService) GetServiceInfo(ctx context.Context) ([]models.ServiceInfo,
 	if err != nil {
 		return nil, err
 	}
+	endpointSlices, _ := s.clientset.DiscoveryV1().EndpointSlices("").List(ctx, metav1.ListOptions{})
 	_, userNamespaces := processNamespaces(namespaces)
-	return processServiceInfo(services, userNamespaces), nil
+	return processServiceInfo(services, endpointSlices, userNamespaces), nil
 }
 
 // GetIngressInfo coleta e processa informações dos ingresses.
 func (s *k8sService) GetIngressInfo(ctx context.Context) ([]models.IngressInfo, error) {
-	ingresses, err := s.clientset.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	ingresses, err := s.clientset.NetworkingV1().Ingresses("").List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -115,13 +202,48 @@ func (s *k8sService) GetIngressInfo(ctx context.Context) ([]models.IngressInfo,
 	if err != nil {
 		return nil, err
 	}
+	ingressClasses, _ := s.clientset.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	_, userNamespaces := processNamespaces(namespaces)
+	return processIngressInfo(ingresses, ingressClasses, userNamespaces), nil
+}
+
+// GetTopology monta o grafo Ingress → Service → EndpointSlice → Pod → Nó. Um namespace vazio retorna todos os namespaces.
+func (s *k8sService) GetTopology(ctx context.Context, namespace string) (*models.TopologyGraph, error) {
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ingresses, err := s.clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	endpointSlices, err := s.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, _ := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
 	_, userNamespaces := processNamespaces(namespaces)
-	return processIngressInfo(ingresses, userNamespaces), nil
+	graph := processTopology(topologyResources{
+		ingresses:      ingresses,
+		services:       services,
+		endpointSlices: endpointSlices,
+		pods:           pods,
+		replicaSets:    replicaSets,
+	}, userNamespaces)
+	return &graph, nil
 }
 
 // GetPvcInfo coleta e processa informações dos PVCs.
 func (s *k8sService) GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error) {
-	pvcs, err := s.clientset.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
+	pvcs, err := s.clientset.CoreV1().PersistentVolumeClaims("").List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -129,13 +251,87 @@ func (s *k8sService) GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	pvs, _ := s.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	pods, _ := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
 	_, userNamespaces := processNamespaces(namespaces)
-	return processPvcs(pvcs, userNamespaces), nil
+	pvcInfoList := processPvcs(pvcs, pvs, pods, userNamespaces)
+	applyPvcUsage(pvcInfoList, s.cachedNodeStats(ctx, nodesWithPvcConsumers(pods)))
+	return pvcInfoList, nil
+}
+
+// GetPersistentVolumeInfo coleta e processa informações dos PersistentVolumes.
+func (s *k8sService) GetPersistentVolumeInfo(ctx context.Context) ([]models.PersistentVolumeInfo, error) {
+	pvs, err := s.clientset.CoreV1().PersistentVolumes().List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return processPersistentVolumes(pvs), nil
 }
 
-// GetEventInfo coleta e processa informações dos eventos.
-func (s *k8sService) GetEventInfo(ctx context.Context) ([]models.EventInfo, error) {
+// GetStorageClassInfo coleta e processa informações dos StorageClasses.
+func (s *k8sService) GetStorageClassInfo(ctx context.Context) ([]models.StorageClassInfo, error) {
+	storageClasses, err := s.clientset.StorageV1().StorageClasses().List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pvs, _ := s.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	return processStorageClasses(storageClasses, pvs), nil
+}
+
+// GetDiskUsage coleta a ocupação de disco de nós, volumes e contêineres a partir do /stats/summary de cada nó.
+func (s *k8sService) GetDiskUsage(ctx context.Context) (*models.DiskUsageReport, error) {
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+	report := processDiskUsage(nodes, s.collectNodeStats(ctx, nodeNames), userNamespaces)
+	return &report, nil
+}
+
+// GetNamespaceInfo coleta e processa o resumo de saúde de cada namespace.
+func (s *k8sService) GetNamespaceInfo(ctx context.Context) ([]models.NamespaceInfo, error) {
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// Quotas, LimitRanges e eventos complementam o resumo: sem eles, os namespaces são retornados sem essas seções.
+	quotas, err := s.clientset.CoreV1().ResourceQuotas("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.WarnContext(ctx, "falha ao listar os ResourceQuotas do resumo de namespaces", logging.Err(err))
+		quotas = nil
+	}
+	limitRanges, err := s.clientset.CoreV1().LimitRanges("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.WarnContext(ctx, "falha ao listar os LimitRanges do resumo de namespaces", logging.Err(err))
+		limitRanges = nil
+	}
 	events, err := s.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.WarnContext(ctx, "falha ao listar os eventos do resumo de namespaces", logging.Err(err))
+		events = nil
+	}
+	podMetrics, _ := s.metricsClientset.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+
+	_, userNamespaces := processNamespaces(namespaces)
+	return processNamespaceInfo(namespaces, pods, podMetrics, quotas, limitRanges, events, userNamespaces), nil
+}
+
+// GetResourceQuotaInfo coleta o consumo dos ResourceQuotas. Um namespace vazio retorna todos os namespaces.
+func (s *k8sService) GetResourceQuotaInfo(ctx context.Context, namespace string) ([]models.ResourceQuotaInfo, error) {
+	quotas, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, listquery.ListOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -144,5 +340,94 @@ func (s *k8sService) GetEventInfo(ctx context.Context) ([]models.EventInfo, erro
 		return nil, err
 	}
 	_, userNamespaces := processNamespaces(namespaces)
-	return processEvents(events, userNamespaces), nil
+	return processResourceQuotas(quotas, userNamespaces), nil
+}
+
+// GetLimitRangeInfo coleta os LimitRanges. Um namespace vazio retorna todos os namespaces.
+func (s *k8sService) GetLimitRangeInfo(ctx context.Context, namespace string) ([]models.LimitRangeInfo, error) {
+	limitRanges, err := s.clientset.CoreV1().LimitRanges(namespace).List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+	return processLimitRanges(limitRanges, userNamespaces), nil
+}
+
+// GetConfigMapInfo lista os ConfigMaps com suas chaves e referências, sem os valores.
+func (s *k8sService) GetConfigMapInfo(ctx context.Context, namespace string) ([]models.ConfigMapInfo, error) {
+	configMaps, err := s.clientset.CoreV1().ConfigMaps(namespace).List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+	return processConfigMaps(configMaps, pods, userNamespaces, false), nil
+}
+
+// GetConfigMapDetail retorna um ConfigMap com os valores de todas as chaves. ConfigMaps de namespaces do sistema
+// ficam ocultos, como na listagem, e resultam em NotFound.
+func (s *k8sService) GetConfigMapDetail(ctx context.Context, namespace, name string) (*models.ConfigMapInfo, error) {
+	if IsSystemNamespace(namespace) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	configMap, err := s.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.ConfigMapList{Items: []v1.ConfigMap{*configMap}}
+	info := processConfigMaps(list, pods, map[string]bool{namespace: true}, true)
+	return &info[0], nil
+}
+
+// GetSecretInfo lista os Secrets com suas chaves e referências, sempre com os valores ocultos.
+func (s *k8sService) GetSecretInfo(ctx context.Context, namespace string) ([]models.SecretInfo, error) {
+	secrets, err := s.clientset.CoreV1().Secrets(namespace).List(ctx, listquery.ListOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+	return processSecrets(secrets, pods, userNamespaces, false), nil
+}
+
+// GetSecretDetail retorna um Secret. Os valores só são revelados quando reveal é verdadeiro;
+// a autorização e a auditoria da revelação ficam a cargo de quem chama. Secrets de namespaces do sistema
+// ficam ocultos, como na listagem, e resultam em NotFound.
+func (s *k8sService) GetSecretDetail(ctx context.Context, namespace, name string, reveal bool) (*models.SecretInfo, error) {
+	if IsSystemNamespace(namespace) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	secret, err := s.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	list := &v1.SecretList{Items: []v1.Secret{*secret}}
+	info := processSecrets(list, pods, map[string]bool{namespace: true}, reveal)
+	return &info[0], nil
 }
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"errors"
+	"kubeowl/internal/listquery"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -32,6 +34,29 @@ func TestGetOverviewData_Success(t *testing.T) {
 	assert.Equal(t, 1, overview.NamespaceCount) // app-ns
 }
 
+// TestGetOverviewData_QuotaAlerts verifica que apenas quotas acima do limite configurado são sinalizados.
+func TestGetOverviewData_QuotaAlerts(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+		&v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "app-ns"},
+			Status: v1.ResourceQuotaStatus{
+				Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("10"), v1.ResourceServices: resource.MustParse("10")},
+				Used: v1.ResourceList{v1.ResourcePods: resource.MustParse("7"), v1.ResourceServices: resource.MustParse("2")},
+			},
+		},
+	)
+
+	overview, err := NewK8sService(fakeClient, metricsvake.NewSimpleClientset()).GetOverviewData(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, overview.QuotaAlerts)
+
+	overview, err = NewK8sService(fakeClient, metricsvake.NewSimpleClientset(), WithQuotaAlertThreshold(70)).GetOverviewData(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, overview.QuotaAlerts, 1)
+	assert.Equal(t, "pods", overview.QuotaAlerts[0].Resource)
+}
+
 // TestGetService_K8sError testa o tratamento de erro quando o cliente K8s falha.
 func TestGetService_K8sError(t *testing.T) {
 	fakeClient := fake.NewSimpleClientset()
@@ -66,3 +91,81 @@ func TestGetPodInfo_Success(t *testing.T) {
 	assert.Len(t, pods, 1)
 	assert.Equal(t, "pod-1", pods[0].Name)
 }
+
+// TestGetPodInfo_LabelSelector testa se o labelSelector da consulta de listagem chega à API do Kubernetes.
+func TestGetPodInfo_LabelSelector(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app-ns", Labels: map[string]string{"app": "web"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "app-ns", Labels: map[string]string{"app": "worker"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+	)
+	service := NewK8sService(fakeClient, metricsvake.NewSimpleClientset())
+
+	ctx := listquery.NewContext(context.Background(), listquery.Query{LabelSelector: "app=web"})
+	pods, err := service.GetPodInfo(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, pods, 1) {
+		assert.Equal(t, "web", pods[0].Name)
+	}
+}
+
+// TestGetNamespaceInfo_Success testa o caminho feliz da função GetNamespaceInfo.
+func TestGetNamespaceInfo_Success(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "app-ns"}},
+	)
+	service := NewK8sService(fakeClient, metricsvake.NewSimpleClientset())
+
+	namespaces, err := service.GetNamespaceInfo(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, namespaces, 1)
+	assert.Equal(t, "app-ns", namespaces[0].Name)
+	assert.Equal(t, 1, namespaces[0].PodCount)
+}
+
+// TestGetNamespaceInfo_AuxiliaryListErrors verifica que falhas ao listar quotas, LimitRanges e eventos não derrubam o resumo.
+func TestGetNamespaceInfo_AuxiliaryListErrors(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "app-ns"}},
+	)
+	for _, kind := range []string{"resourcequotas", "limitranges", "events"} {
+		fakeClient.PrependReactor("list", kind, func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+			return true, nil, errors.New("proibido")
+		})
+	}
+	service := NewK8sService(fakeClient, metricsvake.NewSimpleClientset())
+
+	namespaces, err := service.GetNamespaceInfo(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, namespaces, 1) {
+		assert.Equal(t, 1, namespaces[0].PodCount)
+		assert.Empty(t, namespaces[0].Quotas)
+		assert.Empty(t, namespaces[0].LimitRanges)
+		assert.Empty(t, namespaces[0].WarningEvents)
+	}
+}
+
+// TestGetLimitRangeInfo_FilterByNamespace testa o filtro por namespace de GetLimitRangeInfo.
+func TestGetLimitRangeInfo_FilterByNamespace(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-ns"}},
+		&v1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "app-ns"}},
+		&v1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "other-ns"}},
+	)
+	service := NewK8sService(fakeClient, metricsvake.NewSimpleClientset())
+
+	limitRanges, err := service.GetLimitRangeInfo(context.Background(), "app-ns")
+	assert.NoError(t, err)
+	assert.Len(t, limitRanges, 1)
+	assert.Equal(t, "app-ns", limitRanges[0].Namespace)
+
+	limitRanges, err = service.GetLimitRangeInfo(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Len(t, limitRanges, 2)
+}
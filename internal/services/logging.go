@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"log/slog"
+	"time"
+)
+
+// loggingService registra cada chamada ao serviço com o tipo de recurso, o namespace, a duração e o request_id
+// da requisição que a originou.
+type loggingService struct {
+	next Service
+}
+
+// NewLoggingService envolve o serviço com o log estruturado das chamadas: sucesso em nível debug e falhas em warn.
+func NewLoggingService(next Service) Service {
+	return &loggingService{next: next}
+}
+
+// observe executa a chamada e registra o resultado com os campos padronizados.
+func observe[T any](ctx context.Context, resource, namespace string, call func() (T, error), attrs ...any) (T, error) {
+	start := time.Now()
+	result, err := call()
+	attrs = append(attrs, logging.KeyResource, resource, logging.KeyNamespace, namespace, logging.Duration(time.Since(start)))
+	if err != nil {
+		slog.WarnContext(ctx, "falha na chamada ao cluster", append(attrs, logging.Err(err))...)
+	} else {
+		slog.DebugContext(ctx, "chamada ao cluster concluída", attrs...)
+	}
+	return result, err
+}
+
+func (s *loggingService) GetOverviewData(ctx context.Context) (*models.OverviewResponse, error) {
+	return observe(ctx, "overview", "", func() (*models.OverviewResponse, error) { return s.next.GetOverviewData(ctx) })
+}
+
+func (s *loggingService) GetNodeInfo(ctx context.Context) ([]models.NodeInfo, error) {
+	return observe(ctx, "nodes", "", func() ([]models.NodeInfo, error) { return s.next.GetNodeInfo(ctx) })
+}
+
+func (s *loggingService) GetNodePoolInfo(ctx context.Context) ([]models.NodePoolInfo, error) {
+	return observe(ctx, "node-pools", "", func() ([]models.NodePoolInfo, error) { return s.next.GetNodePoolInfo(ctx) })
+}
+
+func (s *loggingService) GetPodInfo(ctx context.Context) ([]models.PodInfo, error) {
+	return observe(ctx, "pods", "", func() ([]models.PodInfo, error) { return s.next.GetPodInfo(ctx) })
+}
+
+func (s *loggingService) GetServiceInfo(ctx context.Context) ([]models.ServiceInfo, error) {
+	return observe(ctx, "services", "", func() ([]models.ServiceInfo, error) { return s.next.GetServiceInfo(ctx) })
+}
+
+func (s *loggingService) GetIngressInfo(ctx context.Context) ([]models.IngressInfo, error) {
+	return observe(ctx, "ingresses", "", func() ([]models.IngressInfo, error) { return s.next.GetIngressInfo(ctx) })
+}
+
+func (s *loggingService) GetTopology(ctx context.Context, namespace string) (*models.TopologyGraph, error) {
+	return observe(ctx, "topology", namespace, func() (*models.TopologyGraph, error) { return s.next.GetTopology(ctx, namespace) })
+}
+
+func (s *loggingService) GetHealthReport(ctx context.Context) (*models.HealthReport, error) {
+	return observe(ctx, "health-report", "", func() (*models.HealthReport, error) { return s.next.GetHealthReport(ctx) })
+}
+
+func (s *loggingService) GetPolicyReport(ctx context.Context, namespace string) (*models.PolicyReport, error) {
+	return observe(ctx, "policy-report", namespace, func() (*models.PolicyReport, error) { return s.next.GetPolicyReport(ctx, namespace) })
+}
+
+func (s *loggingService) GetRightsizingReport(ctx context.Context, namespace string) (*models.RightsizingReport, error) {
+	return observe(ctx, "rightsizing", namespace, func() (*models.RightsizingReport, error) { return s.next.GetRightsizingReport(ctx, namespace) })
+}
+
+func (s *loggingService) GetGatewayAPIInfo(ctx context.Context, namespace string) (*models.GatewayAPIInfo, error) {
+	return observe(ctx, "gateways", namespace, func() (*models.GatewayAPIInfo, error) { return s.next.GetGatewayAPIInfo(ctx, namespace) })
+}
+
+func (s *loggingService) GetAPIResources(ctx context.Context) ([]models.APIResourceInfo, error) {
+	return observe(ctx, "api-resources", "", func() ([]models.APIResourceInfo, error) { return s.next.GetAPIResources(ctx) })
+}
+
+func (s *loggingService) GetResourceList(ctx context.Context, group, version, resource, namespace string) (*models.ResourceListInfo, error) {
+	return observe(ctx, resource, namespace, func() (*models.ResourceListInfo, error) {
+		return s.next.GetResourceList(ctx, group, version, resource, namespace)
+	}, "group", group, "version", version)
+}
+
+func (s *loggingService) GetResourceDetail(ctx context.Context, group, version, resource, namespace, name string) (map[string]interface{}, error) {
+	return observe(ctx, resource, namespace, func() (map[string]interface{}, error) {
+		return s.next.GetResourceDetail(ctx, group, version, resource, namespace, name)
+	}, "group", group, "version", version, "name", name)
+}
+
+func (s *loggingService) GetPvcInfo(ctx context.Context) ([]models.PvcInfo, error) {
+	return observe(ctx, "persistentvolumeclaims", "", func() ([]models.PvcInfo, error) { return s.next.GetPvcInfo(ctx) })
+}
+
+func (s *loggingService) GetPersistentVolumeInfo(ctx context.Context) ([]models.PersistentVolumeInfo, error) {
+	return observe(ctx, "persistentvolumes", "", func() ([]models.PersistentVolumeInfo, error) { return s.next.GetPersistentVolumeInfo(ctx) })
+}
+
+func (s *loggingService) GetStorageClassInfo(ctx context.Context) ([]models.StorageClassInfo, error) {
+	return observe(ctx, "storageclasses", "", func() ([]models.StorageClassInfo, error) { return s.next.GetStorageClassInfo(ctx) })
+}
+
+func (s *loggingService) GetDiskUsage(ctx context.Context) (*models.DiskUsageReport, error) {
+	return observe(ctx, "disk-usage", "", func() (*models.DiskUsageReport, error) { return s.next.GetDiskUsage(ctx) })
+}
+
+func (s *loggingService) GetEventInfo(ctx context.Context, filter eventinfo.Filter) ([]models.EventInfo, error) {
+	return observe(ctx, "events", filter.Namespace, func() ([]models.EventInfo, error) { return s.next.GetEventInfo(ctx, filter) })
+}
+
+func (s *loggingService) GetNamespaceInfo(ctx context.Context) ([]models.NamespaceInfo, error) {
+	return observe(ctx, "namespaces", "", func() ([]models.NamespaceInfo, error) { return s.next.GetNamespaceInfo(ctx) })
+}
+
+func (s *loggingService) GetResourceQuotaInfo(ctx context.Context, namespace string) ([]models.ResourceQuotaInfo, error) {
+	return observe(ctx, "resourcequotas", namespace, func() ([]models.ResourceQuotaInfo, error) { return s.next.GetResourceQuotaInfo(ctx, namespace) })
+}
+
+func (s *loggingService) GetLimitRangeInfo(ctx context.Context, namespace string) ([]models.LimitRangeInfo, error) {
+	return observe(ctx, "limitranges", namespace, func() ([]models.LimitRangeInfo, error) { return s.next.GetLimitRangeInfo(ctx, namespace) })
+}
+
+func (s *loggingService) GetConfigMapInfo(ctx context.Context, namespace string) ([]models.ConfigMapInfo, error) {
+	return observe(ctx, "configmaps", namespace, func() ([]models.ConfigMapInfo, error) { return s.next.GetConfigMapInfo(ctx, namespace) })
+}
+
+func (s *loggingService) GetConfigMapDetail(ctx context.Context, namespace, name string) (*models.ConfigMapInfo, error) {
+	return observe(ctx, "configmaps", namespace, func() (*models.ConfigMapInfo, error) {
+		return s.next.GetConfigMapDetail(ctx, namespace, name)
+	}, "name", name)
+}
+
+func (s *loggingService) GetSecretInfo(ctx context.Context, namespace string) ([]models.SecretInfo, error) {
+	return observe(ctx, "secrets", namespace, func() ([]models.SecretInfo, error) { return s.next.GetSecretInfo(ctx, namespace) })
+}
+
+func (s *loggingService) GetSecretDetail(ctx context.Context, namespace, name string, reveal bool) (*models.SecretInfo, error) {
+	return observe(ctx, "secrets", namespace, func() (*models.SecretInfo, error) {
+		return s.next.GetSecretDetail(ctx, namespace, name, reveal)
+	}, "name", name, "reveal", reveal)
+}
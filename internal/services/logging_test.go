@@ -0,0 +1,60 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"kubeowl/internal/logging"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsvake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestLoggingService(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, slog.LevelDebug, logging.FormatJSON)
+	if err != nil {
+		t.Fatalf("falha ao criar o logger: %v", err)
+	}
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer func() {
+		// SetDefault redireciona o pacote log; volta a silenciá-lo como no TestMain.
+		slog.SetDefault(previous)
+		log.SetOutput(io.Discard)
+	}()
+
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "shop"}},
+	)
+	service := NewLoggingService(NewK8sService(fakeClient, metricsvake.NewSimpleClientset()))
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+
+	configMaps, err := service.GetConfigMapInfo(ctx, "shop")
+	assert.NoError(t, err)
+	assert.Len(t, configMaps, 1)
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "configmaps", record[logging.KeyResource])
+	assert.Equal(t, "shop", record[logging.KeyNamespace])
+	assert.Equal(t, "req-1", record[logging.KeyRequestID])
+	assert.Contains(t, record, logging.KeyDuration)
+
+	buf.Reset()
+	_, err = service.GetConfigMapDetail(ctx, "shop", "missing")
+	assert.Error(t, err)
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "missing", record["name"])
+	assert.Contains(t, record[logging.KeyError], "not found")
+}
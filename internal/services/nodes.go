@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"kubeowl/internal/models"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// nodeRoleLabelPrefix é o prefixo dos rótulos padrão de papel dos nós (node-role.kubernetes.io/<papel>).
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// legacyNodeRoleLabel é o rótulo antigo cujo valor indica o papel do nó.
+	legacyNodeRoleLabel = "kubernetes.io/role"
+	// defaultNodeRole é o papel atribuído a nós sem nenhum rótulo de papel.
+	defaultNodeRole = "worker"
+	// unpooledNodePool agrupa os nós que não pertencem a nenhum pool conhecido.
+	unpooledNodePool = "none"
+)
+
+// defaultNodePoolLabels são os rótulos usados pelos provedores gerenciados para identificar o pool de um nó.
+var defaultNodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"karpenter.sh/nodepool",
+	"doks.digitalocean.com/node-pool",
+	"node.kubernetes.io/pool",
+}
+
+// NodeRoleMapping associa um rótulo de nó (e opcionalmente um valor) a um papel de exibição.
+type NodeRoleMapping struct {
+	Label string
+	Value string
+	Role  string
+}
+
+// ParseNodeRoleMappings interpreta uma lista separada por vírgulas no formato "rotulo[=valor]:papel",
+// por exemplo "cloud.google.com/gke-nodepool=gpu-pool:gpu,dedicated=infra:infra".
+func ParseNodeRoleMappings(spec string) ([]NodeRoleMapping, error) {
+	mappings := []NodeRoleMapping{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		sep := strings.LastIndex(entry, ":")
+		if sep <= 0 || sep == len(entry)-1 {
+			return nil, fmt.Errorf("mapeamento de papel inválido %q: use rotulo[=valor]:papel", entry)
+		}
+		selector, role := entry[:sep], entry[sep+1:]
+		label, value, _ := strings.Cut(selector, "=")
+		mappings = append(mappings, NodeRoleMapping{Label: label, Value: value, Role: role})
+	}
+	return mappings, nil
+}
+
+// WithNodeRoleMappings adiciona mapeamentos de rótulos arbitrários para papéis de exibição dos nós.
+func WithNodeRoleMappings(mappings []NodeRoleMapping) Option {
+	return func(s *k8sService) {
+		s.nodeLabels.roleMappings = append(s.nodeLabels.roleMappings, mappings...)
+	}
+}
+
+// WithNodePoolLabel define um rótulo adicional, com prioridade sobre os padrões, para identificar o pool dos nós.
+func WithNodePoolLabel(label string) Option {
+	return func(s *k8sService) {
+		if label != "" {
+			s.nodeLabels.poolLabels = append([]string{label}, s.nodeLabels.poolLabels...)
+		}
+	}
+}
+
+// nodeLabelConfig reúne a configuração usada para derivar papéis e pools a partir dos rótulos dos nós.
+type nodeLabelConfig struct {
+	roleMappings []NodeRoleMapping
+	poolLabels   []string
+}
+
+// nodeRoles lista todos os papéis de um nó: os rótulos node-role.kubernetes.io/*, o rótulo legado
+// kubernetes.io/role e os mapeamentos configurados. Nós sem papel são considerados "worker".
+func nodeRoles(node v1.Node, cfg nodeLabelConfig) []string {
+	roleSet := map[string]bool{}
+	for label, value := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			roleSet[role] = true
+		}
+		if label == legacyNodeRoleLabel && value != "" {
+			roleSet[value] = true
+		}
+	}
+	for _, mapping := range cfg.roleMappings {
+		value, ok := node.Labels[mapping.Label]
+		if ok && (mapping.Value == "" || mapping.Value == value) {
+			roleSet[mapping.Role] = true
+		}
+	}
+	// "master" é o nome antigo de "control-plane".
+	if roleSet["master"] {
+		delete(roleSet, "master")
+		roleSet["control-plane"] = true
+	}
+
+	roles := make([]string, 0, len(roleSet))
+	for role := range roleSet {
+		roles = append(roles, role)
+	}
+	if len(roles) == 0 {
+		return []string{defaultNodeRole}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// nodePool retorna o pool do nó a partir do primeiro rótulo de pool encontrado.
+func nodePool(node v1.Node, cfg nodeLabelConfig) string {
+	if pool := firstLabel(node.Labels, cfg.poolLabels...); pool != "" {
+		return pool
+	}
+	return unpooledNodePool
+}
+
+// isNodeReady indica se a condição Ready do nó está verdadeira.
+func isNodeReady(node v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// processNodePools agrupa os nós por pool, somando capacidade alocável e uso.
+func processNodePools(nodes *v1.NodeList, nodeMetrics *metricsv1beta1.NodeMetricsList, cfg nodeLabelConfig) []models.NodePoolInfo {
+	poolInfoList := []models.NodePoolInfo{}
+	if nodes == nil {
+		return poolInfoList
+	}
+
+	pools := map[string]*models.NodePoolInfo{}
+	poolRoles := map[string]map[string]bool{}
+	for _, node := range nodes.Items {
+		name := nodePool(node, cfg)
+		pool, ok := pools[name]
+		if !ok {
+			pool = &models.NodePoolInfo{Name: name, Nodes: []string{}}
+			pools[name] = pool
+			poolRoles[name] = map[string]bool{}
+		}
+
+		pool.NodeCount++
+		pool.Nodes = append(pool.Nodes, node.Name)
+		if isNodeReady(node) {
+			pool.ReadyNodes++
+		}
+		for _, role := range nodeRoles(node, cfg) {
+			poolRoles[name][role] = true
+		}
+
+		usedCPU, usedMemory := getNodeUsage(node.Name, nodeMetrics)
+		pool.TotalCPU += node.Status.Allocatable.Cpu().MilliValue()
+		pool.TotalMemory += node.Status.Allocatable.Memory().Value()
+		pool.UsedCPU += usedCPU.MilliValue()
+		pool.UsedMemory += usedMemory.Value()
+	}
+
+	for name, pool := range pools {
+		pool.Roles = []string{}
+		for role := range poolRoles[name] {
+			pool.Roles = append(pool.Roles, role)
+		}
+		sort.Strings(pool.Roles)
+		sort.Strings(pool.Nodes)
+		if pool.TotalCPU > 0 {
+			pool.CPUUsagePercentage = (float64(pool.UsedCPU) / float64(pool.TotalCPU)) * 100
+		}
+		if pool.TotalMemory > 0 {
+			pool.MemoryUsagePercentage = (float64(pool.UsedMemory) / float64(pool.TotalMemory)) * 100
+		}
+		poolInfoList = append(poolInfoList, *pool)
+	}
+	sort.Slice(poolInfoList, func(i, j int) bool { return poolInfoList[i].Name < poolInfoList[j].Name })
+	return poolInfoList
+}
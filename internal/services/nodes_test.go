@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestParseNodeRoleMappings(t *testing.T) {
+	mappings, err := ParseNodeRoleMappings("cloud.google.com/gke-nodepool=gpu-pool:gpu, dedicated:infra")
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeRoleMapping{
+		{Label: "cloud.google.com/gke-nodepool", Value: "gpu-pool", Role: "gpu"},
+		{Label: "dedicated", Role: "infra"},
+	}, mappings)
+
+	mappings, err = ParseNodeRoleMappings("")
+	assert.NoError(t, err)
+	assert.Empty(t, mappings)
+
+	_, err = ParseNodeRoleMappings("sem-papel")
+	assert.Error(t, err)
+	_, err = ParseNodeRoleMappings("rotulo:")
+	assert.Error(t, err)
+}
+
+func TestNodeRoles(t *testing.T) {
+	cfg := nodeLabelConfig{roleMappings: []NodeRoleMapping{
+		{Label: "cloud.google.com/gke-nodepool", Value: "gpu-pool", Role: "gpu"},
+		{Label: "dedicated", Role: "infra"},
+	}}
+
+	testCases := []struct {
+		name     string
+		labels   map[string]string
+		expected []string
+	}{
+		{name: "Sem rótulos", labels: nil, expected: []string{"worker"}},
+		{name: "Master legado", labels: map[string]string{"node-role.kubernetes.io/master": ""}, expected: []string{"control-plane"}},
+		{
+			name:     "Vários papéis",
+			labels:   map[string]string{"node-role.kubernetes.io/control-plane": "", "node-role.kubernetes.io/etcd": "true", "node-role.kubernetes.io/ingress": ""},
+			expected: []string{"control-plane", "etcd", "ingress"},
+		},
+		{name: "Rótulo kubernetes.io/role", labels: map[string]string{"kubernetes.io/role": "infra"}, expected: []string{"infra"}},
+		{name: "Mapeamento com valor", labels: map[string]string{"cloud.google.com/gke-nodepool": "gpu-pool"}, expected: []string{"gpu"}},
+		{name: "Mapeamento com outro valor", labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"}, expected: []string{"worker"}},
+		{name: "Mapeamento sem valor", labels: map[string]string{"dedicated": "anything"}, expected: []string{"infra"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}}
+			assert.Equal(t, tc.expected, nodeRoles(node, cfg))
+		})
+	}
+}
+
+func TestProcessNodePools(t *testing.T) {
+	cfg := nodeLabelConfig{poolLabels: append([]string{"custom/pool"}, defaultNodePoolLabels...)}
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+		v1.ResourceMemory: *resource.NewQuantity(4*1024*1024*1024, resource.BinarySI),
+	}
+	ready := []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	nodes := &v1.NodeList{Items: []v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gke-a", Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"}}, Status: v1.NodeStatus{Allocatable: allocatable, Conditions: ready}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gke-b", Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool", "node-role.kubernetes.io/ingress": ""}}, Status: v1.NodeStatus{Allocatable: allocatable}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "custom-1", Labels: map[string]string{"custom/pool": "batch", "cloud.google.com/gke-nodepool": "ignored"}}, Status: v1.NodeStatus{Allocatable: allocatable, Conditions: ready}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bare-1"}},
+	}}
+	nodeMetrics := &metricsv1beta1.NodeMetricsList{Items: []metricsv1beta1.NodeMetrics{{
+		ObjectMeta: metav1.ObjectMeta{Name: "gke-a"},
+		Usage:      v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(1000, resource.DecimalSI)},
+	}}}
+
+	pools := processNodePools(nodes, nodeMetrics, cfg)
+	assert.Len(t, pools, 3)
+	assert.Equal(t, "batch", pools[0].Name)
+	assert.Equal(t, "default-pool", pools[1].Name)
+	assert.Equal(t, 2, pools[1].NodeCount)
+	assert.Equal(t, 1, pools[1].ReadyNodes)
+	assert.Equal(t, []string{"ingress", "worker"}, pools[1].Roles)
+	assert.Equal(t, []string{"gke-a", "gke-b"}, pools[1].Nodes)
+	assert.InDelta(t, 25.0, pools[1].CPUUsagePercentage, 0.01)
+	assert.Equal(t, unpooledNodePool, pools[2].Name)
+}
+
+func TestProcessNodePools_NilInput(t *testing.T) {
+	assert.NotPanics(t, func() { processNodePools(nil, nil, nodeLabelConfig{}) })
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"kubeowl/internal/policy"
+	"log/slog"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// WithPolicyLinter define as regras de boas práticas usadas no relatório de políticas.
+func WithPolicyLinter(linter *policy.Linter) Option {
+	return func(s *k8sService) {
+		if linter != nil {
+			s.policyLinter = linter
+		}
+	}
+}
+
+// workloadLists reúne os objetos dos quais os workloads avaliados pelas regras são extraídos.
+type workloadLists struct {
+	deployments  *appsv1.DeploymentList
+	statefulSets *appsv1.StatefulSetList
+	daemonSets   *appsv1.DaemonSetList
+	replicaSets  *appsv1.ReplicaSetList
+	jobs         *batchv1.JobList
+	cronJobs     *batchv1.CronJobList
+	pods         *v1.PodList
+	budgets      *policyv1.PodDisruptionBudgetList
+}
+
+// GetPolicyReport avalia os workloads contra as regras de boas práticas. Um namespace vazio avalia todos os namespaces.
+func (s *k8sService) GetPolicyReport(ctx context.Context, namespace string) (*models.PolicyReport, error) {
+	var lists workloadLists
+	var err error
+	if lists.deployments, err = s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if lists.statefulSets, err = s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if lists.daemonSets, err = s.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if lists.replicaSets, err = s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if lists.jobs, err = s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if lists.pods, err = s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	_, userNamespaces := processNamespaces(namespaces)
+
+	// CronJobs e PodDisruptionBudgets são complementares: sem eles, o relatório sai sem os CronJobs e sem a
+	// regra que depende dos PDBs, em vez de falhar por inteiro.
+	linter := s.policyLinter
+	if lists.cronJobs, err = s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		slog.WarnContext(ctx, "falha ao listar CronJobs para o relatório de políticas", logging.Err(err))
+		lists.cronJobs = nil
+	}
+	if lists.budgets, err = s.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+		slog.WarnContext(ctx, "falha ao listar PodDisruptionBudgets para o relatório de políticas", logging.Err(err))
+		lists.budgets = nil
+		linter = linter.Without(policy.RuleSingleReplica)
+	}
+
+	report := linter.Lint(processWorkloads(lists, userNamespaces), i18n.FromContext(ctx))
+	return &report, nil
+}
+
+// processWorkloads extrai os workloads de nível mais alto: objetos criados por um controlador avaliado aqui
+// (ReplicaSets de Deployments, Jobs de CronJobs e pods de ReplicaSets, StatefulSets, DaemonSets e Jobs) são
+// avaliados pelo template do dono. Os criados por outros controladores, como CRDs de operadores, são avaliados
+// diretamente.
+func processWorkloads(lists workloadLists, userNamespaces map[string]bool) []policy.Workload {
+	var workloads []policy.Workload
+	evaluated := evaluatedControllers(lists)
+	ownedByEvaluated := func(obj metav1.Object) bool {
+		controller := metav1.GetControllerOf(obj)
+		return controller != nil && evaluated[controller.Kind]
+	}
+	add := func(kind string, meta metav1.ObjectMeta, replicas *int32, template v1.PodTemplateSpec) {
+		if !userNamespaces[meta.Namespace] {
+			return
+		}
+		workloads = append(workloads, policy.Workload{
+			Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Replicas: replicas, Template: template,
+			DisruptionBudgets: matchingBudgets(lists.budgets, meta.Namespace, template.Labels),
+		})
+	}
+
+	if lists.deployments != nil {
+		for _, d := range lists.deployments.Items {
+			replicas := int32(1)
+			if d.Spec.Replicas != nil {
+				replicas = *d.Spec.Replicas
+			}
+			add("Deployment", d.ObjectMeta, &replicas, d.Spec.Template)
+		}
+	}
+	if lists.statefulSets != nil {
+		for _, sts := range lists.statefulSets.Items {
+			replicas := int32(1)
+			if sts.Spec.Replicas != nil {
+				replicas = *sts.Spec.Replicas
+			}
+			add("StatefulSet", sts.ObjectMeta, &replicas, sts.Spec.Template)
+		}
+	}
+	if lists.daemonSets != nil {
+		for _, ds := range lists.daemonSets.Items {
+			add("DaemonSet", ds.ObjectMeta, nil, ds.Spec.Template)
+		}
+	}
+	if lists.replicaSets != nil {
+		for _, rs := range lists.replicaSets.Items {
+			if !ownedByEvaluated(&rs) {
+				add("ReplicaSet", rs.ObjectMeta, rs.Spec.Replicas, rs.Spec.Template)
+			}
+		}
+	}
+	if lists.cronJobs != nil {
+		for _, cj := range lists.cronJobs.Items {
+			add("CronJob", cj.ObjectMeta, nil, cj.Spec.JobTemplate.Spec.Template)
+		}
+	}
+	if lists.jobs != nil {
+		for _, job := range lists.jobs.Items {
+			if !ownedByEvaluated(&job) {
+				add("Job", job.ObjectMeta, nil, job.Spec.Template)
+			}
+		}
+	}
+	if lists.pods != nil {
+		for _, pod := range lists.pods.Items {
+			if !ownedByEvaluated(&pod) {
+				add("Pod", pod.ObjectMeta, nil, v1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec})
+			}
+		}
+	}
+	return workloads
+}
+
+// evaluatedControllers retorna os tipos de controlador cujos objetos são avaliados pelo relatório. Um dono fora
+// deste conjunto (ou cuja lista não pôde ser lida) não cobre os objetos que criou.
+func evaluatedControllers(lists workloadLists) map[string]bool {
+	evaluated := map[string]bool{}
+	for kind, listed := range map[string]bool{
+		"Deployment":  lists.deployments != nil,
+		"StatefulSet": lists.statefulSets != nil,
+		"DaemonSet":   lists.daemonSets != nil,
+		"ReplicaSet":  lists.replicaSets != nil,
+		"Job":         lists.jobs != nil,
+		"CronJob":     lists.cronJobs != nil,
+	} {
+		if listed {
+			evaluated[kind] = true
+		}
+	}
+	return evaluated
+}
+
+// matchingBudgets lista os PodDisruptionBudgets do namespace cujo seletor cobre os rótulos dos pods.
+func matchingBudgets(budgets *policyv1.PodDisruptionBudgetList, namespace string, podLabels map[string]string) []string {
+	var names []string
+	if budgets == nil {
+		return names
+	}
+	for _, pdb := range budgets.Items {
+		if pdb.Namespace != namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		names = append(names, pdb.Name)
+	}
+	return names
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kubeowl/internal/models"
+	"kubeowl/internal/policy"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func policyFixtures() []runtime.Object {
+	controller := true
+	one := int32(1)
+	template := func(app string) v1.PodTemplateSpec {
+		return v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: app, Image: app + ":latest"}}},
+		}
+	}
+	return []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "app"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &one, Template: template("api")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &one, Template: template("web")},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+		// ReplicaSets, Jobs e pods com controlador são avaliados pelo template do dono.
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "api-5f6", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: template("api")},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "api-5f6-x", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5f6", Controller: &controller}},
+			},
+			Spec: template("api").Spec,
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "app"},
+			Spec:       batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template("report")}}},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "report-28913", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "report", Controller: &controller}},
+			},
+			Spec: batchv1.JobSpec{Template: template("report")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app"},
+			Spec:       appsv1.StatefulSetSpec{Template: template("db")},
+		},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "app"}, Spec: template("debug").Spec},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"},
+			Spec:       appsv1.DaemonSetSpec{Template: template("kube-proxy")},
+		},
+	}
+}
+
+func TestGetPolicyReport(t *testing.T) {
+	service := NewK8sService(fake.NewSimpleClientset(policyFixtures()...), metricsfake.NewSimpleClientset())
+
+	report, err := service.GetPolicyReport(context.Background(), "")
+	assert.NoError(t, err)
+	// api, web, report, db e o pod avulso debug; o DaemonSet do kube-system fica de fora.
+	assert.Equal(t, 5, report.Workloads)
+
+	workloads := map[string]bool{}
+	singleReplica := []string{}
+	for _, violation := range report.Violations {
+		workloads[violation.Kind+"/"+violation.Name] = true
+		if violation.Rule == policy.RuleSingleReplica {
+			singleReplica = append(singleReplica, violation.Name)
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"Deployment/api": true, "Deployment/web": true, "CronJob/report": true, "StatefulSet/db": true, "Pod/debug": true,
+	}, workloads)
+	// O Deployment web é coberto pelo PodDisruptionBudget.
+	assert.Equal(t, []string{"api"}, singleReplica)
+}
+
+// TestGetPolicyReport_AuxiliaryListErrors verifica que falhas ao listar CronJobs e PodDisruptionBudgets não derrubam o relatório.
+func TestGetPolicyReport_AuxiliaryListErrors(t *testing.T) {
+	clientset := fake.NewSimpleClientset(policyFixtures()...)
+	for _, kind := range []string{"cronjobs", "poddisruptionbudgets"} {
+		clientset.PrependReactor("list", kind, func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+	}
+	service := NewK8sService(clientset, metricsfake.NewSimpleClientset())
+
+	report, err := service.GetPolicyReport(context.Background(), "")
+	assert.NoError(t, err)
+	// Sem a lista de CronJobs, o CronJob report fica de fora e o Job que ele criou passa a ser avaliado diretamente.
+	assert.Equal(t, 5, report.Workloads)
+	jobs := []string{}
+	for _, violation := range report.Violations {
+		assert.NotEqual(t, "CronJob", violation.Kind)
+		if violation.Kind == "Job" {
+			jobs = append(jobs, violation.Name)
+		}
+		assert.NotEqual(t, policy.RuleSingleReplica, violation.Rule, "sem os PDBs, a regra não é avaliada")
+	}
+	assert.Contains(t, jobs, "report-28913")
+	for _, rule := range report.Rules {
+		assert.Equal(t, rule.ID != policy.RuleSingleReplica, rule.Enabled, rule.ID)
+	}
+}
+
+// TestGetPolicyReport_CustomControllers verifica que objetos criados por controladores de CRDs são avaliados diretamente.
+func TestGetPolicyReport_CustomControllers(t *testing.T) {
+	controller := true
+	spec := v1.PodSpec{Containers: []v1.Container{{Name: "kafka", Image: "kafka:latest"}}}
+	clientset := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		// Um ReplicaSet de um Rollout do Argo é avaliado no lugar do Rollout, e o pod dele pelo ReplicaSet.
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "checkout-7c9", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "checkout", Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: v1.PodTemplateSpec{Spec: spec}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "checkout-7c9-x", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "checkout-7c9", Controller: &controller}},
+			},
+			Spec: spec,
+		},
+		// Um pod de um StrimziPodSet não tem outro objeto avaliado acima dele.
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kafka-0", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "core.strimzi.io/v1beta2", Kind: "StrimziPodSet", Name: "kafka", Controller: &controller}},
+			},
+			Spec: spec,
+		},
+	)
+	service := NewK8sService(clientset, metricsfake.NewSimpleClientset())
+
+	report, err := service.GetPolicyReport(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Workloads)
+	workloads := map[string]bool{}
+	for _, violation := range report.Violations {
+		workloads[violation.Kind+"/"+violation.Name] = true
+	}
+	assert.Equal(t, map[string]bool{"ReplicaSet/checkout-7c9": true, "Pod/kafka-0": true}, workloads)
+}
+
+func TestGetPolicyReport_LinterOptions(t *testing.T) {
+	linter := policy.New(
+		policy.WithDisabledRules(policy.RuleResources, policy.RuleProbes, policy.RuleRunAsRoot, policy.RuleSingleReplica),
+		policy.WithExemptions(policy.Exemption{Namespace: "app", Rule: policy.RuleLatestImage}),
+	)
+	service := NewK8sService(fake.NewSimpleClientset(policyFixtures()...), metricsfake.NewSimpleClientset(), WithPolicyLinter(linter))
+
+	report, err := service.GetPolicyReport(context.Background(), "app")
+	assert.NoError(t, err)
+	assert.Empty(t, report.Violations)
+	assert.NotNil(t, report.Violations)
+	for _, rule := range report.Rules {
+		if rule.ID == policy.RuleLatestImage {
+			assert.Equal(t, []string{"app"}, rule.ExemptNamespaces)
+		}
+	}
+	assert.Equal(t, models.SeverityCritical, report.Rules[0].Severity)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"kubeowl/internal/eventinfo"
 	"kubeowl/internal/models"
 	"sort"
 	"strconv"
@@ -9,43 +10,68 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
-// processServiceInfo formata os dados brutos dos serviços em uma estrutura mais amigável.
-func processServiceInfo(services *v1.ServiceList, userNamespaces map[string]bool) []models.ServiceInfo {
+// processServiceInfo formata os dados brutos dos serviços em uma estrutura mais amigável,
+// incluindo as portas detalhadas e a contagem de endpoints prontos a partir dos EndpointSlices.
+func processServiceInfo(services *v1.ServiceList, endpointSlices *discoveryv1.EndpointSliceList, userNamespaces map[string]bool) []models.ServiceInfo {
 	serviceInfoList := []models.ServiceInfo{}
 	if services == nil {
 		return serviceInfoList
 	}
 
+	endpoints := countServiceEndpoints(endpointSlices)
 	for _, service := range services.Items {
 		// Ignora os serviços de namespaces do sistema.
 		if !userNamespaces[service.Namespace] {
 			continue
 		}
 
-		// Obtém o IP externo, se disponível.
-		externalIP := ""
-		if len(service.Status.LoadBalancer.Ingress) > 0 {
-			externalIP = service.Status.LoadBalancer.Ingress[0].IP
+		// Reúne os IPs externos declarados e os endereços (IP ou hostname) atribuídos pelo load balancer.
+		externalIPs := append([]string{}, service.Spec.ExternalIPs...)
+		for _, lb := range service.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				externalIPs = append(externalIPs, lb.IP)
+			}
+			if lb.Hostname != "" {
+				externalIPs = append(externalIPs, lb.Hostname)
+			}
 		}
 
-		// Formata as portas para exibição.
-		var portStrings []string
+		ports := []models.ServicePortInfo{}
 		for _, port := range service.Spec.Ports {
-			portStrings = append(portStrings, strconv.Itoa(int(port.Port)))
+			ports = append(ports, models.ServicePortInfo{
+				Name:       port.Name,
+				Protocol:   string(port.Protocol),
+				Port:       port.Port,
+				TargetPort: port.TargetPort.String(),
+				NodePort:   port.NodePort,
+			})
+		}
+
+		selector := service.Spec.Selector
+		if selector == nil {
+			selector = map[string]string{}
 		}
 
+		counts := endpoints[service.Namespace+"/"+service.Name]
 		info := models.ServiceInfo{
-			Name:       service.Name,
-			Namespace:  service.Namespace,
-			Type:       string(service.Spec.Type),
-			ClusterIP:  service.Spec.ClusterIP,
-			ExternalIP: externalIP,
-			Ports:      strings.Join(portStrings, ", "),
+			Name:              service.Name,
+			Namespace:         service.Namespace,
+			Type:              string(service.Spec.Type),
+			ClusterIP:         service.Spec.ClusterIP,
+			ExternalIPs:       externalIPs,
+			ExternalName:      service.Spec.ExternalName,
+			Ports:             ports,
+			Selector:          selector,
+			SessionAffinity:   string(service.Spec.SessionAffinity),
+			ReadyEndpoints:    counts.ready,
+			NotReadyEndpoints: counts.notReady,
 		}
 		serviceInfoList = append(serviceInfoList, info)
 	}
@@ -60,6 +86,55 @@ func processServiceInfo(services *v1.ServiceList, userNamespaces map[string]bool
 	return serviceInfoList
 }
 
+// endpointCounts guarda quantos endpoints de um Service estão prontos ou não.
+type endpointCounts struct {
+	ready    int
+	notReady int
+}
+
+// isEndpointReady indica se o endpoint está pronto; pela API, uma condição Ready ausente significa pronto.
+func isEndpointReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+// countServiceEndpoints conta os endpoints de cada Service ("namespace/nome") a partir dos EndpointSlices.
+// Em clusters dual-stack o mesmo pod aparece em um slice IPv4 e outro IPv6, por isso os endpoints são
+// identificados pelo objeto de destino quando houver.
+func countServiceEndpoints(endpointSlices *discoveryv1.EndpointSliceList) map[string]endpointCounts {
+	counts := map[string]endpointCounts{}
+	if endpointSlices == nil {
+		return counts
+	}
+
+	seen := map[string]bool{}
+	for _, slice := range endpointSlices.Items {
+		serviceName := slice.Labels[discoveryv1.LabelServiceName]
+		if serviceName == "" {
+			continue
+		}
+		key := slice.Namespace + "/" + serviceName
+		for _, endpoint := range slice.Endpoints {
+			id := strings.Join(endpoint.Addresses, ",")
+			if endpoint.TargetRef != nil {
+				id = endpoint.TargetRef.Kind + "/" + endpoint.TargetRef.Name
+			}
+			if seen[key+"|"+id] {
+				continue
+			}
+			seen[key+"|"+id] = true
+
+			c := counts[key]
+			if isEndpointReady(endpoint) {
+				c.ready++
+			} else {
+				c.notReady++
+			}
+			counts[key] = c
+		}
+	}
+	return counts
+}
+
 // getPodStatus determina a condição detalhada de um pod.
 func getPodStatus(pod v1.Pod) (string, int32) {
 	var totalRestarts int32
@@ -85,39 +160,128 @@ func getPodStatus(pod v1.Pod) (string, int32) {
 	return status, totalRestarts
 }
 
-// processIngressInfo formata os dados dos Ingresses.
-func processIngressInfo(ingresses *networkingv1.IngressList, userNamespaces map[string]bool) []models.IngressInfo {
+// Anotações usadas para identificar a IngressClass de Ingresses antigos e a classe padrão do cluster.
+const (
+	legacyIngressClassAnnotation  = "kubernetes.io/ingress.class"
+	defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// processIngressInfo formata os dados dos Ingresses com todas as regras, caminhos, backends, TLS e endereços.
+func processIngressInfo(ingresses *networkingv1.IngressList, ingressClasses *networkingv1.IngressClassList, userNamespaces map[string]bool) []models.IngressInfo {
 	ingressInfoList := []models.IngressInfo{}
 	if ingresses == nil {
 		return ingressInfoList
 	}
 
+	controllers := map[string]string{}
+	defaultClass := ""
+	if ingressClasses != nil {
+		for _, class := range ingressClasses.Items {
+			controllers[class.Name] = class.Spec.Controller
+			if class.Annotations[defaultIngressClassAnnotation] == "true" {
+				defaultClass = class.Name
+			}
+		}
+	}
+
 	for _, ingress := range ingresses.Items {
 		if !userNamespaces[ingress.Namespace] {
 			continue
 		}
 
-		var hosts []string
-		var backendService string
+		info := models.IngressInfo{
+			Name:      ingress.Name,
+			Namespace: ingress.Namespace,
+			Hosts:     []string{},
+			Rules:     []models.IngressRuleInfo{},
+			TLS:       []models.IngressTLSInfo{},
+			Addresses: []string{},
+		}
+
+		// A classe vem do spec, da anotação legada ou, na falta de ambas, da IngressClass padrão.
+		switch {
+		case ingress.Spec.IngressClassName != nil:
+			info.IngressClass = *ingress.Spec.IngressClassName
+		case ingress.Annotations[legacyIngressClassAnnotation] != "":
+			info.IngressClass = ingress.Annotations[legacyIngressClassAnnotation]
+		default:
+			info.IngressClass = defaultClass
+		}
+		info.ClassController = controllers[info.IngressClass]
+
+		if ingress.Spec.DefaultBackend != nil {
+			backend := ingressBackendInfo(*ingress.Spec.DefaultBackend)
+			info.DefaultBackend = &backend
+		}
+
+		hostSet := map[string]bool{}
 		for _, rule := range ingress.Spec.Rules {
-			hosts = append(hosts, rule.Host)
-			if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
-				backendService = rule.HTTP.Paths[0].Backend.Service.Name
+			if rule.Host != "" && !hostSet[rule.Host] {
+				hostSet[rule.Host] = true
+				info.Hosts = append(info.Hosts, rule.Host)
+			}
+			ruleInfo := models.IngressRuleInfo{Host: rule.Host, Paths: []models.IngressPathInfo{}}
+			if rule.HTTP != nil {
+				for _, path := range rule.HTTP.Paths {
+					pathInfo := models.IngressPathInfo{Path: path.Path, Backend: ingressBackendInfo(path.Backend)}
+					if path.PathType != nil {
+						pathInfo.PathType = string(*path.PathType)
+					}
+					ruleInfo.Paths = append(ruleInfo.Paths, pathInfo)
+				}
 			}
+			info.Rules = append(info.Rules, ruleInfo)
 		}
 
-		info := models.IngressInfo{
-			Name:      ingress.Name,
-			Namespace: ingress.Namespace,
-			Hosts:     strings.Join(hosts, ", "),
-			Service:   backendService,
+		for _, tls := range ingress.Spec.TLS {
+			hosts := tls.Hosts
+			if hosts == nil {
+				hosts = []string{}
+			}
+			info.TLS = append(info.TLS, models.IngressTLSInfo{Hosts: hosts, SecretName: tls.SecretName})
+		}
+
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				info.Addresses = append(info.Addresses, lb.IP)
+			}
+			if lb.Hostname != "" {
+				info.Addresses = append(info.Addresses, lb.Hostname)
+			}
 		}
+
 		ingressInfoList = append(ingressInfoList, info)
 	}
-	sort.Slice(ingressInfoList, func(i, j int) bool { return ingressInfoList[i].Name < ingressInfoList[j].Name })
+	sort.Slice(ingressInfoList, func(i, j int) bool {
+		if ingressInfoList[i].Namespace == ingressInfoList[j].Namespace {
+			return ingressInfoList[i].Name < ingressInfoList[j].Name
+		}
+		return ingressInfoList[i].Namespace < ingressInfoList[j].Namespace
+	})
 	return ingressInfoList
 }
 
+// ingressBackendInfo converte um backend de Ingress, que pode apontar para um Service ou para um recurso.
+func ingressBackendInfo(backend networkingv1.IngressBackend) models.IngressBackendInfo {
+	info := models.IngressBackendInfo{}
+	if backend.Service != nil {
+		info.Service = backend.Service.Name
+		if backend.Service.Port.Name != "" {
+			info.Port = backend.Service.Port.Name
+		} else if backend.Service.Port.Number != 0 {
+			info.Port = strconv.Itoa(int(backend.Service.Port.Number))
+		}
+	}
+	if backend.Resource != nil {
+		resourceKind := backend.Resource.Kind
+		if backend.Resource.APIGroup != nil && *backend.Resource.APIGroup != "" {
+			resourceKind = *backend.Resource.APIGroup + "/" + resourceKind
+		}
+		info.Resource = resourceKind + "/" + backend.Resource.Name
+	}
+	return info
+}
+
 // processClusterCapacity calcula o uso total de CPU e memória do cluster.
 func processClusterCapacity(nodes *v1.NodeList, nodeMetrics *metricsv1beta1.NodeMetricsList) models.ClusterCapacityInfo {
 	var totalCPU, usedCPU, totalMemory, usedMemory int64
@@ -154,13 +318,30 @@ func processClusterCapacity(nodes *v1.NodeList, nodeMetrics *metricsv1beta1.Node
 	}
 }
 
-// processNamespaces filtra e conta os namespaces que não são do sistema.
-func processNamespaces(namespaces *v1.NamespaceList) (int, map[string]bool) {
-	systemNamespaces := map[string]bool{
+// systemNamespaces e systemNamespacePrefixes identificam os namespaces de infraestrutura, ocultos no dashboard.
+var (
+	systemNamespaces = map[string]bool{
 		"default": true, "kube-system": true, "kube-public": true, "kube-node-lease": true,
 		"local": true, "cert-manager": true,
 	}
-	systemPrefixes := []string{"cattle-", "fleet-", "cluster-fleet-", "local-p-", "p-", "user-"}
+	systemNamespacePrefixes = []string{"cattle-", "fleet-", "cluster-fleet-", "local-p-", "p-", "user-"}
+)
+
+// IsSystemNamespace indica se o namespace é do sistema e, portanto, não é exibido no dashboard.
+func IsSystemNamespace(name string) bool {
+	if systemNamespaces[name] {
+		return true
+	}
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// processNamespaces filtra e conta os namespaces que não são do sistema.
+func processNamespaces(namespaces *v1.NamespaceList) (int, map[string]bool) {
 	userNamespaceCount := 0
 	userNamespaces := map[string]bool{}
 
@@ -169,18 +350,7 @@ func processNamespaces(namespaces *v1.NamespaceList) (int, map[string]bool) {
 	}
 
 	for _, ns := range namespaces.Items {
-		isSystem := false
-		if systemNamespaces[ns.Name] {
-			isSystem = true
-		} else {
-			for _, prefix := range systemPrefixes {
-				if strings.HasPrefix(ns.Name, prefix) {
-					isSystem = true
-					break
-				}
-			}
-		}
-		if !isSystem {
+		if !IsSystemNamespace(ns.Name) {
 			userNamespaceCount++
 			userNamespaces[ns.Name] = true
 		}
@@ -188,20 +358,20 @@ func processNamespaces(namespaces *v1.NamespaceList) (int, map[string]bool) {
 	return userNamespaceCount, userNamespaces
 }
 
-// processNodeInfo formata os dados dos nós do cluster, identificando o master.
-func processNodeInfo(nodes *v1.NodeList, pods *v1.PodList, nodeMetrics *metricsv1beta1.NodeMetricsList) []models.NodeInfo {
+// processNodeInfo formata os dados dos nós do cluster, identificando o master, os papéis e o pool de cada nó.
+func processNodeInfo(nodes *v1.NodeList, pods *v1.PodList, nodeMetrics *metricsv1beta1.NodeMetricsList, labelConfig nodeLabelConfig) []models.NodeInfo {
 	nodeInfoList := []models.NodeInfo{}
 	if nodes == nil {
 		return nodeInfoList
 	}
 
 	for _, node := range nodes.Items {
+		roles := nodeRoles(node, labelConfig)
 		role := "Worker" // Define 'Worker' como padrão.
-		if _, ok := node.Labels["node-role.kubernetes.io/master"]; ok {
-			role = "Control-Plane"
-		}
-		if _, ok := node.Labels["node-role.kubernetes.io/control-plane"]; ok {
-			role = "Control-Plane"
+		for _, r := range roles {
+			if r == "control-plane" {
+				role = "Control-Plane"
+			}
 		}
 
 		usedCPU, usedMemory := getNodeUsage(node.Name, nodeMetrics)
@@ -224,6 +394,8 @@ func processNodeInfo(nodes *v1.NodeList, pods *v1.PodList, nodeMetrics *metricsv
 		info := models.NodeInfo{
 			Name:                  node.Name,
 			Role:                  role,
+			Roles:                 roles,
+			Pool:                  nodePool(node, labelConfig),
 			PodCount:              podCount,
 			TotalCPU:              fmt.Sprintf("%.2f", float64(node.Status.Allocatable.Cpu().MilliValue())/1000.0),
 			UsedCPU:               fmt.Sprintf("%.2f", float64(usedCPU.MilliValue())/1000.0),
@@ -231,13 +403,100 @@ func processNodeInfo(nodes *v1.NodeList, pods *v1.PodList, nodeMetrics *metricsv
 			TotalMemory:           fmt.Sprintf("%.2f Gi", float64(node.Status.Allocatable.Memory().Value())/(1024*1024*1024)),
 			UsedMemory:            fmt.Sprintf("%.2f Gi", float64(usedMemory.Value())/(1024*1024*1024)),
 			MemoryUsagePercentage: memoryUsagePercentage,
+			AllocatablePods:       node.Status.Allocatable.Pods().Value(),
+			RunningPods:           countRunningPodsOnNode(node.Name, pods),
 		}
+		applyNodeStatus(&info, node)
 		nodeInfoList = append(nodeInfoList, info)
 	}
 	sort.Slice(nodeInfoList, func(i, j int) bool { return nodeInfoList[i].Name < nodeInfoList[j].Name })
 	return nodeInfoList
 }
 
+// applyNodeStatus preenche as condições, taints, informações de sistema, endereços e topologia do nó.
+func applyNodeStatus(info *models.NodeInfo, node v1.Node) {
+	info.Unschedulable = node.Spec.Unschedulable
+	info.Conditions = []models.NodeConditionInfo{}
+	for _, condition := range node.Status.Conditions {
+		isTrue := condition.Status == v1.ConditionTrue
+		switch condition.Type {
+		case v1.NodeReady:
+			info.Ready = isTrue
+		case v1.NodeMemoryPressure:
+			info.MemoryPressure = isTrue
+		case v1.NodeDiskPressure:
+			info.DiskPressure = isTrue
+		case v1.NodePIDPressure:
+			info.PIDPressure = isTrue
+		}
+		info.Conditions = append(info.Conditions, models.NodeConditionInfo{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Format(time.RFC3339),
+		})
+	}
+
+	info.Taints = []models.TaintInfo{}
+	for _, taint := range node.Spec.Taints {
+		info.Taints = append(info.Taints, models.TaintInfo{Key: taint.Key, Value: taint.Value, Effect: string(taint.Effect)})
+	}
+
+	nodeInfo := node.Status.NodeInfo
+	info.KubeletVersion = nodeInfo.KubeletVersion
+	info.KernelVersion = nodeInfo.KernelVersion
+	info.ContainerRuntime = nodeInfo.ContainerRuntimeVersion
+	info.OSImage = nodeInfo.OSImage
+	info.OperatingSystem = nodeInfo.OperatingSystem
+	info.Architecture = nodeInfo.Architecture
+
+	if !node.CreationTimestamp.IsZero() {
+		info.CreatedAt = node.CreationTimestamp.Format(time.RFC3339)
+		info.Age = formatAge(time.Since(node.CreationTimestamp.Time))
+	}
+
+	info.InternalIPs = []string{}
+	info.ExternalIPs = []string{}
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case v1.NodeInternalIP:
+			info.InternalIPs = append(info.InternalIPs, address.Address)
+		case v1.NodeExternalIP:
+			info.ExternalIPs = append(info.ExternalIPs, address.Address)
+		case v1.NodeHostName:
+			info.Hostname = address.Address
+		}
+	}
+
+	info.Zone = firstLabel(node.Labels, v1.LabelTopologyZone, v1.LabelFailureDomainBetaZone)
+	info.Region = firstLabel(node.Labels, v1.LabelTopologyRegion, v1.LabelFailureDomainBetaRegion)
+}
+
+// firstLabel retorna o valor do primeiro rótulo encontrado, permitindo cair para rótulos legados.
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := labels[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// formatAge formata uma duração no estilo do kubectl (ex.: 45s, 12m, 5h, 3d).
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 func getNodeUsage(nodeName string, nodeMetrics *metricsv1beta1.NodeMetricsList) (*resource.Quantity, *resource.Quantity) {
 	if nodeMetrics == nil {
 		return resource.NewQuantity(0, resource.DecimalSI), resource.NewQuantity(0, resource.BinarySI)
@@ -263,6 +522,20 @@ func countPodsOnNode(nodeName string, pods *v1.PodList) int {
 	return count
 }
 
+// countRunningPodsOnNode conta os pods do nó que ainda ocupam uma vaga (não finalizados).
+func countRunningPodsOnNode(nodeName string, pods *v1.PodList) int {
+	if pods == nil {
+		return 0
+	}
+	count := 0
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == nodeName && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			count++
+		}
+	}
+	return count
+}
+
 // processPodInfo formata os dados dos pods, incluindo status detalhado e reinicializações.
 func processPodInfo(pods *v1.PodList, podMetricsList *metricsv1beta1.PodMetricsList, userNamespaces map[string]bool) []models.PodInfo {
 	podInfoList := []models.PodInfo{}
@@ -287,15 +560,8 @@ func processPodInfo(pods *v1.PodList, podMetricsList *metricsv1beta1.PodMetricsL
 		var usedCPUMilli, usedMemoryBytes int64
 
 		if pm, ok := metricsMap[pod.Namespace+"/"+pod.Name]; ok {
-			totalCPU := resource.NewQuantity(0, resource.DecimalSI)
-			totalMemory := resource.NewQuantity(0, resource.BinarySI)
-			for _, container := range pm.Containers {
-				totalCPU.Add(*container.Usage.Cpu())
-				totalMemory.Add(*container.Usage.Memory())
-			}
-			usedCPUMilli = totalCPU.MilliValue()
+			usedCPUMilli, usedMemoryBytes = sumPodMetrics(pm)
 			usedCPU = fmt.Sprintf("%d m", usedCPUMilli)
-			usedMemoryBytes = totalMemory.Value()
 			usedMemory = fmt.Sprintf("%.2f Mi", float64(usedMemoryBytes)/(1024*1024))
 		}
 
@@ -315,44 +581,32 @@ func processPodInfo(pods *v1.PodList, podMetricsList *metricsv1beta1.PodMetricsL
 	return podInfoList
 }
 
-// processEvents formata e ordena os eventos do cluster.
-func processEvents(events *v1.EventList, userNamespaces map[string]bool) []models.EventInfo {
-	eventInfoList := []models.EventInfo{}
-	if events == nil {
-		return eventInfoList
-	}
-
-	sort.Slice(events.Items, func(i, j int) bool {
-		return events.Items[j].LastTimestamp.Before(&events.Items[i].LastTimestamp)
-	})
-
-	for _, event := range events.Items {
-		if !userNamespaces[event.Namespace] && event.Namespace != "" {
-			continue
-		}
-
-		info := models.EventInfo{
-			Timestamp: event.LastTimestamp.Format(time.RFC822),
-			Type:      event.Type,
-			Reason:    event.Reason,
-			Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Message:   event.Message,
-		}
-		eventInfoList = append(eventInfoList, info)
-		if len(eventInfoList) >= 50 {
-			break
-		}
+// sumPodMetrics soma o uso de CPU (em milicores) e memória (em bytes) de todos os contêineres de um pod.
+func sumPodMetrics(pm metricsv1beta1.PodMetrics) (int64, int64) {
+	totalCPU := resource.NewQuantity(0, resource.DecimalSI)
+	totalMemory := resource.NewQuantity(0, resource.BinarySI)
+	for _, container := range pm.Containers {
+		totalCPU.Add(*container.Usage.Cpu())
+		totalMemory.Add(*container.Usage.Memory())
 	}
-	return eventInfoList
+	return totalCPU.MilliValue(), totalMemory.Value()
 }
 
-// processPvcs formata os dados dos PVCs (PersistentVolumeClaims).
-func processPvcs(pvcs *v1.PersistentVolumeClaimList, userNamespaces map[string]bool) []models.PvcInfo {
+// processPvcs formata os dados dos PVCs (PersistentVolumeClaims), ligando cada um ao seu PV e aos pods que o utilizam.
+func processPvcs(pvcs *v1.PersistentVolumeClaimList, pvs *v1.PersistentVolumeList, pods *v1.PodList, userNamespaces map[string]bool) []models.PvcInfo {
 	pvcInfoList := []models.PvcInfo{}
 	if pvcs == nil {
 		return pvcInfoList
 	}
 
+	pvMap := make(map[string]v1.PersistentVolume)
+	if pvs != nil {
+		for _, pv := range pvs.Items {
+			pvMap[pv.Name] = pv
+		}
+	}
+	consumers := podsByClaim(pods)
+
 	for _, pvc := range pvcs.Items {
 		if !userNamespaces[pvc.Namespace] {
 			continue
@@ -360,13 +614,388 @@ func processPvcs(pvcs *v1.PersistentVolumeClaimList, userNamespaces map[string]b
 
 		storage := pvc.Spec.Resources.Requests[v1.ResourceStorage]
 		info := models.PvcInfo{
-			Name:      pvc.Name,
-			Namespace: pvc.Namespace,
-			Status:    string(pvc.Status.Phase),
-			Capacity:  storage.String(),
+			Name:        pvc.Name,
+			Namespace:   pvc.Namespace,
+			Status:      string(pvc.Status.Phase),
+			Capacity:    storage.String(),
+			VolumeName:  pvc.Spec.VolumeName,
+			AccessModes: accessModesToStrings(pvc.Status.AccessModes),
+			UsedBy:      consumers[pvc.Namespace+"/"+pvc.Name],
+		}
+		if bound, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+			info.BoundCapacity = bound.String()
+		}
+		if len(info.AccessModes) == 0 {
+			info.AccessModes = accessModesToStrings(pvc.Spec.AccessModes)
+		}
+		if pvc.Spec.StorageClassName != nil {
+			info.StorageClass = *pvc.Spec.StorageClassName
+		}
+		if pvc.Spec.VolumeMode != nil {
+			info.VolumeMode = string(*pvc.Spec.VolumeMode)
+		}
+		if pv, ok := pvMap[pvc.Spec.VolumeName]; ok {
+			info.ReclaimPolicy = string(pv.Spec.PersistentVolumeReclaimPolicy)
+			if info.StorageClass == "" {
+				info.StorageClass = pv.Spec.StorageClassName
+			}
+		}
+		if info.UsedBy == nil {
+			info.UsedBy = []string{}
 		}
 		pvcInfoList = append(pvcInfoList, info)
 	}
 	sort.Slice(pvcInfoList, func(i, j int) bool { return pvcInfoList[i].Name < pvcInfoList[j].Name })
 	return pvcInfoList
 }
+
+// podsByClaim indexa, por "namespace/claim", os nomes dos pods que montam cada PVC.
+// Volumes efêmeros geram um PVC chamado "<pod>-<volume>", que também é considerado.
+func podsByClaim(pods *v1.PodList) map[string][]string {
+	consumers := map[string][]string{}
+	if pods == nil {
+		return consumers
+	}
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			var claimName string
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				claimName = volume.PersistentVolumeClaim.ClaimName
+			case volume.Ephemeral != nil:
+				claimName = pod.Name + "-" + volume.Name
+			default:
+				continue
+			}
+			key := pod.Namespace + "/" + claimName
+			consumers[key] = append(consumers[key], pod.Name)
+		}
+	}
+	for key := range consumers {
+		sort.Strings(consumers[key])
+	}
+	return consumers
+}
+
+// accessModesToStrings converte os modos de acesso para uma lista de strings.
+func accessModesToStrings(modes []v1.PersistentVolumeAccessMode) []string {
+	result := []string{}
+	for _, mode := range modes {
+		result = append(result, string(mode))
+	}
+	return result
+}
+
+// processPersistentVolumes formata os dados dos PersistentVolumes.
+func processPersistentVolumes(pvs *v1.PersistentVolumeList) []models.PersistentVolumeInfo {
+	pvInfoList := []models.PersistentVolumeInfo{}
+	if pvs == nil {
+		return pvInfoList
+	}
+
+	for _, pv := range pvs.Items {
+		capacity := pv.Spec.Capacity[v1.ResourceStorage]
+		info := models.PersistentVolumeInfo{
+			Name:          pv.Name,
+			Status:        string(pv.Status.Phase),
+			Capacity:      capacity.String(),
+			AccessModes:   accessModesToStrings(pv.Spec.AccessModes),
+			ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+			StorageClass:  pv.Spec.StorageClassName,
+			Source:        persistentVolumeSource(pv),
+			Reason:        pv.Status.Reason,
+		}
+		if pv.Spec.VolumeMode != nil {
+			info.VolumeMode = string(*pv.Spec.VolumeMode)
+		}
+		if pv.Spec.ClaimRef != nil {
+			info.Claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		pvInfoList = append(pvInfoList, info)
+	}
+	sort.Slice(pvInfoList, func(i, j int) bool { return pvInfoList[i].Name < pvInfoList[j].Name })
+	return pvInfoList
+}
+
+// persistentVolumeSource descreve o backend de armazenamento de um PV.
+func persistentVolumeSource(pv v1.PersistentVolume) string {
+	source := pv.Spec.PersistentVolumeSource
+	switch {
+	case source.CSI != nil:
+		return "csi:" + source.CSI.Driver
+	case source.HostPath != nil:
+		return "hostPath:" + source.HostPath.Path
+	case source.Local != nil:
+		return "local:" + source.Local.Path
+	case source.NFS != nil:
+		return "nfs:" + source.NFS.Server + ":" + source.NFS.Path
+	case source.ISCSI != nil:
+		return "iscsi"
+	case source.FC != nil:
+		return "fc"
+	case source.RBD != nil:
+		return "rbd"
+	case source.CephFS != nil:
+		return "cephfs"
+	default:
+		return "other"
+	}
+}
+
+// processStorageClasses formata os StorageClasses, contando quantos PVs cada um provisionou.
+func processStorageClasses(storageClasses *storagev1.StorageClassList, pvs *v1.PersistentVolumeList) []models.StorageClassInfo {
+	storageClassInfoList := []models.StorageClassInfo{}
+	if storageClasses == nil {
+		return storageClassInfoList
+	}
+
+	volumeCount := map[string]int{}
+	if pvs != nil {
+		for _, pv := range pvs.Items {
+			volumeCount[pv.Spec.StorageClassName]++
+		}
+	}
+
+	for _, sc := range storageClasses.Items {
+		info := models.StorageClassInfo{
+			Name:                 sc.Name,
+			Provisioner:          sc.Provisioner,
+			AllowVolumeExpansion: sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion,
+			IsDefault:            sc.Annotations["storageclass.kubernetes.io/is-default-class"] == "true",
+			Parameters:           sc.Parameters,
+			VolumeCount:          volumeCount[sc.Name],
+		}
+		if sc.ReclaimPolicy != nil {
+			info.ReclaimPolicy = string(*sc.ReclaimPolicy)
+		}
+		if sc.VolumeBindingMode != nil {
+			info.VolumeBindingMode = string(*sc.VolumeBindingMode)
+		}
+		storageClassInfoList = append(storageClassInfoList, info)
+	}
+	sort.Slice(storageClassInfoList, func(i, j int) bool { return storageClassInfoList[i].Name < storageClassInfoList[j].Name })
+	return storageClassInfoList
+}
+
+// maxNamespaceWarningEvents limita a quantidade de eventos de alerta exibidos por namespace.
+const maxNamespaceWarningEvents = 5
+
+// processNamespaceInfo monta o resumo de saúde de cada namespace de usuário.
+func processNamespaceInfo(
+	namespaces *v1.NamespaceList,
+	pods *v1.PodList,
+	podMetricsList *metricsv1beta1.PodMetricsList,
+	quotas *v1.ResourceQuotaList,
+	limitRanges *v1.LimitRangeList,
+	events *v1.EventList,
+	userNamespaces map[string]bool,
+) []models.NamespaceInfo {
+	namespaceInfoList := []models.NamespaceInfo{}
+	if namespaces == nil {
+		return namespaceInfoList
+	}
+
+	infoByName := make(map[string]*models.NamespaceInfo)
+	for _, ns := range namespaces.Items {
+		if !userNamespaces[ns.Name] {
+			continue
+		}
+		infoByName[ns.Name] = &models.NamespaceInfo{
+			Name:          ns.Name,
+			Phase:         string(ns.Status.Phase),
+			Labels:        ns.Labels,
+			PodsByStatus:  map[string]int{},
+			Quotas:        []models.ResourceQuotaInfo{},
+			LimitRanges:   []models.LimitRangeInfo{},
+			WarningEvents: []models.EventInfo{},
+		}
+	}
+
+	if pods != nil {
+		for _, pod := range pods.Items {
+			info, ok := infoByName[pod.Namespace]
+			if !ok {
+				continue
+			}
+			status, _ := getPodStatus(pod)
+			info.PodCount++
+			info.PodsByStatus[status]++
+		}
+	}
+
+	if podMetricsList != nil {
+		for _, pm := range podMetricsList.Items {
+			info, ok := infoByName[pm.Namespace]
+			if !ok {
+				continue
+			}
+			cpuMilli, memoryBytes := sumPodMetrics(pm)
+			info.UsedCPUMilli += cpuMilli
+			info.UsedMemoryBytes += memoryBytes
+		}
+	}
+
+	for _, quota := range processResourceQuotas(quotas, userNamespaces) {
+		if info, ok := infoByName[quota.Namespace]; ok {
+			info.Quotas = append(info.Quotas, quota)
+		}
+	}
+
+	for _, limitRange := range processLimitRanges(limitRanges, userNamespaces) {
+		if info, ok := infoByName[limitRange.Namespace]; ok {
+			info.LimitRanges = append(info.LimitRanges, limitRange)
+		}
+	}
+
+	if events != nil {
+		warnings := make([]v1.Event, 0, len(events.Items))
+		for _, event := range events.Items {
+			if event.Type == v1.EventTypeWarning {
+				warnings = append(warnings, event)
+			}
+		}
+		sort.Slice(warnings, func(i, j int) bool {
+			return eventinfo.CoreLastSeen(warnings[j]).Before(eventinfo.CoreLastSeen(warnings[i]))
+		})
+		for _, event := range warnings {
+			info, ok := infoByName[event.Namespace]
+			if !ok || len(info.WarningEvents) >= maxNamespaceWarningEvents {
+				continue
+			}
+			info.WarningEvents = append(info.WarningEvents, eventinfo.FromCoreEvent(event))
+		}
+	}
+
+	for _, info := range infoByName {
+		info.UsedCPU = fmt.Sprintf("%d m", info.UsedCPUMilli)
+		info.UsedMemory = fmt.Sprintf("%.2f Mi", float64(info.UsedMemoryBytes)/(1024*1024))
+		namespaceInfoList = append(namespaceInfoList, *info)
+	}
+	sort.Slice(namespaceInfoList, func(i, j int) bool { return namespaceInfoList[i].Name < namespaceInfoList[j].Name })
+	return namespaceInfoList
+}
+
+// processResourceQuotas calcula o consumo (usado vs. limite) de cada ResourceQuota.
+func processResourceQuotas(quotas *v1.ResourceQuotaList, userNamespaces map[string]bool) []models.ResourceQuotaInfo {
+	quotaInfoList := []models.ResourceQuotaInfo{}
+	if quotas == nil {
+		return quotaInfoList
+	}
+
+	for _, quota := range quotas.Items {
+		if !userNamespaces[quota.Namespace] {
+			continue
+		}
+
+		resources := []models.QuotaResourceUsage{}
+		for name, hard := range quota.Status.Hard {
+			used := quota.Status.Used[name]
+			var percentage float64
+			if hardValue := hard.AsApproximateFloat64(); hardValue > 0 {
+				percentage = (used.AsApproximateFloat64() / hardValue) * 100
+			}
+			resources = append(resources, models.QuotaResourceUsage{
+				Resource:   string(name),
+				Hard:       hard.String(),
+				Used:       used.String(),
+				Percentage: percentage,
+			})
+		}
+		sort.Slice(resources, func(i, j int) bool { return resources[i].Resource < resources[j].Resource })
+
+		quotaInfoList = append(quotaInfoList, models.ResourceQuotaInfo{
+			Name:      quota.Name,
+			Namespace: quota.Namespace,
+			Resources: resources,
+		})
+	}
+	sort.Slice(quotaInfoList, func(i, j int) bool {
+		if quotaInfoList[i].Namespace == quotaInfoList[j].Namespace {
+			return quotaInfoList[i].Name < quotaInfoList[j].Name
+		}
+		return quotaInfoList[i].Namespace < quotaInfoList[j].Namespace
+	})
+	return quotaInfoList
+}
+
+// processQuotaAlerts seleciona os recursos de quota cujo uso atingiu o percentual de alerta, do mais crítico ao menos crítico.
+func processQuotaAlerts(quotas []models.ResourceQuotaInfo, threshold float64) []models.QuotaAlert {
+	alerts := []models.QuotaAlert{}
+	for _, quota := range quotas {
+		for _, r := range quota.Resources {
+			if r.Percentage < threshold {
+				continue
+			}
+			alerts = append(alerts, models.QuotaAlert{
+				Namespace:  quota.Namespace,
+				Quota:      quota.Name,
+				Resource:   r.Resource,
+				Hard:       r.Hard,
+				Used:       r.Used,
+				Percentage: r.Percentage,
+			})
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].Percentage > alerts[j].Percentage })
+	return alerts
+}
+
+// processLimitRanges lista os limites definidos em cada LimitRange, um item por tipo e recurso.
+func processLimitRanges(limitRanges *v1.LimitRangeList, userNamespaces map[string]bool) []models.LimitRangeInfo {
+	limitRangeInfoList := []models.LimitRangeInfo{}
+	if limitRanges == nil {
+		return limitRangeInfoList
+	}
+
+	for _, limitRange := range limitRanges.Items {
+		if !userNamespaces[limitRange.Namespace] {
+			continue
+		}
+
+		limits := []models.LimitRangeItemInfo{}
+		for _, item := range limitRange.Spec.Limits {
+			resourceNames := map[v1.ResourceName]bool{}
+			for _, list := range []v1.ResourceList{item.Min, item.Max, item.Default, item.DefaultRequest, item.MaxLimitRequestRatio} {
+				for name := range list {
+					resourceNames[name] = true
+				}
+			}
+
+			itemLimits := []models.LimitRangeItemInfo{}
+			for name := range resourceNames {
+				itemLimits = append(itemLimits, models.LimitRangeItemInfo{
+					Type:                 string(item.Type),
+					Resource:             string(name),
+					Min:                  quantityString(item.Min, name),
+					Max:                  quantityString(item.Max, name),
+					Default:              quantityString(item.Default, name),
+					DefaultRequest:       quantityString(item.DefaultRequest, name),
+					MaxLimitRequestRatio: quantityString(item.MaxLimitRequestRatio, name),
+				})
+			}
+			sort.Slice(itemLimits, func(i, j int) bool { return itemLimits[i].Resource < itemLimits[j].Resource })
+			limits = append(limits, itemLimits...)
+		}
+
+		limitRangeInfoList = append(limitRangeInfoList, models.LimitRangeInfo{
+			Name:      limitRange.Name,
+			Namespace: limitRange.Namespace,
+			Limits:    limits,
+		})
+	}
+	sort.Slice(limitRangeInfoList, func(i, j int) bool {
+		if limitRangeInfoList[i].Namespace == limitRangeInfoList[j].Namespace {
+			return limitRangeInfoList[i].Name < limitRangeInfoList[j].Name
+		}
+		return limitRangeInfoList[i].Namespace < limitRangeInfoList[j].Namespace
+	})
+	return limitRangeInfoList
+}
+
+// quantityString retorna o valor de um recurso da lista, ou uma string vazia se ele não estiver definido.
+func quantityString(list v1.ResourceList, name v1.ResourceName) string {
+	if q, ok := list[name]; ok {
+		return q.String()
+	}
+	return ""
+}
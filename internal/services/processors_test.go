@@ -2,15 +2,21 @@ package services
 
 import (
 	"io"
+	"kubeowl/internal/eventinfo"
+	"kubeowl/internal/models"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
@@ -108,16 +114,67 @@ func TestProcessNamespaces(t *testing.T) {
 }
 
 func TestProcessServiceInfo(t *testing.T) {
-	services := &v1.ServiceList{Items: []v1.Service{{
-		ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "app-ns"},
-		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
-		Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "8.8.8.8"}}}},
-	}}}
+	ready, notReady := true, false
+	services := &v1.ServiceList{Items: []v1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "app-ns"},
+			Spec: v1.ServiceSpec{
+				Type:            v1.ServiceTypeLoadBalancer,
+				ClusterIP:       "10.0.0.1",
+				ExternalIPs:     []string{"192.0.2.10"},
+				Selector:        map[string]string{"app": "web"},
+				SessionAffinity: v1.ServiceAffinityClientIP,
+				Ports: []v1.ServicePort{
+					{Name: "http", Protocol: v1.ProtocolTCP, Port: 80, TargetPort: intstr.FromString("http"), NodePort: 30080},
+					{Name: "metrics", Protocol: v1.ProtocolTCP, Port: 9090, TargetPort: intstr.FromInt32(9090)},
+				},
+			},
+			Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{
+				{IP: "8.8.8.8"}, {Hostname: "abc.elb.amazonaws.com"},
+			}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "external-db", Namespace: "app-ns"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "db.example.com"},
+		},
+	}}
+	endpointSlices := &discoveryv1.EndpointSliceList{Items: []discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-service-v4", Namespace: "app-ns", Labels: map[string]string{discoveryv1.LabelServiceName: "my-service"}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.1.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+				{Addresses: []string{"10.1.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+				{Addresses: []string{"10.1.0.3"}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-3"}},
+			},
+		},
+		{
+			// Slice IPv6 do mesmo Service em um cluster dual-stack: os pods não devem ser contados duas vezes.
+			ObjectMeta: metav1.ObjectMeta{Name: "my-service-v6", Namespace: "app-ns", Labels: map[string]string{discoveryv1.LabelServiceName: "my-service"}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"fd00::1"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+			},
+		},
+	}}
 	userNamespaces := map[string]bool{"app-ns": true}
 
-	serviceInfo := processServiceInfo(services, userNamespaces)
-	assert.Len(t, serviceInfo, 1)
-	assert.Equal(t, "8.8.8.8", serviceInfo[0].ExternalIP)
+	serviceInfo := processServiceInfo(services, endpointSlices, userNamespaces)
+	assert.Len(t, serviceInfo, 2)
+
+	externalName := serviceInfo[0]
+	assert.Equal(t, "db.example.com", externalName.ExternalName)
+	assert.Empty(t, externalName.Ports)
+	assert.Empty(t, externalName.Selector)
+
+	service := serviceInfo[1]
+	assert.Equal(t, []string{"192.0.2.10", "8.8.8.8", "abc.elb.amazonaws.com"}, service.ExternalIPs)
+	assert.Equal(t, map[string]string{"app": "web"}, service.Selector)
+	assert.Equal(t, "ClientIP", service.SessionAffinity)
+	assert.Equal(t, []models.ServicePortInfo{
+		{Name: "http", Protocol: "TCP", Port: 80, TargetPort: "http", NodePort: 30080},
+		{Name: "metrics", Protocol: "TCP", Port: 9090, TargetPort: "9090"},
+	}, service.Ports)
+	assert.Equal(t, 2, service.ReadyEndpoints)
+	assert.Equal(t, 1, service.NotReadyEndpoints)
 }
 
 func TestProcessPodInfo(t *testing.T) {
@@ -145,17 +202,89 @@ func TestProcessPodInfo(t *testing.T) {
 }
 
 func TestProcessIngressInfo(t *testing.T) {
-	ingresses := &networkingv1.IngressList{Items: []networkingv1.Ingress{{
-		ObjectMeta: metav1.ObjectMeta{Name: "my-ingress", Namespace: "app-ns"},
-		Spec: networkingv1.IngressSpec{
-			Rules: []networkingv1.IngressRule{{Host: "test.com"}},
+	className := "nginx"
+	prefix := networkingv1.PathTypePrefix
+	exact := networkingv1.PathTypeExact
+	apiGroup := "storage.example.com"
+	ingresses := &networkingv1.IngressList{Items: []networkingv1.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ingress", Namespace: "app-ns"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &className,
+				DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+					Name: "fallback", Port: networkingv1.ServiceBackendPort{Number: 8080},
+				}},
+				TLS: []networkingv1.IngressTLS{{Hosts: []string{"test.com"}, SecretName: "test-tls"}},
+				Rules: []networkingv1.IngressRule{
+					{Host: "test.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: &prefix, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+							Name: "web", Port: networkingv1.ServiceBackendPort{Name: "http"},
+						}}},
+						{Path: "/assets", PathType: &exact, Backend: networkingv1.IngressBackend{Resource: &v1.TypedLocalObjectReference{
+							APIGroup: &apiGroup, Kind: "Bucket", Name: "static",
+						}}},
+					}}}},
+					{Host: "api.test.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/v1", Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+							Name: "api", Port: networkingv1.ServiceBackendPort{Number: 9000},
+						}}},
+					}}}},
+					{Host: "test.com"},
+				},
+			},
+			Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{Ingress: []networkingv1.IngressLoadBalancerIngress{
+				{IP: "203.0.113.10"}, {Hostname: "lb.example.com"},
+			}}},
 		},
-	}}}
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "app-ns", Annotations: map[string]string{"kubernetes.io/ingress.class": "traefik"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unclassed", Namespace: "app-ns"},
+		},
+	}}
+	ingressClasses := &networkingv1.IngressClassList{Items: []networkingv1.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Annotations: map[string]string{"ingressclass.kubernetes.io/is-default-class": "true"}},
+			Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+		},
+	}}
 	userNamespaces := map[string]bool{"app-ns": true}
 
-	ingressInfo := processIngressInfo(ingresses, userNamespaces)
-	assert.Len(t, ingressInfo, 1)
-	assert.Equal(t, "test.com", ingressInfo[0].Hosts)
+	ingressInfo := processIngressInfo(ingresses, ingressClasses, userNamespaces)
+	assert.Len(t, ingressInfo, 3)
+
+	legacy, main, unclassed := ingressInfo[0], ingressInfo[1], ingressInfo[2]
+	assert.Equal(t, "traefik", legacy.IngressClass)
+	assert.Empty(t, legacy.ClassController)
+	assert.Equal(t, "nginx", unclassed.IngressClass)
+	assert.Empty(t, unclassed.Rules)
+
+	assert.Equal(t, "nginx", main.IngressClass)
+	assert.Equal(t, "k8s.io/ingress-nginx", main.ClassController)
+	assert.Equal(t, []string{"test.com", "api.test.com"}, main.Hosts)
+	assert.Equal(t, &models.IngressBackendInfo{Service: "fallback", Port: "8080"}, main.DefaultBackend)
+	assert.Equal(t, []models.IngressTLSInfo{{Hosts: []string{"test.com"}, SecretName: "test-tls"}}, main.TLS)
+	assert.Equal(t, []string{"203.0.113.10", "lb.example.com"}, main.Addresses)
+	assert.Len(t, main.Rules, 3)
+	assert.Equal(t, []models.IngressPathInfo{
+		{Path: "/", PathType: "Prefix", Backend: models.IngressBackendInfo{Service: "web", Port: "http"}},
+		{Path: "/assets", PathType: "Exact", Backend: models.IngressBackendInfo{Resource: "storage.example.com/Bucket/static"}},
+	}, main.Rules[0].Paths)
+	assert.Equal(t, []models.IngressPathInfo{
+		{Path: "/v1", Backend: models.IngressBackendInfo{Service: "api", Port: "9000"}},
+	}, main.Rules[1].Paths)
+	assert.Empty(t, main.Rules[2].Paths)
+}
+
+func TestIngressBackendInfo_NilSafety(t *testing.T) {
+	assert.NotPanics(t, func() {
+		assert.Equal(t, models.IngressBackendInfo{}, ingressBackendInfo(networkingv1.IngressBackend{}))
+	})
+	assert.NotPanics(t, func() {
+		info := ingressBackendInfo(networkingv1.IngressBackend{Resource: &v1.TypedLocalObjectReference{Kind: "Bucket", Name: "b"}})
+		assert.Equal(t, "Bucket/b", info.Resource)
+	})
 }
 
 func TestProcessNodeInfo(t *testing.T) {
@@ -168,18 +297,239 @@ func TestProcessNodeInfo(t *testing.T) {
 		Usage:      v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(250, resource.DecimalSI)},
 	}}}
 
-	nodeInfo := processNodeInfo(nodes, nil, nodeMetrics)
+	nodeInfo := processNodeInfo(nodes, nil, nodeMetrics, nodeLabelConfig{})
 	assert.Len(t, nodeInfo, 1)
 	assert.Equal(t, "Control-Plane", nodeInfo[0].Role)
+	assert.Equal(t, []string{"control-plane"}, nodeInfo[0].Roles)
+	assert.Equal(t, unpooledNodePool, nodeInfo[0].Pool)
 	assert.InDelta(t, 25.0, nodeInfo[0].CPUUsagePercentage, 0.01)
 }
 
+func TestProcessNamespaceInfo(t *testing.T) {
+	namespaces := &v1.NamespaceList{Items: []v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app-ns", Labels: map[string]string{"team": "a"}}, Status: v1.NamespaceStatus{Phase: v1.NamespaceActive}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}}
+	pods := &v1.PodList{Items: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "app-ns"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "app-ns"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	}}
+	podMetrics := &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "app-ns"},
+		Containers: []metricsv1beta1.ContainerMetrics{{Usage: v1.ResourceList{
+			v1.ResourceCPU:    *resource.NewMilliQuantity(150, resource.DecimalSI),
+			v1.ResourceMemory: *resource.NewQuantity(64*1024*1024, resource.BinarySI),
+		}}},
+	}}}
+	quotas := &v1.ResourceQuotaList{Items: []v1.ResourceQuota{{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "app-ns"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{v1.ResourcePods: resource.MustParse("10")},
+			Used: v1.ResourceList{v1.ResourcePods: resource.MustParse("2")},
+		},
+	}}}
+	limitRanges := &v1.LimitRangeList{Items: []v1.LimitRange{{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "app-ns"},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{{
+			Type:    v1.LimitTypeContainer,
+			Default: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+		}}},
+	}}}
+	events := &v1.EventList{Items: []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns"}, Type: v1.EventTypeWarning, Reason: "BackOff"},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app-ns"}, Type: v1.EventTypeNormal, Reason: "Scheduled"},
+	}}
+	_, userNamespaces := processNamespaces(namespaces)
+
+	namespaceInfo := processNamespaceInfo(namespaces, pods, podMetrics, quotas, limitRanges, events, userNamespaces)
+	assert.Len(t, namespaceInfo, 1)
+	info := namespaceInfo[0]
+	assert.Equal(t, "app-ns", info.Name)
+	assert.Equal(t, "Active", info.Phase)
+	assert.Equal(t, "a", info.Labels["team"])
+	assert.Equal(t, 2, info.PodCount)
+	assert.Equal(t, 1, info.PodsByStatus["Running"])
+	assert.Equal(t, 1, info.PodsByStatus["Pending"])
+	assert.Equal(t, int64(150), info.UsedCPUMilli)
+	assert.Equal(t, int64(64*1024*1024), info.UsedMemoryBytes)
+	assert.Len(t, info.Quotas, 1)
+	assert.InDelta(t, 20.0, info.Quotas[0].Resources[0].Percentage, 0.01)
+	assert.Len(t, info.LimitRanges, 1)
+	assert.Equal(t, "500m", info.LimitRanges[0].Limits[0].Default)
+	assert.Len(t, info.WarningEvents, 1)
+	assert.Equal(t, "BackOff", info.WarningEvents[0].Reason)
+}
+
+func TestProcessQuotaAlerts(t *testing.T) {
+	quotas := []models.ResourceQuotaInfo{{
+		Name:      "compute",
+		Namespace: "app-ns",
+		Resources: []models.QuotaResourceUsage{
+			{Resource: "pods", Hard: "10", Used: "9", Percentage: 90},
+			{Resource: "requests.cpu", Hard: "4", Used: "1", Percentage: 25},
+			{Resource: "requests.memory", Hard: "8Gi", Used: "8Gi", Percentage: 100},
+		},
+	}}
+
+	alerts := processQuotaAlerts(quotas, 80)
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "requests.memory", alerts[0].Resource)
+	assert.Equal(t, "pods", alerts[1].Resource)
+	assert.Equal(t, "compute", alerts[1].Quota)
+}
+
+func TestProcessPvcs(t *testing.T) {
+	storageClass := "fast"
+	filesystem := v1.PersistentVolumeFilesystem
+	pvcs := &v1.PersistentVolumeClaimList{Items: []v1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "app-ns"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			VolumeName:       "pv-1",
+			VolumeMode:       &filesystem,
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources:        v1.VolumeResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")}},
+		},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:    v1.ClaimBound,
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("20Gi")},
+		},
+	}}}
+	pvs := &v1.PersistentVolumeList{Items: []v1.PersistentVolume{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+		Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain},
+	}}}
+	pods := &v1.PodList{Items: []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "app-ns"},
+		Spec: v1.PodSpec{Volumes: []v1.Volume{{
+			Name:         "data",
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"}},
+		}}},
+	}}}
+
+	pvcInfo := processPvcs(pvcs, pvs, pods, map[string]bool{"app-ns": true})
+	assert.Len(t, pvcInfo, 1)
+	assert.Equal(t, "10Gi", pvcInfo[0].Capacity)
+	assert.Equal(t, "20Gi", pvcInfo[0].BoundCapacity)
+	assert.Equal(t, "pv-1", pvcInfo[0].VolumeName)
+	assert.Equal(t, "fast", pvcInfo[0].StorageClass)
+	assert.Equal(t, "Filesystem", pvcInfo[0].VolumeMode)
+	assert.Equal(t, []string{"ReadWriteOnce"}, pvcInfo[0].AccessModes)
+	assert.Equal(t, "Retain", pvcInfo[0].ReclaimPolicy)
+	assert.Equal(t, []string{"db-0"}, pvcInfo[0].UsedBy)
+}
+
+func TestProcessPersistentVolumesAndStorageClasses(t *testing.T) {
+	pvs := &v1.PersistentVolumeList{Items: []v1.PersistentVolume{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName: "fast",
+			ClaimRef:         &v1.ObjectReference{Namespace: "app-ns", Name: "data-db-0"},
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com"},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}}}
+	storageClasses := &storagev1.StorageClassList{Items: []storagev1.StorageClass{{
+		ObjectMeta:  metav1.ObjectMeta{Name: "fast", Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}},
+		Provisioner: "ebs.csi.aws.com",
+	}}}
+
+	pvInfo := processPersistentVolumes(pvs)
+	assert.Len(t, pvInfo, 1)
+	assert.Equal(t, "app-ns/data-db-0", pvInfo[0].Claim)
+	assert.Equal(t, "csi:ebs.csi.aws.com", pvInfo[0].Source)
+
+	scInfo := processStorageClasses(storageClasses, pvs)
+	assert.Len(t, scInfo, 1)
+	assert.True(t, scInfo[0].IsDefault)
+	assert.Equal(t, 1, scInfo[0].VolumeCount)
+}
+
+func TestProcessNodeInfo_StatusAndSystemInfo(t *testing.T) {
+	nodes := &v1.NodeList{Items: []v1.Node{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node-1",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-72 * time.Hour)),
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":              "us-east-1a",
+				"failure-domain.beta.kubernetes.io/region": "us-east-1",
+			},
+		},
+		Spec: v1.NodeSpec{
+			Unschedulable: true,
+			Taints:        []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourcePods: resource.MustParse("110")},
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse},
+			},
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: "10.0.0.5"},
+				{Type: v1.NodeExternalIP, Address: "54.1.2.3"},
+				{Type: v1.NodeHostName, Address: "ip-10-0-0-5"},
+			},
+			NodeInfo: v1.NodeSystemInfo{
+				KubeletVersion:          "v1.33.1",
+				KernelVersion:           "6.1.0",
+				ContainerRuntimeVersion: "containerd://1.7.2",
+				OSImage:                 "Ubuntu 22.04",
+				OperatingSystem:         "linux",
+				Architecture:            "arm64",
+			},
+		},
+	}}}
+	pods := &v1.PodList{Items: []v1.Pod{
+		{Spec: v1.PodSpec{NodeName: "node-1"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{Spec: v1.PodSpec{NodeName: "node-1"}, Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+	}}
+
+	nodeInfo := processNodeInfo(nodes, pods, nil, nodeLabelConfig{})
+	assert.Len(t, nodeInfo, 1)
+	info := nodeInfo[0]
+	assert.True(t, info.Ready)
+	assert.True(t, info.MemoryPressure)
+	assert.False(t, info.DiskPressure)
+	assert.True(t, info.Unschedulable)
+	assert.Len(t, info.Conditions, 3)
+	assert.Equal(t, []models.TaintInfo{{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"}}, info.Taints)
+	assert.Equal(t, "v1.33.1", info.KubeletVersion)
+	assert.Equal(t, "containerd://1.7.2", info.ContainerRuntime)
+	assert.Equal(t, "arm64", info.Architecture)
+	assert.Equal(t, "3d", info.Age)
+	assert.Equal(t, []string{"10.0.0.5"}, info.InternalIPs)
+	assert.Equal(t, []string{"54.1.2.3"}, info.ExternalIPs)
+	assert.Equal(t, "ip-10-0-0-5", info.Hostname)
+	assert.Equal(t, "us-east-1a", info.Zone)
+	assert.Equal(t, "us-east-1", info.Region)
+	assert.Equal(t, int64(110), info.AllocatablePods)
+	assert.Equal(t, 2, info.PodCount)
+	assert.Equal(t, 1, info.RunningPods)
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "30s", formatAge(30*time.Second))
+	assert.Equal(t, "5m", formatAge(5*time.Minute))
+	assert.Equal(t, "2h", formatAge(150*time.Minute))
+	assert.Equal(t, "10d", formatAge(240*time.Hour))
+}
+
 // TestProcessFunctions_NilInput testa se as funções de processamento lidam com entradas nulas sem pânico.
 func TestProcessFunctions_NilInput(t *testing.T) {
-	assert.NotPanics(t, func() { processNodeInfo(nil, nil, nil) })
+	assert.NotPanics(t, func() { processNodeInfo(nil, nil, nil, nodeLabelConfig{}) })
 	assert.NotPanics(t, func() { processPodInfo(nil, nil, nil) })
-	assert.NotPanics(t, func() { processServiceInfo(nil, nil) })
-	assert.NotPanics(t, func() { processIngressInfo(nil, nil) })
-	assert.NotPanics(t, func() { processPvcs(nil, nil) })
-	assert.NotPanics(t, func() { processEvents(nil, nil) })
+	assert.NotPanics(t, func() { processServiceInfo(nil, nil, nil) })
+	assert.NotPanics(t, func() { processIngressInfo(nil, nil, nil) })
+	assert.NotPanics(t, func() { processPvcs(nil, nil, nil, nil) })
+	assert.NotPanics(t, func() { processPersistentVolumes(nil) })
+	assert.NotPanics(t, func() { processStorageClasses(nil, nil) })
+	assert.NotPanics(t, func() { processEvents(nil, nil, eventinfo.Filter{}) })
+	assert.NotPanics(t, func() { processNamespaceInfo(nil, nil, nil, nil, nil, nil, nil) })
+	assert.NotPanics(t, func() { processResourceQuotas(nil, nil) })
+	assert.NotPanics(t, func() { processLimitRanges(nil, nil) })
 }
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"kubeowl/internal/i18n"
+	"kubeowl/internal/listquery"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// errDynamicClientUnavailable indica que o serviço foi criado sem cliente dinâmico.
+var errDynamicClientUnavailable = errors.New("cliente dinâmico indisponível")
+
+// ErrNamespaceRequired indica que um recurso com namespace foi pedido sem o parâmetro namespace.
+var ErrNamespaceRequired = i18n.Errorf(i18n.MsgInvalidParameter, "namespace", "")
+
+// crdResource é o recurso dos CustomResourceDefinitions, lido via cliente dinâmico para não exigir os tipos do apiextensions.
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// GetAPIResources lista, via discovery, todos os tipos de recurso listáveis do cluster na versão preferida,
+// marcando os que são definidos por CRDs.
+func (s *k8sService) GetAPIResources(ctx context.Context) ([]models.APIResourceInfo, error) {
+	resourceLists, err := discovery.ServerPreferredResources(s.clientset.Discovery())
+	if err != nil {
+		// Grupos indisponíveis (ex.: metrics-server fora do ar) não impedem a listagem dos demais.
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		slog.WarnContext(ctx, "discovery parcial dos recursos da API", logging.Err(err))
+	}
+
+	customResources := map[string]bool{}
+	if s.dynamicClient != nil {
+		if crds, err := s.dynamicClient.Resource(crdResource).List(ctx, metav1.ListOptions{}); err == nil {
+			for _, crd := range crds.Items {
+				customResources[crd.GetName()] = true
+			}
+		}
+	}
+
+	resources := []models.APIResourceInfo{}
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			// Sub-recursos (ex.: pods/log) e recursos sem "list" não fazem sentido no navegador.
+			if strings.Contains(resource.Name, "/") || !hasVerb(resource.Verbs, "list") {
+				continue
+			}
+			resources = append(resources, models.APIResourceInfo{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   resource.Name,
+				Kind:       resource.Kind,
+				Namespaced: resource.Namespaced,
+				Custom:     customResources[resource.Name+"."+gv.Group],
+			})
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Group == resources[j].Group {
+			return resources[i].Resource < resources[j].Resource
+		}
+		return resources[i].Group < resources[j].Group
+	})
+	return resources, nil
+}
+
+func hasVerb(verbs metav1.Verbs, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupAPIResource confirma via discovery que o recurso existe e informa se é namespaced.
+func (s *k8sService) lookupAPIResource(group, version, resource string) (models.APIResourceInfo, error) {
+	gv := schema.GroupVersion{Group: group, Version: version}
+	list, err := s.clientset.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return models.APIResourceInfo{}, err
+	}
+	for _, r := range list.APIResources {
+		if r.Name == resource {
+			return models.APIResourceInfo{Group: group, Version: version, Resource: resource, Kind: r.Kind, Namespaced: r.Namespaced}, nil
+		}
+	}
+	return models.APIResourceInfo{}, apierrors.NewNotFound(schema.GroupResource{Group: group, Resource: resource}, "")
+}
+
+// printerColumns busca o additionalPrinterColumns da versão informada no CRD do recurso e indica se o
+// recurso é definido por um CRD. Recursos nativos retornam nenhuma coluna adicional.
+func (s *k8sService) printerColumns(ctx context.Context, group, version, resource string) ([]models.PrinterColumn, bool) {
+	columns := []models.PrinterColumn{}
+	crd, err := s.dynamicClient.Resource(crdResource).Get(ctx, resource+"."+group, metav1.GetOptions{})
+	if err != nil {
+		return columns, false
+	}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		versionMap, ok := v.(map[string]interface{})
+		if !ok || versionMap["name"] != version {
+			continue
+		}
+		rawColumns, _, _ := unstructured.NestedSlice(versionMap, "additionalPrinterColumns")
+		for _, c := range rawColumns {
+			column, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(column, "name")
+			columnType, _, _ := unstructured.NestedString(column, "type")
+			path, _, _ := unstructured.NestedString(column, "jsonPath")
+			description, _, _ := unstructured.NestedString(column, "description")
+			// A coluna Age já é exibida para todos os recursos.
+			if path == ".metadata.creationTimestamp" {
+				continue
+			}
+			columns = append(columns, models.PrinterColumn{Name: name, Type: columnType, JSONPath: path, Description: description})
+		}
+	}
+	return columns, true
+}
+
+// evaluatePrinterColumn aplica o JSONPath de uma coluna ao objeto e formata o resultado conforme o tipo.
+func evaluatePrinterColumn(obj map[string]interface{}, column models.PrinterColumn) string {
+	parser := jsonpath.New(column.Name).AllowMissingKeys(true)
+	if err := parser.Parse("{" + column.JSONPath + "}"); err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := parser.Execute(&buf, obj); err != nil {
+		return ""
+	}
+	value := buf.String()
+	if column.Type == "date" && value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return formatAge(time.Since(t))
+		}
+	}
+	return value
+}
+
+// GetResourceList lista os objetos de qualquer tipo de recurso com as colunas do CRD.
+// Um namespace vazio retorna todos os namespaces; recursos de escopo de cluster ignoram o namespace.
+func (s *k8sService) GetResourceList(ctx context.Context, group, version, resource, namespace string) (*models.ResourceListInfo, error) {
+	if s.dynamicClient == nil {
+		return nil, errDynamicClientUnavailable
+	}
+	apiResource, err := s.lookupAPIResource(group, version, resource)
+	if err != nil {
+		return nil, err
+	}
+	columns, custom := s.printerColumns(ctx, group, version, resource)
+	apiResource.Custom = custom
+
+	gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+	var list *unstructured.UnstructuredList
+	if apiResource.Namespaced {
+		list, err = s.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, listquery.ListOptions(ctx))
+	} else {
+		list, err = s.dynamicClient.Resource(gvr).List(ctx, listquery.ListOptions(ctx))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var userNamespaces map[string]bool
+	if apiResource.Namespaced {
+		namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		_, userNamespaces = processNamespaces(namespaces)
+	}
+
+	info := &models.ResourceListInfo{Resource: apiResource, Columns: columns, Items: []models.ResourceRow{}}
+	for _, item := range list.Items {
+		if apiResource.Namespaced && !userNamespaces[item.GetNamespace()] {
+			continue
+		}
+		created := item.GetCreationTimestamp()
+		row := models.ResourceRow{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+			CreatedAt: created.Format(time.RFC3339),
+			Age:       formatAge(time.Since(created.Time)),
+			Cells:     make([]string, 0, len(columns)),
+		}
+		for _, column := range columns {
+			row.Cells = append(row.Cells, evaluatePrinterColumn(item.Object, column))
+		}
+		info.Items = append(info.Items, row)
+	}
+	sort.Slice(info.Items, func(i, j int) bool {
+		if info.Items[i].Namespace == info.Items[j].Namespace {
+			return info.Items[i].Name < info.Items[j].Name
+		}
+		return info.Items[i].Namespace < info.Items[j].Namespace
+	})
+	return info, nil
+}
+
+// GetResourceDetail retorna um objeto completo de qualquer tipo de recurso. Os valores de Secrets são
+// sempre removidos: a revelação só é possível pela rota de Secrets, que é controlada e auditada.
+func (s *k8sService) GetResourceDetail(ctx context.Context, group, version, resource, namespace, name string) (map[string]interface{}, error) {
+	if s.dynamicClient == nil {
+		return nil, errDynamicClientUnavailable
+	}
+	apiResource, err := s.lookupAPIResource(group, version, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
+	var obj *unstructured.Unstructured
+	if apiResource.Namespaced {
+		if namespace == "" {
+			return nil, ErrNamespaceRequired
+		}
+		if IsSystemNamespace(namespace) {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Group: group, Resource: resource}, name)
+		}
+		obj, err = s.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = s.dynamicClient.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if group == "" && resource == "secrets" {
+		redactSecretObject(obj.Object)
+	}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	return obj.Object, nil
+}
+
+// redactSecretObject substitui os valores de data e stringData de um Secret não tipado, mantendo as chaves.
+func redactSecretObject(obj map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		values, found, _ := unstructured.NestedMap(obj, field)
+		if !found {
+			continue
+		}
+		for key := range values {
+			values[key] = "<redacted>"
+		}
+		_ = unstructured.SetNestedMap(obj, values, field)
+	}
+	// A anotação do kubectl apply pode conter uma cópia dos valores.
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+}
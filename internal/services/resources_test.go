@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+var (
+	certificatesGVR   = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	clusterIssuersGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers"}
+	secretsGVR        = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+func newResourcesTestService(t *testing.T) Service {
+	clientset := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	)
+	listVerbs := metav1.Verbs{"get", "list", "watch"}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: listVerbs},
+			{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: metav1.Verbs{"get"}},
+			{Name: "bindings", Namespaced: true, Kind: "Binding", Verbs: metav1.Verbs{"create"}},
+		}},
+		{GroupVersion: "cert-manager.io/v1", APIResources: []metav1.APIResource{
+			{Name: "certificates", Namespaced: true, Kind: "Certificate", Verbs: listVerbs},
+			{Name: "clusterissuers", Namespaced: false, Kind: "ClusterIssuer", Verbs: listVerbs},
+		}},
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		crdResource:       "CustomResourceDefinitionList",
+		certificatesGVR:   "CertificateList",
+		clusterIssuersGVR: "ClusterIssuerList",
+		secretsGVR:        "SecretList",
+	})
+	objects := []struct {
+		gvr schema.GroupVersionResource
+		obj map[string]interface{}
+	}{
+		{crdResource, map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition",
+			"metadata": map[string]interface{}{"name": "certificates.cert-manager.io"},
+			"spec": map[string]interface{}{"versions": []interface{}{map[string]interface{}{
+				"name": "v1",
+				"additionalPrinterColumns": []interface{}{
+					map[string]interface{}{"name": "Ready", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Ready")].status`},
+					map[string]interface{}{"name": "Secret", "type": "string", "jsonPath": ".spec.secretName"},
+					map[string]interface{}{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+				},
+			}}},
+		}},
+		{crdResource, map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1", "kind": "CustomResourceDefinition",
+			"metadata": map[string]interface{}{"name": "clusterissuers.cert-manager.io"},
+			"spec":     map[string]interface{}{"versions": []interface{}{map[string]interface{}{"name": "v1"}}},
+		}},
+		{certificatesGVR, map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			"metadata": map[string]interface{}{"name": "web-tls", "namespace": "app"},
+			"spec":     map[string]interface{}{"secretName": "web-tls-secret"},
+			"status":   map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}}},
+		}},
+		{certificatesGVR, map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			"metadata": map[string]interface{}{"name": "webhook", "namespace": "kube-system"},
+		}},
+		{clusterIssuersGVR, map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1", "kind": "ClusterIssuer",
+			"metadata": map[string]interface{}{"name": "letsencrypt"},
+		}},
+		{secretsGVR, map[string]interface{}{
+			"apiVersion": "v1", "kind": "Secret",
+			"metadata": map[string]interface{}{"name": "db", "namespace": "app", "annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"c2VjcmV0"}}`,
+			}},
+			"data": map[string]interface{}{"password": "c2VjcmV0"},
+		}},
+	}
+	for _, o := range objects {
+		obj := &unstructured.Unstructured{Object: o.obj}
+		if err := dynamicClient.Tracker().Create(o.gvr, obj, obj.GetNamespace()); err != nil {
+			t.Fatalf("falha ao registrar %s: %v", obj.GetName(), err)
+		}
+	}
+	return NewK8sService(clientset, metricsfake.NewSimpleClientset(), WithDynamicClient(dynamicClient))
+}
+
+func TestGetAPIResources(t *testing.T) {
+	resources, err := newResourcesTestService(t).GetAPIResources(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.APIResourceInfo{
+		{Group: "", Version: "v1", Resource: "secrets", Kind: "Secret", Namespaced: true},
+		{Group: "cert-manager.io", Version: "v1", Resource: "certificates", Kind: "Certificate", Namespaced: true, Custom: true},
+		{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers", Kind: "ClusterIssuer", Custom: true},
+	}, resources)
+}
+
+func TestGetResourceList(t *testing.T) {
+	service := newResourcesTestService(t)
+
+	list, err := service.GetResourceList(context.Background(), "cert-manager.io", "v1", "certificates", "")
+	assert.NoError(t, err)
+	assert.True(t, list.Resource.Custom)
+	assert.True(t, list.Resource.Namespaced)
+	assert.Equal(t, []string{"Ready", "Secret"}, []string{list.Columns[0].Name, list.Columns[1].Name})
+	if assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "web-tls", list.Items[0].Name)
+		assert.Equal(t, []string{"True", "web-tls-secret"}, list.Items[0].Cells)
+	}
+
+	clusterScoped, err := service.GetResourceList(context.Background(), "cert-manager.io", "v1", "clusterissuers", "app")
+	assert.NoError(t, err)
+	assert.False(t, clusterScoped.Resource.Namespaced)
+	assert.Empty(t, clusterScoped.Columns)
+	assert.Len(t, clusterScoped.Items, 1)
+
+	_, err = service.GetResourceList(context.Background(), "cert-manager.io", "v1", "issuers", "")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestGetResourceDetail(t *testing.T) {
+	service := newResourcesTestService(t)
+
+	certificate, err := service.GetResourceDetail(context.Background(), "cert-manager.io", "v1", "certificates", "app", "web-tls")
+	assert.NoError(t, err)
+	assert.Equal(t, "Certificate", certificate["kind"])
+
+	secret, err := service.GetResourceDetail(context.Background(), "", "v1", "secrets", "app", "db")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "<redacted>"}, secret["data"])
+	annotations, _, _ := unstructured.NestedStringMap(secret, "metadata", "annotations")
+	assert.NotContains(t, annotations, "kubectl.kubernetes.io/last-applied-configuration")
+
+	_, err = service.GetResourceDetail(context.Background(), "cert-manager.io", "v1", "certificates", "", "web-tls")
+	assert.ErrorIs(t, err, ErrNamespaceRequired)
+
+	_, err = service.GetResourceDetail(context.Background(), "cert-manager.io", "v1", "certificates", "kube-system", "webhook")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	withoutDynamic := NewK8sService(fake.NewSimpleClientset(), metricsfake.NewSimpleClientset())
+	_, err = withoutDynamic.GetResourceDetail(context.Background(), "", "v1", "secrets", "app", "db")
+	assert.ErrorIs(t, err, errDynamicClientUnavailable)
+}
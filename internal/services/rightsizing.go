@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/models"
+	"math"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MinRecommendationSamples é a quantidade mínima de amostras para que um contêiner receba recomendação.
+	MinRecommendationSamples = 10
+	// recommendationMargin é a folga somada ao p95 do uso no request recomendado.
+	recommendationMargin = 0.15
+	// limitHeadroom é a folga somada ao pico de uso no limit recomendado.
+	limitHeadroom = 0.3
+	// overProvisionedRatio: um request é superdimensionado quando a recomendação fica abaixo desta fração dele.
+	overProvisionedRatio = 0.7
+	// memoryLimitRiskRatio: um pico de memória acima desta fração do limit indica risco de OOMKill.
+	memoryLimitRiskRatio = 0.9
+
+	minCPURecommendationMilli    = 10
+	cpuRecommendationStepMilli   = 5
+	minMemoryRecommendationBytes = 16 * mebibyte
+	mebibyte                     = 1024 * 1024
+)
+
+// rightsizingRank ordena as classificações da mais urgente para a menos urgente.
+var rightsizingRank = map[string]int{
+	models.RightsizingUnderProvisioned: 0,
+	models.RightsizingOverProvisioned:  1,
+	models.RightsizingNoRequest:        2,
+	models.RightsizingOK:               3,
+	models.RightsizingInsufficientData: 4,
+}
+
+// WithUsageHistory define o histórico de uso usado nas recomendações de dimensionamento. Sem ele, as
+// recomendações usam apenas a amostra atual do metrics-server e ficam sem dados suficientes.
+func WithUsageHistory(history *UsageHistory) Option {
+	return func(s *k8sService) {
+		s.usageHistory = history
+	}
+}
+
+// GetRightsizingReport compara o uso observado de cada contêiner com os requests e limits e sugere novos valores,
+// agrupados pelo workload dono dos pods. Um namespace vazio retorna todos os namespaces.
+func (s *k8sService) GetRightsizingReport(ctx context.Context, namespace string) (*models.RightsizingReport, error) {
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, _ := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	_, userNamespaces := processNamespaces(namespaces)
+
+	history := s.usageHistory
+	if history == nil {
+		history = NewUsageHistory()
+		podMetrics, _ := s.metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+		history.record(pods, replicaSets, podMetrics)
+	}
+	report := processRightsizing(pods, replicaSets, userNamespaces, history)
+	return &report, nil
+}
+
+// processRightsizing monta as recomendações dos workloads com pods ativos, usando o spec do pod mais recente
+// de cada workload como configuração atual.
+func processRightsizing(pods *v1.PodList, replicaSets *appsv1.ReplicaSetList, userNamespaces map[string]bool, history *UsageHistory) models.RightsizingReport {
+	report := models.RightsizingReport{
+		Window:     history.Window().String(),
+		MinSamples: MinRecommendationSamples,
+		Workloads:  []models.WorkloadRecommendation{},
+	}
+	if pods == nil {
+		return report
+	}
+	replicaSetsByName := map[string]appsv1.ReplicaSet{}
+	if replicaSets != nil {
+		for _, rs := range replicaSets.Items {
+			replicaSetsByName[rs.Namespace+"/"+rs.Name] = rs
+		}
+	}
+
+	latest := map[workloadRef]v1.Pod{}
+	podCount := map[workloadRef]int{}
+	var workloads []workloadRef
+	for _, pod := range pods.Items {
+		if !userNamespaces[pod.Namespace] || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		ref := podWorkloadRef(pod, replicaSetsByName)
+		current, ok := latest[ref]
+		if !ok {
+			workloads = append(workloads, ref)
+		}
+		if !ok || current.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest[ref] = pod
+		}
+		podCount[ref]++
+	}
+
+	for _, ref := range workloads {
+		recommendation := models.WorkloadRecommendation{
+			Kind: ref.kind, Namespace: ref.namespace, Name: ref.name, Pods: podCount[ref],
+			Status: models.RightsizingInsufficientData, Containers: []models.ContainerRecommendation{},
+		}
+		for _, container := range latest[ref].Spec.Containers {
+			samples := history.samples(usageKey{workload: ref, container: container.Name})
+			containerRecommendation := recommendContainer(container, samples, history.now())
+			recommendation.Containers = append(recommendation.Containers, containerRecommendation)
+			for _, status := range []string{containerRecommendation.CPU.Status, containerRecommendation.Memory.Status} {
+				if rightsizingRank[status] < rightsizingRank[recommendation.Status] {
+					recommendation.Status = status
+				}
+			}
+		}
+		report.Workloads = append(report.Workloads, recommendation)
+	}
+
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		a, b := report.Workloads[i], report.Workloads[j]
+		return models.CompareRanked(rightsizingRank[a.Status], rightsizingRank[b.Status],
+			[]string{a.Namespace, a.Kind, a.Name}, []string{b.Namespace, b.Kind, b.Name}) < 0
+	})
+	return report
+}
+
+// recommendContainer calcula as recomendações de CPU e memória de um contêiner.
+func recommendContainer(container v1.Container, samples []usageSample, now time.Time) models.ContainerRecommendation {
+	cpu := make([]int64, 0, len(samples))
+	memory := make([]int64, 0, len(samples))
+	for _, sample := range samples {
+		cpu = append(cpu, sample.cpuMilli)
+		memory = append(memory, sample.memoryBytes)
+	}
+	requests, limits := container.Resources.Requests, container.Resources.Limits
+	recommendation := models.ContainerRecommendation{
+		Container: container.Name,
+		Samples:   len(samples),
+		CPU:       recommendCPU(cpu, requests.Cpu().MilliValue(), limits.Cpu().MilliValue()),
+		Memory:    recommendMemory(memory, requests.Memory().Value(), limits.Memory().Value()),
+	}
+	if len(samples) > 0 {
+		recommendation.ObservedWindow = now.Sub(samples[0].time).Round(time.Second).String()
+	}
+	return recommendation
+}
+
+// recommendCPU sugere o request como o p95 do uso mais a margem. O limit só é sugerido quando já existe,
+// já que limitar CPU causa throttling e muitos clusters optam por não defini-lo.
+func recommendCPU(values []int64, request, limit int64) models.ResourceRecommendation {
+	rec := usageStats(values, request, limit)
+	if len(values) < MinRecommendationSamples {
+		rec.Status = models.RightsizingInsufficientData
+		return rec
+	}
+	recommended := roundUp(int64(math.Ceil(float64(rec.P95)*(1+recommendationMargin))), cpuRecommendationStepMilli)
+	recommended = max(recommended, minCPURecommendationMilli)
+	rec.RecommendedRequest = resource.NewMilliQuantity(recommended, resource.DecimalSI).String()
+	if limit > 0 {
+		recommendedLimit := max(roundUp(int64(math.Ceil(float64(rec.Max)*(1+limitHeadroom))), cpuRecommendationStepMilli), recommended)
+		rec.RecommendedLimit = resource.NewMilliQuantity(recommendedLimit, resource.DecimalSI).String()
+	}
+	rec.Status = classify(rec, recommended, false)
+	return rec
+}
+
+// recommendMemory sugere o request como o p95 do uso mais a margem e o limit como o pico mais a folga,
+// já que estourar o limit de memória encerra o contêiner.
+func recommendMemory(values []int64, request, limit int64) models.ResourceRecommendation {
+	rec := usageStats(values, request, limit)
+	if len(values) < MinRecommendationSamples {
+		rec.Status = models.RightsizingInsufficientData
+		return rec
+	}
+	recommended := roundUp(int64(math.Ceil(float64(rec.P95)*(1+recommendationMargin))), mebibyte)
+	recommended = max(recommended, minMemoryRecommendationBytes)
+	recommendedLimit := max(roundUp(int64(math.Ceil(float64(rec.Max)*(1+limitHeadroom))), mebibyte), recommended)
+	rec.RecommendedRequest = resource.NewQuantity(recommended, resource.BinarySI).String()
+	rec.RecommendedLimit = resource.NewQuantity(recommendedLimit, resource.BinarySI).String()
+	rec.Status = classify(rec, recommended, true)
+	return rec
+}
+
+// usageStats preenche os valores atuais e os percentis do uso observado.
+func usageStats(values []int64, request, limit int64) models.ResourceRecommendation {
+	rec := models.ResourceRecommendation{Request: request, Limit: limit}
+	if len(values) == 0 {
+		return rec
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rec.P50 = percentile(sorted, 0.50)
+	rec.P95 = percentile(sorted, 0.95)
+	rec.Max = sorted[len(sorted)-1]
+	return rec
+}
+
+// classify compara o request atual com o uso: é subdimensionado quando o p95 passa do request (ou, na memória,
+// quando o pico se aproxima do limit) e superdimensionado quando a recomendação fica bem abaixo do request.
+func classify(rec models.ResourceRecommendation, recommended int64, memory bool) string {
+	switch {
+	case rec.Request == 0:
+		return models.RightsizingNoRequest
+	case rec.P95 > rec.Request:
+		return models.RightsizingUnderProvisioned
+	case memory && rec.Limit > 0 && float64(rec.Max) >= float64(rec.Limit)*memoryLimitRiskRatio:
+		return models.RightsizingUnderProvisioned
+	case float64(recommended) < float64(rec.Request)*overProvisionedRatio:
+		return models.RightsizingOverProvisioned
+	default:
+		return models.RightsizingOK
+	}
+}
+
+// percentile retorna o percentil p (0 a 1) de valores já ordenados, pelo método do posto mais próximo.
+func percentile(sorted []int64, p float64) int64 {
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(index, 0), len(sorted)-1)]
+}
+
+// roundUp arredonda value para cima, até o próximo múltiplo de step.
+func roundUp(value, step int64) int64 {
+	return (value + step - 1) / step * step
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kubeowl/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func resourceList(cpu, memory string) v1.ResourceList {
+	list := v1.ResourceList{}
+	if cpu != "" {
+		list[v1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[v1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func rightsizingPod(name, replicaSet string, created time.Time, resources v1.ResourceRequirements) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "app", CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet, Controller: &controller}},
+		},
+		Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "main", Resources: resources}}},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func rightsizingFixtures(now time.Time) []runtime.Object {
+	controller := true
+	replicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "app",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment, Controller: &controller}},
+		}}
+	}
+	return []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		replicaSet("api-old", "api"), replicaSet("api-new", "api"), replicaSet("cache-1", "cache"), replicaSet("batch-1", "batch"),
+		// O pod mais recente define a configuração atual do Deployment api.
+		rightsizingPod("api-old-a", "api-old", now.Add(-2*time.Hour), v1.ResourceRequirements{Requests: resourceList("1", "1Gi")}),
+		rightsizingPod("api-new-a", "api-new", now.Add(-time.Hour), v1.ResourceRequirements{
+			Requests: resourceList("1", "1Gi"), Limits: resourceList("2", "2Gi"),
+		}),
+		rightsizingPod("cache-1-a", "cache-1", now.Add(-time.Hour), v1.ResourceRequirements{
+			Requests: resourceList("100m", "128Mi"), Limits: resourceList("", "256Mi"),
+		}),
+		rightsizingPod("batch-1-a", "batch-1", now.Add(-time.Hour), v1.ResourceRequirements{}),
+	}
+}
+
+func podUsage(name string, at time.Time, cpu, memory string) metricsv1beta1.PodMetrics {
+	return metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+		Timestamp:  metav1.NewTime(at),
+		Containers: []metricsv1beta1.ContainerMetrics{{Name: "main", Usage: resourceList(cpu, memory)}},
+	}
+}
+
+// fillHistory grava uma amostra de cada pod por minuto durante a última hora.
+func fillHistory(t *testing.T, history *UsageHistory, now time.Time) {
+	clientset := fake.NewSimpleClientset(rightsizingFixtures(now)...)
+	pods, err := clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao listar os pods: %v", err)
+	}
+	replicaSets, err := clientset.AppsV1().ReplicaSets("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao listar os ReplicaSets: %v", err)
+	}
+	for i := 60; i > 0; i-- {
+		at := now.Add(-time.Duration(i) * time.Minute)
+		history.now = func() time.Time { return at }
+		// O cache usa quase todo o limit de memória em um pico.
+		cacheMemory := "100Mi"
+		if i == 30 {
+			cacheMemory = "240Mi"
+		}
+		history.record(pods, replicaSets, &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{
+			podUsage("api-old-a", at, "100m", "200Mi"),
+			podUsage("api-new-a", at, "120m", "220Mi"),
+			podUsage("cache-1-a", at, "80m", cacheMemory),
+			podUsage("batch-1-a", at, "300m", "10Mi"),
+		}})
+	}
+	history.now = func() time.Time { return now }
+}
+
+func TestGetRightsizingReport(t *testing.T) {
+	now := time.Now()
+	history := NewUsageHistory()
+	fillHistory(t, history, now)
+	service := NewK8sService(fake.NewSimpleClientset(rightsizingFixtures(now)...), metricsfake.NewSimpleClientset(), WithUsageHistory(history))
+
+	report, err := service.GetRightsizingReport(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "24h0m0s", report.Window)
+	if !assert.Len(t, report.Workloads, 3) {
+		return
+	}
+
+	// Ordenação: subdimensionado, superdimensionado e sem request.
+	cache, api, batch := report.Workloads[0], report.Workloads[1], report.Workloads[2]
+	assert.Equal(t, "cache", cache.Name)
+	assert.Equal(t, models.RightsizingUnderProvisioned, cache.Status)
+	assert.Equal(t, models.RightsizingUnderProvisioned, cache.Containers[0].Memory.Status)
+	assert.Equal(t, "312Mi", cache.Containers[0].Memory.RecommendedLimit)
+	assert.Equal(t, models.RightsizingOK, cache.Containers[0].CPU.Status)
+	assert.Empty(t, cache.Containers[0].CPU.RecommendedLimit, "sem limit de CPU atual, nenhum é sugerido")
+
+	// As duas réplicas, inclusive a antiga, formam uma amostra por coleta com o maior uso entre elas.
+	assert.Equal(t, "Deployment", api.Kind)
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, 2, api.Pods)
+	assert.Equal(t, models.RightsizingOverProvisioned, api.Status)
+	container := api.Containers[0]
+	assert.Equal(t, 60, container.Samples)
+	assert.Equal(t, "1h0m0s", container.ObservedWindow, "a janela efetiva é a idade da amostra mais antiga")
+	assert.Equal(t, int64(1000), container.CPU.Request)
+	assert.Equal(t, int64(2000), container.CPU.Limit, "a configuração vem do pod mais recente")
+	assert.Equal(t, int64(120), container.CPU.P95)
+	assert.Equal(t, int64(120), container.CPU.P50)
+	assert.Equal(t, "140m", container.CPU.RecommendedRequest)
+	assert.Equal(t, "160m", container.CPU.RecommendedLimit)
+	assert.Equal(t, "253Mi", container.Memory.RecommendedRequest)
+	assert.Equal(t, models.RightsizingOverProvisioned, container.Memory.Status)
+
+	assert.Equal(t, models.RightsizingNoRequest, batch.Status)
+	assert.Equal(t, "345m", batch.Containers[0].CPU.RecommendedRequest)
+	assert.Equal(t, "16Mi", batch.Containers[0].Memory.RecommendedRequest, "a recomendação respeita o mínimo")
+}
+
+func TestGetRightsizingReport_WithoutHistory(t *testing.T) {
+	now := time.Now()
+	metrics := metricsfake.NewSimpleClientset()
+	usage := podUsage("api-new-a", now, "120m", "220Mi")
+	if err := metrics.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), &usage, "app"); err != nil {
+		t.Fatalf("falha ao criar as métricas: %v", err)
+	}
+	service := NewK8sService(fake.NewSimpleClientset(rightsizingFixtures(now)...), metrics)
+
+	report, err := service.GetRightsizingReport(context.Background(), "app")
+	assert.NoError(t, err)
+	for _, workload := range report.Workloads {
+		assert.Equal(t, models.RightsizingInsufficientData, workload.Status, workload.Name)
+		if workload.Name == "api" {
+			assert.Equal(t, 1, workload.Containers[0].Samples)
+			assert.Equal(t, int64(120), workload.Containers[0].CPU.P95)
+			assert.Empty(t, workload.Containers[0].CPU.RecommendedRequest)
+		}
+	}
+}
+
+func TestUsageHistory_PrunesAndDeduplicates(t *testing.T) {
+	now := time.Now()
+	history := NewUsageHistory(WithUsageWindow(30*time.Minute), WithUsageMaxSamples(20))
+	fillHistory(t, history, now)
+	key := usageKey{workload: workloadRef{kind: "Deployment", namespace: "app", name: "cache"}, container: "main"}
+	samples := history.samples(key)
+	// A janela de 30 minutos mantém 30 amostras, limitadas a 20.
+	assert.Len(t, samples, 20)
+	assert.Equal(t, now.Add(-20*time.Minute).Unix(), samples[0].time.Unix())
+
+	// Métricas com o mesmo timestamp não geram amostras repetidas.
+	pods := &v1.PodList{Items: []v1.Pod{*rightsizingPod("solo", "", now, v1.ResourceRequirements{})}}
+	pods.Items[0].OwnerReferences = nil
+	metrics := &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{podUsage("solo", now, "10m", "10Mi")}}
+	history.record(pods, nil, metrics)
+	history.record(pods, nil, metrics)
+	assert.Len(t, history.samples(usageKey{workload: workloadRef{kind: "Pod", namespace: "app", name: "solo"}, container: "main"}), 1)
+
+	// Sem novas amostras, a série sai do histórico quando a janela passa.
+	history.now = func() time.Time { return now.Add(time.Hour) }
+	history.record(nil, nil, nil)
+	assert.Empty(t, history.samples(key))
+}
+
+// TestUsageHistory_AggregatesReplicas verifica que réplicas não multiplicam as amostras e, assim, não encurtam a janela.
+func TestUsageHistory_AggregatesReplicas(t *testing.T) {
+	now := time.Now()
+	history := NewUsageHistory(WithUsageMaxSamples(20))
+	pods := &v1.PodList{}
+	for i := 0; i < 50; i++ {
+		pods.Items = append(pods.Items, *rightsizingPod(fmt.Sprintf("api-new-%d", i), "api-new", now.Add(-time.Hour), v1.ResourceRequirements{}))
+	}
+	replicaSets, err := fake.NewSimpleClientset(rightsizingFixtures(now)...).AppsV1().ReplicaSets("app").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao listar os ReplicaSets: %v", err)
+	}
+	for tick := 10; tick > 0; tick-- {
+		at := now.Add(-time.Duration(tick) * time.Minute)
+		history.now = func() time.Time { return at }
+		metrics := &metricsv1beta1.PodMetricsList{}
+		for i, pod := range pods.Items {
+			metrics.Items = append(metrics.Items, podUsage(pod.Name, at, fmt.Sprintf("%dm", 10+i), "64Mi"))
+		}
+		history.record(pods, replicaSets, metrics)
+	}
+
+	samples := history.samples(usageKey{workload: workloadRef{kind: "Deployment", namespace: "app", name: "api"}, container: "main"})
+	if assert.Len(t, samples, 10) {
+		assert.Equal(t, now.Add(-10*time.Minute).Unix(), samples[0].time.Unix())
+		assert.Equal(t, int64(59), samples[0].cpuMilli, "a amostra guarda a réplica mais carregada")
+	}
+}
+
+// TestUsageHistory_StaleReplica verifica que uma réplica ainda não atualizada pelo metrics-server continua no
+// maior uso da coleta, e que uma coleta sem nenhuma réplica atualizada não gera amostra.
+func TestUsageHistory_StaleReplica(t *testing.T) {
+	now := time.Now()
+	history := NewUsageHistory()
+	pods := &v1.PodList{Items: []v1.Pod{
+		*rightsizingPod("api-new-a", "api-new", now.Add(-time.Hour), v1.ResourceRequirements{}),
+		*rightsizingPod("api-new-b", "api-new", now.Add(-time.Hour), v1.ResourceRequirements{}),
+	}}
+	replicaSets, err := fake.NewSimpleClientset(rightsizingFixtures(now)...).AppsV1().ReplicaSets("app").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("falha ao listar os ReplicaSets: %v", err)
+	}
+	first, second := now.Add(-2*time.Minute), now.Add(-time.Minute)
+	collect := func(at time.Time, metrics ...metricsv1beta1.PodMetrics) {
+		history.now = func() time.Time { return at }
+		history.record(pods, replicaSets, &metricsv1beta1.PodMetricsList{Items: metrics})
+	}
+
+	collect(first, podUsage("api-new-a", first, "100m", "64Mi"), podUsage("api-new-b", first, "300m", "64Mi"))
+	// Só a réplica a foi atualizada; a b continua com a métrica anterior, a mais carregada.
+	collect(second, podUsage("api-new-a", second, "150m", "64Mi"), podUsage("api-new-b", first, "300m", "64Mi"))
+	collect(now, podUsage("api-new-a", second, "150m", "64Mi"), podUsage("api-new-b", first, "300m", "64Mi"))
+
+	samples := history.samples(usageKey{workload: workloadRef{kind: "Deployment", namespace: "app", name: "api"}, container: "main"})
+	if assert.Len(t, samples, 2) {
+		assert.Equal(t, []int64{300, 300}, []int64{samples[0].cpuMilli, samples[1].cpuMilli})
+		assert.Equal(t, second.Unix(), samples[1].time.Unix())
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, int64(5), percentile(values, 0.5))
+	assert.Equal(t, int64(10), percentile(values, 0.95))
+	assert.Equal(t, int64(1), percentile(values, 0))
+	assert.Equal(t, int64(7), percentile([]int64{7}, 0.95))
+}
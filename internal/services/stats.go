@@ -0,0 +1,393 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/models"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// nodeStatsConcurrency limita quantos nós são consultados ao mesmo tempo na API de estatísticas do kubelet.
+	nodeStatsConcurrency = 5
+	// nodeStatsTimeout é o tempo máximo de espera pela resposta de cada nó.
+	nodeStatsTimeout = 5 * time.Second
+	// nodeStatsCacheTTL é por quanto tempo o resumo de um nó é reaproveitado sem consultar o kubelet novamente.
+	nodeStatsCacheTTL = 30 * time.Second
+	// nodeStatsMaxAge é a idade a partir da qual um resumo guardado deixa de ser exibido na listagem de nós.
+	nodeStatsMaxAge = 5 * time.Minute
+)
+
+// ErrNodeStatsUnavailable indica que o cliente não permite acessar o proxy dos nós (ex.: clientset falso).
+var ErrNodeStatsUnavailable = errors.New("API de estatísticas do kubelet indisponível")
+
+// statsSummary espelha os campos usados da resposta de /stats/summary do kubelet.
+type statsSummary struct {
+	Node nodeStats  `json:"node"`
+	Pods []podStats `json:"pods"`
+}
+
+type nodeStats struct {
+	NodeName string        `json:"nodeName"`
+	Fs       *fsStats      `json:"fs,omitempty"`
+	Runtime  *runtimeStats `json:"runtime,omitempty"`
+}
+
+type runtimeStats struct {
+	ImageFs     *fsStats `json:"imageFs,omitempty"`
+	ContainerFs *fsStats `json:"containerFs,omitempty"`
+}
+
+type podStats struct {
+	PodRef     podReference     `json:"podRef"`
+	Containers []containerStats `json:"containers"`
+	Volumes    []volumeStats    `json:"volume"`
+}
+
+type podReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type containerStats struct {
+	Name   string   `json:"name"`
+	Rootfs *fsStats `json:"rootfs,omitempty"`
+	Logs   *fsStats `json:"logs,omitempty"`
+}
+
+type volumeStats struct {
+	fsStats
+	Name   string        `json:"name"`
+	PVCRef *podReference `json:"pvcRef,omitempty"`
+}
+
+type fsStats struct {
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+	Inodes         *uint64 `json:"inodes,omitempty"`
+	InodesUsed     *uint64 `json:"inodesUsed,omitempty"`
+}
+
+// NodeStatsFunc busca o /stats/summary bruto de um nó.
+type NodeStatsFunc func(ctx context.Context, nodeName string) ([]byte, error)
+
+// WithNodeStatsFunc substitui a consulta ao kubelet, permitindo servir estatísticas gravadas (ex.: snapshots).
+func WithNodeStatsFunc(f NodeStatsFunc) Option {
+	return func(s *k8sService) {
+		s.nodeStatsFunc = f
+	}
+}
+
+// fetchNodeStatsSummary busca o resumo de estatísticas do kubelet através do proxy do API server.
+func (s *k8sService) fetchNodeStatsSummary(ctx context.Context, nodeName string) ([]byte, error) {
+	return FetchNodeStatsSummary(ctx, s.clientset, nodeName)
+}
+
+// FetchNodeStatsSummary busca o /stats/summary de um nó através do proxy do API server.
+func FetchNodeStatsSummary(ctx context.Context, clientset kubernetes.Interface, nodeName string) ([]byte, error) {
+	restClient := clientset.CoreV1().RESTClient()
+	if c, ok := restClient.(*rest.RESTClient); !ok || c == nil {
+		return nil, ErrNodeStatsUnavailable
+	}
+	return restClient.Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw(ctx)
+}
+
+// nodeStatsCache guarda o último resumo de cada nó, para que consultas próximas não repitam as chamadas ao kubelet
+// e a listagem de nós não precise esperar por elas.
+type nodeStatsCache struct {
+	mu         sync.Mutex
+	entries    map[string]nodeStatsEntry
+	refreshing bool
+}
+
+type nodeStatsEntry struct {
+	summary *statsSummary
+	fetched time.Time
+}
+
+func newNodeStatsCache() *nodeStatsCache {
+	return &nodeStatsCache{entries: map[string]nodeStatsEntry{}}
+}
+
+// get retorna os resumos dos nós informados com no máximo maxAge de idade, além dos nós sem resumo nessa idade.
+func (c *nodeStatsCache) get(nodeNames []string, maxAge time.Duration) (map[string]*statsSummary, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := make(map[string]*statsSummary)
+	var missing []string
+	for _, nodeName := range nodeNames {
+		entry, ok := c.entries[nodeName]
+		if ok && time.Since(entry.fetched) <= maxAge {
+			found[nodeName] = entry.summary
+		} else {
+			missing = append(missing, nodeName)
+		}
+	}
+	return found, missing
+}
+
+func (c *nodeStatsCache) put(nodeName string, summary *statsSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[nodeName] = nodeStatsEntry{summary: summary, fetched: time.Now()}
+}
+
+// cachedNodeStats retorna os resumos guardados sem consultar os kubelets. Quando algum está ausente ou vencido,
+// uma atualização é disparada em segundo plano e aparece nas consultas seguintes.
+func (s *k8sService) cachedNodeStats(ctx context.Context, nodeNames []string) map[string]*statsSummary {
+	stats, _ := s.nodeStatsCache.get(nodeNames, nodeStatsMaxAge)
+	if _, stale := s.nodeStatsCache.get(nodeNames, nodeStatsCacheTTL); len(stale) > 0 {
+		s.nodeStatsCache.mu.Lock()
+		start := !s.nodeStatsCache.refreshing
+		s.nodeStatsCache.refreshing = true
+		s.nodeStatsCache.mu.Unlock()
+		if start {
+			go func() {
+				defer func() {
+					s.nodeStatsCache.mu.Lock()
+					s.nodeStatsCache.refreshing = false
+					s.nodeStatsCache.mu.Unlock()
+				}()
+				s.collectNodeStats(context.WithoutCancel(ctx), stale)
+			}()
+		}
+	}
+	return stats
+}
+
+// collectNodeStats consulta /stats/summary dos nós informados em paralelo, respeitando o limite de
+// concorrência e o timeout por nó. Resumos com menos de nodeStatsCacheTTL são reaproveitados; nós que
+// falham são omitidos do resultado.
+func (s *k8sService) collectNodeStats(ctx context.Context, nodeNames []string) map[string]*statsSummary {
+	results, missing := s.nodeStatsCache.get(nodeNames, nodeStatsCacheTTL)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, nodeStatsConcurrency)
+
+	for _, nodeName := range missing {
+		wg.Add(1)
+		go func(nodeName string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			nodeCtx, cancel := context.WithTimeout(ctx, nodeStatsTimeout)
+			defer cancel()
+
+			raw, err := s.nodeStatsFunc(nodeCtx, nodeName)
+			if err != nil {
+				if !errors.Is(err, ErrNodeStatsUnavailable) {
+					slog.WarnContext(ctx, "falha ao buscar estatísticas do nó", "node", nodeName, logging.Err(err))
+				}
+				return
+			}
+			var summary statsSummary
+			if err := json.Unmarshal(raw, &summary); err != nil {
+				slog.WarnContext(ctx, "falha ao decodificar estatísticas do nó", "node", nodeName, logging.Err(err))
+				return
+			}
+
+			s.nodeStatsCache.put(nodeName, &summary)
+			mu.Lock()
+			results[nodeName] = &summary
+			mu.Unlock()
+		}(nodeName)
+	}
+	wg.Wait()
+	return results
+}
+
+// toFilesystemUsage converte as estatísticas do kubelet para o modelo exibido, ou nil se não houver dados.
+func toFilesystemUsage(fs *fsStats) *models.FilesystemUsage {
+	if fs == nil || fs.UsedBytes == nil {
+		return nil
+	}
+	value := func(v *uint64) int64 {
+		if v == nil {
+			return 0
+		}
+		return int64(*v)
+	}
+	usage := &models.FilesystemUsage{
+		UsedBytes:      value(fs.UsedBytes),
+		CapacityBytes:  value(fs.CapacityBytes),
+		AvailableBytes: value(fs.AvailableBytes),
+		InodesUsed:     value(fs.InodesUsed),
+		Inodes:         value(fs.Inodes),
+	}
+	if usage.CapacityBytes > 0 {
+		usage.UsagePercentage = (float64(usage.UsedBytes) / float64(usage.CapacityBytes)) * 100
+	}
+	return usage
+}
+
+// applyNodeFilesystemUsage preenche a ocupação de disco dos nós com as estatísticas coletadas.
+func applyNodeFilesystemUsage(nodeInfoList []models.NodeInfo, stats map[string]*statsSummary) {
+	for i := range nodeInfoList {
+		summary, ok := stats[nodeInfoList[i].Name]
+		if !ok {
+			continue
+		}
+		nodeInfoList[i].Filesystem = toFilesystemUsage(summary.Node.Fs)
+		if summary.Node.Runtime != nil {
+			nodeInfoList[i].ImageFilesystem = toFilesystemUsage(summary.Node.Runtime.ImageFs)
+		}
+	}
+}
+
+// applyPvcUsage preenche a ocupação real dos PVCs a partir das estatísticas de volume dos pods.
+func applyPvcUsage(pvcInfoList []models.PvcInfo, stats map[string]*statsSummary) {
+	usageByClaim := map[string]*models.FilesystemUsage{}
+	for _, summary := range stats {
+		for _, pod := range summary.Pods {
+			for _, volume := range pod.Volumes {
+				if volume.PVCRef == nil {
+					continue
+				}
+				if usage := toFilesystemUsage(&volume.fsStats); usage != nil {
+					usageByClaim[volume.PVCRef.Namespace+"/"+volume.PVCRef.Name] = usage
+				}
+			}
+		}
+	}
+	for i := range pvcInfoList {
+		pvcInfoList[i].Usage = usageByClaim[pvcInfoList[i].Namespace+"/"+pvcInfoList[i].Name]
+	}
+}
+
+// nodesWithPvcConsumers retorna os nós onde rodam pods que montam PVCs, evitando consultar nós sem volumes persistentes.
+func nodesWithPvcConsumers(pods *v1.PodList) []string {
+	nodeSet := map[string]bool{}
+	if pods == nil {
+		return nil
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil || volume.Ephemeral != nil {
+				nodeSet[pod.Spec.NodeName] = true
+				break
+			}
+		}
+	}
+	nodeNames := make([]string, 0, len(nodeSet))
+	for name := range nodeSet {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	return nodeNames
+}
+
+// processDiskUsage monta o relatório de ocupação de disco, ordenando cada lista da maior para a menor ocupação.
+func processDiskUsage(nodes *v1.NodeList, stats map[string]*statsSummary, userNamespaces map[string]bool) models.DiskUsageReport {
+	report := models.DiskUsageReport{
+		Nodes:      []models.NodeDiskUsage{},
+		Volumes:    []models.VolumeDiskUsage{},
+		Containers: []models.ContainerDiskUsage{},
+	}
+	if nodes == nil {
+		return report
+	}
+
+	for _, node := range nodes.Items {
+		nodeUsage := models.NodeDiskUsage{Node: node.Name}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeDiskPressure && condition.Status == v1.ConditionTrue {
+				nodeUsage.DiskPressure = true
+			}
+		}
+
+		summary, ok := stats[node.Name]
+		if ok {
+			nodeUsage.StatsAvailable = true
+			nodeUsage.Filesystem = toFilesystemUsage(summary.Node.Fs)
+			if summary.Node.Runtime != nil {
+				nodeUsage.ImageFilesystem = toFilesystemUsage(summary.Node.Runtime.ImageFs)
+			}
+		}
+		report.Nodes = append(report.Nodes, nodeUsage)
+		if !ok {
+			continue
+		}
+
+		for _, pod := range summary.Pods {
+			if !userNamespaces[pod.PodRef.Namespace] {
+				continue
+			}
+			for _, volume := range pod.Volumes {
+				// Os tokens de service account são montados em todos os pods e não ocupam espaço relevante.
+				if strings.HasPrefix(volume.Name, "kube-api-access-") {
+					continue
+				}
+				usage := toFilesystemUsage(&volume.fsStats)
+				if usage == nil {
+					continue
+				}
+				volumeUsage := models.VolumeDiskUsage{
+					Namespace: pod.PodRef.Namespace,
+					Pod:       pod.PodRef.Name,
+					Node:      node.Name,
+					Volume:    volume.Name,
+					Usage:     *usage,
+				}
+				if volume.PVCRef != nil {
+					volumeUsage.Pvc = volume.PVCRef.Name
+				}
+				report.Volumes = append(report.Volumes, volumeUsage)
+			}
+			for _, container := range pod.Containers {
+				report.Containers = append(report.Containers, models.ContainerDiskUsage{
+					Namespace: pod.PodRef.Namespace,
+					Pod:       pod.PodRef.Name,
+					Node:      node.Name,
+					Container: container.Name,
+					Rootfs:    toFilesystemUsage(container.Rootfs),
+					Logs:      toFilesystemUsage(container.Logs),
+				})
+			}
+		}
+	}
+
+	nodePercentage := func(n models.NodeDiskUsage) float64 {
+		if n.Filesystem == nil {
+			return -1
+		}
+		return n.Filesystem.UsagePercentage
+	}
+	sort.SliceStable(report.Nodes, func(i, j int) bool { return nodePercentage(report.Nodes[i]) > nodePercentage(report.Nodes[j]) })
+	sort.SliceStable(report.Volumes, func(i, j int) bool {
+		return report.Volumes[i].Usage.UsagePercentage > report.Volumes[j].Usage.UsagePercentage
+	})
+	containerBytes := func(c models.ContainerDiskUsage) int64 {
+		var total int64
+		if c.Rootfs != nil {
+			total += c.Rootfs.UsedBytes
+		}
+		if c.Logs != nil {
+			total += c.Logs.UsedBytes
+		}
+		return total
+	}
+	sort.SliceStable(report.Containers, func(i, j int) bool {
+		return containerBytes(report.Containers[i]) > containerBytes(report.Containers[j])
+	})
+	return report
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsvake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// sampleStatsSummary é uma resposta reduzida de /stats/summary de um kubelet.
+const sampleStatsSummary = `{
+  "node": {
+    "nodeName": "node-1",
+    "fs": {"availableBytes": 25, "capacityBytes": 100, "usedBytes": 75},
+    "runtime": {"imageFs": {"availableBytes": 50, "capacityBytes": 100, "usedBytes": 50}}
+  },
+  "pods": [{
+    "podRef": {"name": "db-0", "namespace": "app-ns"},
+    "containers": [{"name": "postgres", "rootfs": {"usedBytes": 2048, "capacityBytes": 100000}, "logs": {"usedBytes": 1024}}],
+    "volume": [
+      {"name": "data", "usedBytes": 900, "capacityBytes": 1000, "availableBytes": 100, "pvcRef": {"name": "data-db-0", "namespace": "app-ns"}},
+      {"name": "kube-api-access-abcde", "usedBytes": 10, "capacityBytes": 1000}
+    ]
+  }]
+}`
+
+func newStatsTestService(statsFunc func(ctx context.Context, nodeName string) ([]byte, error), objects ...runtime.Object) *k8sService {
+	s := NewK8sService(fake.NewSimpleClientset(objects...), metricsvake.NewSimpleClientset()).(*k8sService)
+	s.nodeStatsFunc = statsFunc
+	return s
+}
+
+func TestCollectNodeStats(t *testing.T) {
+	var inFlight, maxInFlight int32
+	s := newStatsTestService(func(ctx context.Context, nodeName string) ([]byte, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if nodeName == "broken" {
+			return nil, errors.New("connection refused")
+		}
+		return []byte(sampleStatsSummary), nil
+	})
+
+	nodeNames := []string{"broken"}
+	for i := 0; i < 12; i++ {
+		nodeNames = append(nodeNames, "node-"+string(rune('a'+i)))
+	}
+	stats := s.collectNodeStats(context.Background(), nodeNames)
+
+	assert.Len(t, stats, 12, "o nó com erro deve ser omitido")
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(nodeStatsConcurrency))
+}
+
+func TestCollectNodeStats_Timeout(t *testing.T) {
+	s := newStatsTestService(func(ctx context.Context, nodeName string) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.Empty(t, s.collectNodeStats(ctx, []string{"node-1"}))
+}
+
+func TestGetNodeInfo_DoesNotWaitForKubelet(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	s := newStatsTestService(func(ctx context.Context, nodeName string) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte(sampleStatsSummary), nil
+	}, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	// Sem resumo guardado, a listagem responde sem a ocupação de disco e dispara a coleta em segundo plano.
+	nodes, err := s.GetNodeInfo(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, nodes, 1) {
+		assert.Nil(t, nodes[0].Filesystem)
+	}
+	// Enquanto a coleta está em andamento, novas listagens não disparam outra.
+	_, err = s.GetNodeInfo(context.Background())
+	assert.NoError(t, err)
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		nodes, err := s.GetNodeInfo(context.Background())
+		return err == nil && len(nodes) == 1 && nodes[0].Filesystem != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Resumos recentes também são reaproveitados pela ocupação de disco.
+	_, err = s.GetDiskUsage(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestGetDiskUsage(t *testing.T) {
+	s := newStatsTestService(
+		func(ctx context.Context, nodeName string) ([]byte, error) { return []byte(sampleStatsSummary), nil },
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue}}},
+		},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+	)
+
+	report, err := s.GetDiskUsage(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Nodes, 1)
+	assert.True(t, report.Nodes[0].DiskPressure)
+	assert.InDelta(t, 75.0, report.Nodes[0].Filesystem.UsagePercentage, 0.01)
+	assert.InDelta(t, 50.0, report.Nodes[0].ImageFilesystem.UsagePercentage, 0.01)
+	assert.Len(t, report.Volumes, 1, "o volume do token de service account deve ser ignorado")
+	assert.Equal(t, "data-db-0", report.Volumes[0].Pvc)
+	assert.InDelta(t, 90.0, report.Volumes[0].Usage.UsagePercentage, 0.01)
+	assert.Len(t, report.Containers, 1)
+	assert.Equal(t, int64(1024), report.Containers[0].Logs.UsedBytes)
+}
+
+func TestGetPvcInfo_WithVolumeUsage(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-ns"}},
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "app-ns"}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "app-ns"},
+			Spec: v1.PodSpec{NodeName: "node-1", Volumes: []v1.Volume{{
+				Name:         "data",
+				VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"}},
+			}}},
+		},
+	)
+	s := NewK8sService(fakeClient, metricsvake.NewSimpleClientset()).(*k8sService)
+	var requestedNodes []string
+	var mu sync.Mutex
+	s.nodeStatsFunc = func(ctx context.Context, nodeName string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requestedNodes = append(requestedNodes, nodeName)
+		return []byte(sampleStatsSummary), nil
+	}
+
+	// A primeira consulta não espera pelo kubelet: o uso aparece quando a coleta em segundo plano termina.
+	pvcs, err := s.GetPvcInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, pvcs, 1)
+	assert.Nil(t, pvcs[0].Usage)
+
+	assert.Eventually(t, func() bool {
+		pvcs, err := s.GetPvcInfo(context.Background())
+		return err == nil && len(pvcs) == 1 && pvcs[0].Usage != nil && pvcs[0].Usage.UsedBytes == 900
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"node-1"}, requestedNodes)
+}
+
+func TestFetchNodeStatsSummary_FakeClient(t *testing.T) {
+	s := NewK8sService(fake.NewSimpleClientset(), metricsvake.NewSimpleClientset()).(*k8sService)
+	_, err := s.fetchNodeStatsSummary(context.Background(), "node-1")
+	assert.ErrorIs(t, err, ErrNodeStatsUnavailable)
+}
+
+func TestProcessDiskUsage_NilInput(t *testing.T) {
+	assert.NotPanics(t, func() { processDiskUsage(nil, nil, nil) })
+	assert.Nil(t, toFilesystemUsage(nil))
+	assert.Empty(t, nodesWithPvcConsumers(nil))
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"fmt"
+	"kubeowl/internal/models"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Tipos de vértice do grafo de topologia.
+const (
+	topologyKindIngress       = "Ingress"
+	topologyKindService       = "Service"
+	topologyKindEndpointSlice = "EndpointSlice"
+	topologyKindPod           = "Pod"
+	topologyKindNode          = "Node"
+)
+
+// topologyResources reúne os objetos usados para montar o grafo de topologia.
+type topologyResources struct {
+	ingresses      *networkingv1.IngressList
+	services       *v1.ServiceList
+	endpointSlices *discoveryv1.EndpointSliceList
+	pods           *v1.PodList
+	replicaSets    *appsv1.ReplicaSetList
+}
+
+// topologyBuilder acumula vértices e arestas sem duplicá-los.
+type topologyBuilder struct {
+	nodes map[string]*models.TopologyNode
+	edges map[string]*models.TopologyEdge
+}
+
+func topologyID(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// addNode registra um vértice e retorna o existente caso já tenha sido adicionado.
+func (b *topologyBuilder) addNode(kind, namespace, name string) *models.TopologyNode {
+	id := topologyID(kind, namespace, name)
+	if node, ok := b.nodes[id]; ok {
+		return node
+	}
+	node := &models.TopologyNode{ID: id, Kind: kind, Name: name, Namespace: namespace}
+	b.nodes[id] = node
+	return node
+}
+
+func (b *topologyBuilder) addEdge(source, target, label string, broken bool) {
+	key := source + "|" + target + "|" + label
+	if edge, ok := b.edges[key]; ok {
+		edge.Broken = edge.Broken || broken
+		return
+	}
+	b.edges[key] = &models.TopologyEdge{Source: source, Target: target, Label: label, Broken: broken}
+}
+
+// processTopology monta o grafo de tráfego: Ingresses (todas as regras e caminhos) apontam para Services,
+// que apontam para seus EndpointSlices, que apontam para os Pods; cada Pod liga-se ao workload dono e ao nó.
+// Ligações quebradas (Service inexistente ou sem endpoints prontos) são marcadas com Broken.
+func processTopology(res topologyResources, userNamespaces map[string]bool) models.TopologyGraph {
+	b := &topologyBuilder{nodes: map[string]*models.TopologyNode{}, edges: map[string]*models.TopologyEdge{}}
+
+	services := map[string]v1.Service{}
+	if res.services != nil {
+		for _, service := range res.services.Items {
+			if userNamespaces[service.Namespace] {
+				services[service.Namespace+"/"+service.Name] = service
+			}
+		}
+	}
+	pods := map[string]v1.Pod{}
+	if res.pods != nil {
+		for _, pod := range res.pods.Items {
+			pods[pod.Namespace+"/"+pod.Name] = pod
+		}
+	}
+	replicaSets := map[string]appsv1.ReplicaSet{}
+	if res.replicaSets != nil {
+		for _, rs := range res.replicaSets.Items {
+			replicaSets[rs.Namespace+"/"+rs.Name] = rs
+		}
+	}
+
+	// Ingress → Service
+	if res.ingresses != nil {
+		for _, ingress := range res.ingresses.Items {
+			if !userNamespaces[ingress.Namespace] {
+				continue
+			}
+			ingressNode := b.addNode(topologyKindIngress, ingress.Namespace, ingress.Name)
+			for _, route := range ingressRoutes(ingress) {
+				serviceNode := b.addNode(topologyKindService, ingress.Namespace, route.service)
+				_, exists := services[ingress.Namespace+"/"+route.service]
+				if !exists {
+					serviceNode.Status = "Missing"
+					serviceNode.Broken = true
+					serviceNode.Reason = "Service referenciado pelo Ingress não existe"
+					ingressNode.Broken = true
+					ingressNode.Reason = fmt.Sprintf("Backend %s inexistente", route.service)
+				}
+				b.addEdge(ingressNode.ID, serviceNode.ID, route.label, !exists)
+			}
+		}
+	}
+
+	// Service → EndpointSlice → Pod
+	if res.endpointSlices != nil {
+		for _, slice := range res.endpointSlices.Items {
+			serviceName := slice.Labels[discoveryv1.LabelServiceName]
+			service, ok := services[slice.Namespace+"/"+serviceName]
+			if serviceName == "" || !ok {
+				continue
+			}
+			serviceNode := b.addNode(topologyKindService, service.Namespace, service.Name)
+			sliceNode := b.addNode(topologyKindEndpointSlice, slice.Namespace, slice.Name)
+			sliceNode.Status = string(slice.AddressType)
+			b.addEdge(serviceNode.ID, sliceNode.ID, "", false)
+
+			for _, endpoint := range slice.Endpoints {
+				ready := isEndpointReady(endpoint)
+				if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != topologyKindPod {
+					continue
+				}
+				label := ""
+				if len(endpoint.Addresses) > 0 {
+					label = endpoint.Addresses[0]
+				}
+				podNode := addPodToTopology(b, slice.Namespace, endpoint.TargetRef.Name, pods, replicaSets)
+				b.addEdge(sliceNode.ID, podNode.ID, label, !ready)
+			}
+		}
+	}
+
+	endpoints := countServiceEndpoints(res.endpointSlices)
+	for key, service := range services {
+		serviceNode := b.addNode(topologyKindService, service.Namespace, service.Name)
+		serviceNode.Status = string(service.Spec.Type)
+		if service.Spec.Type != v1.ServiceTypeExternalName && endpoints[key].ready == 0 {
+			serviceNode.Broken = true
+			serviceNode.Reason = "Nenhum endpoint pronto"
+		}
+	}
+
+	graph := models.TopologyGraph{Nodes: []models.TopologyNode{}, Edges: []models.TopologyEdge{}}
+	for _, node := range b.nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	for _, edge := range b.edges {
+		graph.Edges = append(graph.Edges, *edge)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	// Origem, destino e rótulo formam a chave da ligação, então a ordem é total e o JSON do grafo é estável.
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Label < b.Label
+	})
+	return graph
+}
+
+// ingressRoute é um backend de Service referenciado por um Ingress, com o host e caminho que levam até ele.
+type ingressRoute struct {
+	service string
+	label   string
+}
+
+// ingressRoutes lista todos os backends de Service de um Ingress, incluindo o backend padrão.
+// Backends de recurso (Resource) não levam a Services e são ignorados.
+func ingressRoutes(ingress networkingv1.Ingress) []ingressRoute {
+	routes := []ingressRoute{}
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		routes = append(routes, ingressRoute{service: backend.Service.Name, label: "default"})
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			routes = append(routes, ingressRoute{service: path.Backend.Service.Name, label: host + path.Path})
+		}
+	}
+	return routes
+}
+
+// addPodToTopology adiciona o pod, seu workload dono e o nó onde está agendado.
+func addPodToTopology(b *topologyBuilder, namespace, name string, pods map[string]v1.Pod, replicaSets map[string]appsv1.ReplicaSet) *models.TopologyNode {
+	podNode := b.addNode(topologyKindPod, namespace, name)
+	pod, ok := pods[namespace+"/"+name]
+	if !ok {
+		podNode.Status = "Missing"
+		podNode.Broken = true
+		podNode.Reason = "Pod do endpoint não encontrado"
+		return podNode
+	}
+	podNode.Status, _ = getPodStatus(pod)
+
+	if kind, owner := podWorkload(pod, replicaSets); owner != "" {
+		workloadNode := b.addNode(kind, namespace, owner)
+		b.addEdge(workloadNode.ID, podNode.ID, "owns", false)
+	}
+	if pod.Spec.NodeName != "" {
+		node := b.addNode(topologyKindNode, "", pod.Spec.NodeName)
+		b.addEdge(podNode.ID, node.ID, "scheduledOn", false)
+	}
+	return podNode
+}
+
+// podWorkload retorna o tipo e o nome do workload que controla o pod, subindo de ReplicaSet para Deployment.
+func podWorkload(pod v1.Pod, replicaSets map[string]appsv1.ReplicaSet) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "", ""
+	}
+	if owner.Kind == "ReplicaSet" {
+		if rs, ok := replicaSets[pod.Namespace+"/"+owner.Name]; ok {
+			if deployment := metav1.GetControllerOf(&rs); deployment != nil {
+				return deployment.Kind, deployment.Name
+			}
+		}
+	}
+	return owner.Kind, owner.Name
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func topologyFixtures() []runtime.Object {
+	controller := true
+	pathType := networkingv1.PathTypePrefix
+	return []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+					{Path: "/", PathType: &pathType, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "frontend"}}},
+					{Path: "/api", PathType: &pathType, Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+					{Path: "/static", PathType: &pathType, Backend: networkingv1.IngressBackend{Resource: &v1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "assets"}}},
+				}}},
+			}}},
+		},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "app"}, Spec: v1.ServiceSpec{Type: v1.ServiceTypeClusterIP}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "app"}, Spec: v1.ServiceSpec{Type: v1.ServiceTypeClusterIP}},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: "frontend-abc", Namespace: "app", Labels: map[string]string{discoveryv1.LabelServiceName: "frontend"}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "frontend-7d9-x1"}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(false)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "frontend-7d9-x2"}},
+			},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "frontend-7d9", Namespace: "app",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "frontend", Controller: &controller}},
+		}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "frontend-7d9-x1", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "frontend-7d9", Controller: &controller}},
+			},
+			Spec:   v1.PodSpec{NodeName: "node-1"},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "frontend-7d9-x2", Namespace: "app",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "frontend-7d9", Controller: &controller}},
+			},
+			Spec:   v1.PodSpec{NodeName: "node-2"},
+			Status: v1.PodStatus{Phase: v1.PodPending},
+		},
+	}
+}
+
+func findTopologyNode(graph []models.TopologyNode, id string) *models.TopologyNode {
+	for i := range graph {
+		if graph[i].ID == id {
+			return &graph[i]
+		}
+	}
+	return nil
+}
+
+func TestGetTopology(t *testing.T) {
+	service := NewK8sService(fake.NewSimpleClientset(topologyFixtures()...), metricsfake.NewSimpleClientset())
+
+	graph, err := service.GetTopology(context.Background(), "")
+	assert.NoError(t, err)
+
+	ingress := findTopologyNode(graph.Nodes, "Ingress/app/web")
+	if assert.NotNil(t, ingress) {
+		assert.True(t, ingress.Broken)
+	}
+	missing := findTopologyNode(graph.Nodes, "Service/app/api")
+	if assert.NotNil(t, missing) {
+		assert.Equal(t, "Missing", missing.Status)
+		assert.True(t, missing.Broken)
+	}
+	frontend := findTopologyNode(graph.Nodes, "Service/app/frontend")
+	if assert.NotNil(t, frontend) {
+		assert.False(t, frontend.Broken)
+		assert.Equal(t, "ClusterIP", frontend.Status)
+	}
+	idle := findTopologyNode(graph.Nodes, "Service/app/idle")
+	if assert.NotNil(t, idle) {
+		assert.True(t, idle.Broken)
+		assert.Equal(t, "Nenhum endpoint pronto", idle.Reason)
+	}
+	assert.NotNil(t, findTopologyNode(graph.Nodes, "Deployment/app/frontend"))
+	assert.NotNil(t, findTopologyNode(graph.Nodes, "Node//node-1"))
+	assert.Nil(t, findTopologyNode(graph.Nodes, "StorageBucket/app/assets"))
+
+	edges := map[string]bool{}
+	for _, edge := range graph.Edges {
+		edges[edge.Source+"->"+edge.Target+"|"+edge.Label] = edge.Broken
+	}
+	assert.Equal(t, map[string]bool{
+		"Ingress/app/web->Service/app/frontend|shop.example.com/":          false,
+		"Ingress/app/web->Service/app/api|shop.example.com/api":            true,
+		"Service/app/frontend->EndpointSlice/app/frontend-abc|":            false,
+		"EndpointSlice/app/frontend-abc->Pod/app/frontend-7d9-x1|10.0.0.1": false,
+		"EndpointSlice/app/frontend-abc->Pod/app/frontend-7d9-x2|10.0.0.2": true,
+		"Deployment/app/frontend->Pod/app/frontend-7d9-x1|owns":            false,
+		"Deployment/app/frontend->Pod/app/frontend-7d9-x2|owns":            false,
+		"Pod/app/frontend-7d9-x1->Node//node-1|scheduledOn":                false,
+		"Pod/app/frontend-7d9-x2->Node//node-2|scheduledOn":                false,
+	}, edges)
+}
+
+// TestProcessTopology_EdgeOrder verifica que ligações entre os mesmos nós saem ordenadas pelo rótulo.
+func TestProcessTopology_EdgeOrder(t *testing.T) {
+	pathType := networkingv1.PathTypePrefix
+	paths := []networkingv1.HTTPIngressPath{}
+	for _, path := range []string{"/c", "/a", "/d", "/b"} {
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path: path, PathType: &pathType,
+			Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "frontend"}},
+		})
+	}
+	res := topologyResources{
+		ingresses: &networkingv1.IngressList{Items: []networkingv1.Ingress{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host:             "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}},
+			}}},
+		}}},
+		services: &v1.ServiceList{Items: []v1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "app"}}}},
+	}
+
+	for i := 0; i < 10; i++ {
+		graph := processTopology(res, map[string]bool{"app": true})
+		labels := []string{}
+		for _, edge := range graph.Edges {
+			labels = append(labels, edge.Label)
+		}
+		assert.Equal(t, []string{"shop.example.com/a", "shop.example.com/b", "shop.example.com/c", "shop.example.com/d"}, labels)
+	}
+}
+
+func TestProcessTopology_NilInput(t *testing.T) {
+	graph := processTopology(topologyResources{}, map[string]bool{})
+	assert.Empty(t, graph.Nodes)
+	assert.Empty(t, graph.Edges)
+}
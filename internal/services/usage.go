@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"kubeowl/internal/logging"
+	"log/slog"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	versioned "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+const (
+	// DefaultUsageWindow é por quanto tempo as amostras de uso são mantidas.
+	DefaultUsageWindow = 24 * time.Hour
+	// DefaultUsageInterval é o intervalo padrão entre as coletas de uso.
+	DefaultUsageInterval = time.Minute
+	// DefaultUsageMaxSamples limita as amostras guardadas por contêiner de workload; as mais antigas são descartadas
+	// primeiro. Como cada coleta gera uma amostra por contêiner, independentemente do número de réplicas, o limite
+	// cobre a janela padrão com coletas a partir de 5 segundos.
+	DefaultUsageMaxSamples = 20000
+)
+
+// usageSample é o maior consumo de um contêiner entre as réplicas do workload em uma coleta.
+type usageSample struct {
+	time        time.Time
+	cpuMilli    int64
+	memoryBytes int64
+}
+
+// usageKey identifica um contêiner de um workload. As amostras são agrupadas pelo workload, e não pelo pod,
+// para que o histórico sobreviva à troca das réplicas em rollouts e reinícios e não cresça com o número delas.
+type usageKey struct {
+	workload  workloadRef
+	container string
+}
+
+// UsageHistory guarda em memória o consumo recente dos contêineres, coletado periodicamente do metrics-server.
+type UsageHistory struct {
+	mu         sync.RWMutex
+	window     time.Duration
+	maxSamples int
+	series     map[usageKey][]usageSample
+	// lastSeen guarda o timestamp da última métrica de cada pod, para não gravar uma coleta em que nenhuma
+	// réplica do workload foi atualizada pelo metrics-server.
+	lastSeen map[string]time.Time
+	now      func() time.Time
+}
+
+// UsageOption personaliza o UsageHistory.
+type UsageOption func(*UsageHistory)
+
+// WithUsageWindow define por quanto tempo as amostras de uso são mantidas.
+func WithUsageWindow(window time.Duration) UsageOption {
+	return func(h *UsageHistory) {
+		if window > 0 {
+			h.window = window
+		}
+	}
+}
+
+// WithUsageMaxSamples define a quantidade máxima de amostras guardadas por contêiner de workload.
+func WithUsageMaxSamples(maxSamples int) UsageOption {
+	return func(h *UsageHistory) {
+		if maxSamples > 0 {
+			h.maxSamples = maxSamples
+		}
+	}
+}
+
+// NewUsageHistory cria um histórico de uso vazio.
+func NewUsageHistory(opts ...UsageOption) *UsageHistory {
+	h := &UsageHistory{
+		window:     DefaultUsageWindow,
+		maxSamples: DefaultUsageMaxSamples,
+		series:     map[usageKey][]usageSample{},
+		lastSeen:   map[string]time.Time{},
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Window retorna por quanto tempo as amostras são mantidas.
+func (h *UsageHistory) Window() time.Duration {
+	return h.window
+}
+
+// Run coleta o uso imediatamente e a cada intervalo, até o contexto ser cancelado.
+func (h *UsageHistory) Run(ctx context.Context, clientset kubernetes.Interface, metricsClientset versioned.Interface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := h.Collect(ctx, clientset, metricsClientset); err != nil {
+			slog.WarnContext(ctx, "falha ao coletar o histórico de uso", logging.Err(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect lê as métricas atuais dos pods e as grava no histórico, associadas ao workload de cada pod.
+func (h *UsageHistory) Collect(ctx context.Context, clientset kubernetes.Interface, metricsClientset versioned.Interface) error {
+	podMetrics, err := metricsClientset.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	replicaSets, _ := clientset.AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+	h.record(pods, replicaSets, podMetrics)
+	return nil
+}
+
+// record grava uma amostra por contêiner de workload com o maior uso entre as réplicas atuais, para que o
+// dimensionamento atenda à réplica mais carregada, e descarta as amostras fora da janela. Cada réplica entra com
+// a métrica mais recente dela, atualizada ou não nesta coleta; a amostra só é gravada quando ao menos uma réplica
+// foi atualizada, para não repetir a coleta anterior.
+func (h *UsageHistory) record(pods *v1.PodList, replicaSets *appsv1.ReplicaSetList, podMetrics *metricsv1beta1.PodMetricsList) {
+	now := h.now()
+	podsByName := map[string]v1.Pod{}
+	if pods != nil {
+		for _, pod := range pods.Items {
+			podsByName[pod.Namespace+"/"+pod.Name] = pod
+		}
+	}
+	replicaSetsByName := map[string]appsv1.ReplicaSet{}
+	if replicaSets != nil {
+		for _, rs := range replicaSets.Items {
+			replicaSetsByName[rs.Namespace+"/"+rs.Name] = rs
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tick := map[usageKey]usageSample{}
+	refreshed := map[usageKey]bool{}
+	if podMetrics != nil {
+		for _, pm := range podMetrics.Items {
+			podKey := pm.Namespace + "/" + pm.Name
+			pod, ok := podsByName[podKey]
+			if !ok {
+				continue
+			}
+			timestamp := pm.Timestamp.Time
+			if timestamp.IsZero() {
+				timestamp = now
+			}
+			last, seen := h.lastSeen[podKey]
+			updated := !seen || timestamp.After(last)
+			if updated {
+				h.lastSeen[podKey] = timestamp
+			}
+			workload := podWorkloadRef(pod, replicaSetsByName)
+			for _, container := range pm.Containers {
+				key := usageKey{workload: workload, container: container.Name}
+				refreshed[key] = refreshed[key] || updated
+				// As réplicas são medidas em instantes diferentes; a amostra da coleta leva o instante dela.
+				sample := tick[key]
+				sample.time = now
+				sample.cpuMilli = max(sample.cpuMilli, container.Usage.Cpu().MilliValue())
+				sample.memoryBytes = max(sample.memoryBytes, container.Usage.Memory().Value())
+				tick[key] = sample
+			}
+		}
+	}
+	for key, sample := range tick {
+		if refreshed[key] {
+			h.series[key] = append(h.series[key], sample)
+		}
+	}
+	h.prune(now)
+}
+
+// prune descarta as amostras fora da janela e o excedente de cada série. Deve ser chamado com o lock de escrita.
+func (h *UsageHistory) prune(now time.Time) {
+	cutoff := now.Add(-h.window)
+	for key, samples := range h.series {
+		first := 0
+		for first < len(samples) && samples[first].time.Before(cutoff) {
+			first++
+		}
+		first = max(first, len(samples)-h.maxSamples)
+		switch {
+		case first >= len(samples):
+			delete(h.series, key)
+		case first > 0:
+			h.series[key] = append([]usageSample{}, samples[first:]...)
+		}
+	}
+	for podKey, last := range h.lastSeen {
+		if last.Before(cutoff) {
+			delete(h.lastSeen, podKey)
+		}
+	}
+}
+
+// samples retorna uma cópia das amostras do contêiner do workload.
+func (h *UsageHistory) samples(key usageKey) []usageSample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]usageSample{}, h.series[key]...)
+}
+
+// podWorkloadRef identifica o workload do pod; pods sem controlador formam o próprio workload.
+func podWorkloadRef(pod v1.Pod, replicaSets map[string]appsv1.ReplicaSet) workloadRef {
+	ref := workloadRef{kind: "Pod", namespace: pod.Namespace, name: pod.Name}
+	if kind, owner := podWorkload(pod, replicaSets); owner != "" {
+		ref.kind, ref.name = kind, owner
+	}
+	return ref
+}
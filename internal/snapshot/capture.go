@@ -0,0 +1,169 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kubeowl/internal/logging"
+	"kubeowl/internal/services"
+	"log/slog"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	versioned "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// lastAppliedAnnotation guarda a configuração aplicada pelo kubectl, que nos Secrets inclui os valores.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// CaptureOption personaliza a captura.
+type CaptureOption func(*captureConfig)
+
+type captureConfig struct {
+	nodeStats services.NodeStatsFunc
+}
+
+// WithNodeStatsFunc define como o /stats/summary de cada nó é obtido (por padrão, pelo proxy do API server).
+func WithNodeStatsFunc(f services.NodeStatsFunc) CaptureOption {
+	return func(c *captureConfig) {
+		c.nodeStats = f
+	}
+}
+
+// Capture lê do cluster todos os recursos listáveis da versão preferida de cada grupo, as métricas e as
+// estatísticas dos kubelets. Recursos que não podem ser listados (ex.: sem permissão) são omitidos com um aviso.
+// Os valores dos Secrets são mascarados: o snapshot guarda apenas as chaves e o tamanho de cada valor.
+func Capture(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, metricsClientset versioned.Interface, opts ...CaptureOption) (*Snapshot, error) {
+	cfg := &captureConfig{
+		nodeStats: func(ctx context.Context, nodeName string) ([]byte, error) {
+			return services.FetchNodeStatsSummary(ctx, clientset, nodeName)
+		},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	s := &Snapshot{CapturedAt: time.Now().UTC(), NodeStats: make(map[string]json.RawMessage)}
+	if version, err := clientset.Discovery().ServerVersion(); err == nil {
+		s.ServerVersion = version.GitVersion
+	}
+
+	lists, err := discovery.ServerPreferredResources(clientset.Discovery())
+	if err != nil {
+		// Falhas em grupos isolados (ex.: um APIService fora do ar) não impedem a captura dos demais.
+		if !discovery.IsGroupDiscoveryFailedError(err) || len(lists) == 0 {
+			return nil, fmt.Errorf("falha ao descobrir os recursos do cluster: %w", err)
+		}
+		slog.WarnContext(ctx, "descoberta parcial dos recursos do cluster", logging.Err(err))
+	}
+
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		// As métricas são capturadas pelo cliente tipado, abaixo.
+		if gv.Group == metricsv1beta1.SchemeGroupVersion.Group {
+			continue
+		}
+		// A descoberta gravada traz apenas os recursos capturados, para que o modo offline não ofereça
+		// recursos sem dados.
+		kept := &metav1.APIResourceList{TypeMeta: list.TypeMeta, GroupVersion: list.GroupVersion}
+		for _, apiResource := range list.APIResources {
+			if strings.Contains(apiResource.Name, "/") || !hasVerb(apiResource, "list") {
+				continue
+			}
+			items, err := dynamicClient.Resource(gv.WithResource(apiResource.Name)).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				slog.WarnContext(ctx, "recurso omitido do snapshot", "group", gv.Group, "version", gv.Version, "resource", apiResource.Name, logging.Err(err))
+				continue
+			}
+			kept.APIResources = append(kept.APIResources, apiResource)
+			resource := Resource{
+				Group: gv.Group, Version: gv.Version, Resource: apiResource.Name, Kind: apiResource.Kind,
+				Namespaced: apiResource.Namespaced, Items: items.Items,
+			}
+			for i := range resource.Items {
+				sanitize(&resource.Items[i], gv.Group == "" && apiResource.Name == "secrets")
+			}
+			s.Resources = append(s.Resources, resource)
+		}
+		if len(kept.APIResources) > 0 {
+			s.Discovery = append(s.Discovery, kept)
+		}
+	}
+
+	if nodeMetrics, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{}); err == nil {
+		s.NodeMetrics = nodeMetrics.Items
+	} else {
+		slog.WarnContext(ctx, "métricas dos nós omitidas do snapshot", logging.Err(err))
+	}
+	if podMetrics, err := metricsClientset.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{}); err == nil {
+		s.PodMetrics = podMetrics.Items
+	} else {
+		slog.WarnContext(ctx, "métricas dos pods omitidas do snapshot", logging.Err(err))
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar os nós: %w", err)
+	}
+	for _, node := range nodes.Items {
+		raw, err := cfg.nodeStats(ctx, node.Name)
+		if err != nil {
+			if !errors.Is(err, services.ErrNodeStatsUnavailable) {
+				slog.WarnContext(ctx, "estatísticas do nó omitidas do snapshot", "node", node.Name, logging.Err(err))
+			}
+			continue
+		}
+		if !json.Valid(raw) {
+			slog.WarnContext(ctx, "estatísticas do nó inválidas", "node", node.Name)
+			continue
+		}
+		s.NodeStats[node.Name] = raw
+	}
+	return s, nil
+}
+
+func hasVerb(resource metav1.APIResource, verb string) bool {
+	for _, v := range resource.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitize remove os managedFields, que não são exibidos e inflam o arquivo, e mascara os valores dos Secrets.
+func sanitize(obj *unstructured.Unstructured, secret bool) {
+	obj.SetManagedFields(nil)
+	if !secret {
+		return
+	}
+	if annotations := obj.GetAnnotations(); annotations[lastAppliedAnnotation] != "" {
+		delete(annotations, lastAppliedAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+	data, _, _ := unstructured.NestedMap(obj.Object, "data")
+	for key, value := range data {
+		encoded, _ := value.(string)
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			decoded = []byte(encoded)
+		}
+		data[key] = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("*", len(decoded))))
+	}
+	if len(data) > 0 {
+		_ = unstructured.SetNestedMap(obj.Object, data, "data")
+	}
+	unstructured.RemoveNestedField(obj.Object, "stringData")
+}
@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"kubeowl/internal/services"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	versioned "k8s.io/metrics/pkg/client/clientset/versioned"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// crdResource é listado pelo serviço mesmo quando o cluster não tem CRDs.
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// Clients são clientes falsos que respondem com o conteúdo de um snapshot.
+type Clients struct {
+	Clientset kubernetes.Interface
+	Dynamic   dynamic.Interface
+	Metrics   versioned.Interface
+	NodeStats services.NodeStatsFunc
+}
+
+// Clients monta os clientes falsos do snapshot. Os objetos de tipos conhecidos pelo client-go são
+// servidos pelo clientset tipado; todos os objetos, inclusive os de CRDs, são servidos pelo cliente dinâmico.
+func (s *Snapshot) Clients() (*Clients, error) {
+	clientset := fake.NewSimpleClientset()
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = s.Discovery
+	discovery.FakedServerVersion = &version.Info{GitVersion: s.ServerVersion}
+
+	listKinds := map[schema.GroupVersionResource]string{crdResource: "CustomResourceDefinitionList"}
+	for _, r := range s.Resources {
+		listKinds[r.GroupVersionResource()] = r.Kind + "List"
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+
+	// Os objetos são criados com o GVR gravado: o rastreador dos fakes deduziria o recurso pelo Kind,
+	// o que falha em plurais irregulares (ex.: Endpoints).
+	for _, r := range s.Resources {
+		gvr := r.GroupVersionResource()
+		for i := range r.Items {
+			item := r.Items[i].DeepCopy()
+			if err := dynamicClient.Tracker().Create(gvr, item, item.GetNamespace()); err != nil {
+				return nil, fmt.Errorf("falha ao carregar %s %s/%s: %w", gvr.Resource, item.GetNamespace(), item.GetName(), err)
+			}
+			gvk := item.GroupVersionKind()
+			if !scheme.Scheme.Recognizes(gvk) {
+				continue
+			}
+			typed, err := scheme.Scheme.New(gvk)
+			if err != nil {
+				return nil, err
+			}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, typed); err != nil {
+				return nil, fmt.Errorf("falha ao converter %s %s/%s: %w", gvr.Resource, item.GetNamespace(), item.GetName(), err)
+			}
+			if err := clientset.Tracker().Create(gvr, typed, item.GetNamespace()); err != nil {
+				return nil, fmt.Errorf("falha ao carregar %s %s/%s: %w", gvr.Resource, item.GetNamespace(), item.GetName(), err)
+			}
+		}
+	}
+
+	metricsClientset := metricsfake.NewSimpleClientset()
+	for i := range s.NodeMetrics {
+		if err := metricsClientset.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("nodes"), &s.NodeMetrics[i], ""); err != nil {
+			return nil, fmt.Errorf("falha ao carregar as métricas do nó %s: %w", s.NodeMetrics[i].Name, err)
+		}
+	}
+	for i := range s.PodMetrics {
+		pod := &s.PodMetrics[i]
+		if err := metricsClientset.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), pod, pod.Namespace); err != nil {
+			return nil, fmt.Errorf("falha ao carregar as métricas do pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	return &Clients{
+		Clientset: clientset,
+		Dynamic:   dynamicClient,
+		Metrics:   metricsClientset,
+		NodeStats: func(ctx context.Context, nodeName string) ([]byte, error) {
+			raw, ok := s.NodeStats[nodeName]
+			if !ok {
+				return nil, services.ErrNodeStatsUnavailable
+			}
+			return raw, nil
+		},
+	}, nil
+}
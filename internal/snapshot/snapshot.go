@@ -0,0 +1,263 @@
+// Package snapshot grava em um único arquivo .tar.gz todos os recursos e métricas que o KubeOwl lê do
+// cluster, e reconstrói a partir dele clientes falsos que permitem usar o painel offline (post-mortems e demos).
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// FormatVersion é a versão do formato do arquivo; arquivos de versões diferentes são rejeitados.
+const FormatVersion = 1
+
+// Nomes das entradas do arquivo.
+const (
+	manifestFile    = "manifest.json"
+	discoveryFile   = "discovery.json"
+	nodeMetricsFile = "metrics/nodes.json"
+	podMetricsFile  = "metrics/pods.json"
+	resourcesDir    = "resources"
+	statsDir        = "stats"
+)
+
+// maxEntrySize limita o tamanho de cada entrada descompactada, protegendo contra arquivos malformados.
+const maxEntrySize = 1 << 30
+
+// Snapshot é o conteúdo de um arquivo de snapshot.
+type Snapshot struct {
+	CapturedAt    time.Time
+	ServerVersion string
+	// Discovery são os recursos preferidos de cada grupo, como retornados pelo API server.
+	Discovery   []*metav1.APIResourceList
+	Resources   []Resource
+	NodeMetrics []metricsv1beta1.NodeMetrics
+	PodMetrics  []metricsv1beta1.PodMetrics
+	// NodeStats guarda o /stats/summary bruto de cada nó.
+	NodeStats map[string]json.RawMessage
+}
+
+// Resource são os objetos de um tipo de recurso.
+type Resource struct {
+	Group      string
+	Version    string
+	Resource   string
+	Kind       string
+	Namespaced bool
+	Items      []unstructured.Unstructured
+}
+
+// GroupVersionResource retorna o GVR do recurso.
+func (r Resource) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// file retorna o caminho da entrada do recurso no arquivo (o grupo core é gravado como "core").
+func (r Resource) file() string {
+	group := r.Group
+	if group == "" {
+		group = "core"
+	}
+	return path.Join(resourcesDir, group, r.Version, r.Resource+".json")
+}
+
+// manifest descreve o arquivo e lista as entradas de recursos.
+type manifest struct {
+	Version       int             `json:"version"`
+	CapturedAt    time.Time       `json:"capturedAt"`
+	ServerVersion string          `json:"serverVersion,omitempty"`
+	Resources     []manifestEntry `json:"resources"`
+	Nodes         []string        `json:"nodes,omitempty"`
+}
+
+type manifestEntry struct {
+	Group      string `json:"group"`
+	Version    string `json:"version"`
+	Resource   string `json:"resource"`
+	Kind       string `json:"kind"`
+	Namespaced bool   `json:"namespaced"`
+	Count      int    `json:"count"`
+	File       string `json:"file"`
+}
+
+// WriteFile grava o snapshot no caminho informado.
+func (s *Snapshot) WriteFile(name string) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := s.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Write grava o snapshot como um .tar.gz.
+func (s *Snapshot) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := s.CapturedAt
+
+	add := func(name string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("falha ao serializar %s: %w", name, err)
+		}
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	m := manifest{Version: FormatVersion, CapturedAt: s.CapturedAt, ServerVersion: s.ServerVersion, Resources: []manifestEntry{}}
+	for _, r := range s.Resources {
+		m.Resources = append(m.Resources, manifestEntry{
+			Group: r.Group, Version: r.Version, Resource: r.Resource, Kind: r.Kind,
+			Namespaced: r.Namespaced, Count: len(r.Items), File: r.file(),
+		})
+	}
+	for node := range s.NodeStats {
+		m.Nodes = append(m.Nodes, node)
+	}
+	sort.Strings(m.Nodes)
+
+	if err := add(manifestFile, m); err != nil {
+		return err
+	}
+	if err := add(discoveryFile, s.Discovery); err != nil {
+		return err
+	}
+	for _, r := range s.Resources {
+		list := &unstructured.UnstructuredList{Items: r.Items}
+		list.SetAPIVersion(schema.GroupVersion{Group: r.Group, Version: r.Version}.String())
+		list.SetKind(r.Kind + "List")
+		if err := add(r.file(), list); err != nil {
+			return err
+		}
+	}
+	if err := add(nodeMetricsFile, s.NodeMetrics); err != nil {
+		return err
+	}
+	if err := add(podMetricsFile, s.PodMetrics); err != nil {
+		return err
+	}
+	for _, node := range m.Nodes {
+		if err := add(path.Join(statsDir, node+".json"), s.NodeStats[node]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadFile lê o snapshot gravado no caminho informado.
+func ReadFile(name string) (*Snapshot, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Read lê um snapshot gravado por Write.
+func Read(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot inválido: %w", err)
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot inválido: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("snapshot inválido: entrada %s muito grande", header.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot inválido: %w", err)
+		}
+		entries[header.Name] = data
+	}
+
+	decode := func(name string, v interface{}) error {
+		data, ok := entries[name]
+		if !ok {
+			return fmt.Errorf("snapshot inválido: entrada %s ausente", name)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("snapshot inválido: falha ao decodificar %s: %w", name, err)
+		}
+		return nil
+	}
+
+	var m manifest
+	if err := decode(manifestFile, &m); err != nil {
+		return nil, err
+	}
+	if m.Version != FormatVersion {
+		return nil, fmt.Errorf("versão de snapshot não suportada: %d", m.Version)
+	}
+	s := &Snapshot{CapturedAt: m.CapturedAt, ServerVersion: m.ServerVersion, NodeStats: make(map[string]json.RawMessage)}
+	if err := decode(discoveryFile, &s.Discovery); err != nil {
+		return nil, err
+	}
+	for _, entry := range m.Resources {
+		data, ok := entries[entry.File]
+		if !ok {
+			return nil, fmt.Errorf("snapshot inválido: entrada %s ausente", entry.File)
+		}
+		// UnstructuredList preserva os números inteiros, ao contrário de json.Unmarshal em mapas genéricos.
+		list := &unstructured.UnstructuredList{}
+		if err := list.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("snapshot inválido: falha ao decodificar %s: %w", entry.File, err)
+		}
+		s.Resources = append(s.Resources, Resource{
+			Group: entry.Group, Version: entry.Version, Resource: entry.Resource, Kind: entry.Kind,
+			Namespaced: entry.Namespaced, Items: list.Items,
+		})
+	}
+	if err := decode(nodeMetricsFile, &s.NodeMetrics); err != nil {
+		return nil, err
+	}
+	if err := decode(podMetricsFile, &s.PodMetrics); err != nil {
+		return nil, err
+	}
+	for _, node := range m.Nodes {
+		var raw json.RawMessage
+		if err := decode(path.Join(statsDir, node+".json"), &raw); err != nil {
+			return nil, err
+		}
+		s.NodeStats[node] = raw
+	}
+	return s, nil
+}
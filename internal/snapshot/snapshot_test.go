@@ -0,0 +1,202 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"kubeowl/internal/services"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// TestMain silencia a saída de log durante os testes deste pacote.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+const sampleStats = `{"node":{"nodeName":"node-1","fs":{"availableBytes":25,"capacityBytes":100,"usedBytes":75}},"pods":[]}`
+
+var widgetResource = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+// captureSample captura um snapshot de um cluster falso com um nó, um pod, um Secret e um recurso de CRD.
+func captureSample(t *testing.T) *Snapshot {
+	objects := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "shop"},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+			Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", RestartCount: 3},
+			}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", Annotations: map[string]string{
+				lastAppliedAnnotation: `{"data":{"password":"czNjcjN0"}}`,
+			}},
+			Data: map[string][]byte{"password": []byte("s3cr3t")},
+		},
+	}
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+			{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+		}},
+		{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		}},
+		{GroupVersion: "metrics.k8s.io/v1beta1", APIResources: []metav1.APIResource{
+			{Name: "nodes", Kind: "NodeMetrics", Verbs: metav1.Verbs{"get", "list"}},
+		}},
+	}
+
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "gear", "namespace": "shop"},
+		"spec":       map[string]interface{}{"size": int64(3)},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme,
+		map[schema.GroupVersionResource]string{widgetResource: "WidgetList"},
+		append(objects, widget)...)
+
+	metricsClientset := metricsfake.NewSimpleClientset()
+	nodeMetrics := &metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Usage:      v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	if err := metricsClientset.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("nodes"), nodeMetrics, ""); err != nil {
+		t.Fatalf("falha ao preparar as métricas: %v", err)
+	}
+
+	snap, err := Capture(context.Background(), clientset, dynamicClient, metricsClientset,
+		WithNodeStatsFunc(func(ctx context.Context, nodeName string) ([]byte, error) {
+			return []byte(sampleStats), nil
+		}))
+	if err != nil {
+		t.Fatalf("falha na captura: %v", err)
+	}
+	return snap
+}
+
+func findResource(s *Snapshot, resource string) *Resource {
+	for i := range s.Resources {
+		if s.Resources[i].Resource == resource {
+			return &s.Resources[i]
+		}
+	}
+	return nil
+}
+
+func TestCapture(t *testing.T) {
+	snap := captureSample(t)
+
+	names := []string{}
+	for _, r := range snap.Resources {
+		names = append(names, r.Resource)
+	}
+	// Subrecursos, recursos sem list e o grupo de métricas ficam de fora.
+	assert.ElementsMatch(t, []string{"namespaces", "nodes", "pods", "secrets", "widgets"}, names)
+	assert.Len(t, snap.Discovery, 2)
+	assert.Len(t, snap.NodeMetrics, 1)
+	assert.JSONEq(t, sampleStats, string(snap.NodeStats["node-1"]))
+
+	secrets := findResource(snap, "secrets")
+	if secrets == nil || len(secrets.Items) != 1 {
+		t.Fatalf("Secret não capturado: %+v", secrets)
+	}
+	password, _, _ := unstructured.NestedString(secrets.Items[0].Object, "data", "password")
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("******")), password)
+	assert.NotContains(t, secrets.Items[0].GetAnnotations(), lastAppliedAnnotation)
+}
+
+func TestWriteRead_ReplaysThroughService(t *testing.T) {
+	var buf bytes.Buffer
+	if err := captureSample(t).Write(&buf); err != nil {
+		t.Fatalf("falha ao gravar: %v", err)
+	}
+	snap, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("falha ao ler: %v", err)
+	}
+	widgets := findResource(snap, "widgets")
+	if widgets == nil || len(widgets.Items) != 1 {
+		t.Fatalf("Widget não lido: %+v", widgets)
+	}
+	size, _, _ := unstructured.NestedInt64(widgets.Items[0].Object, "spec", "size")
+	assert.Equal(t, int64(3), size)
+
+	clients, err := snap.Clients()
+	if err != nil {
+		t.Fatalf("falha ao montar os clientes: %v", err)
+	}
+	service := services.NewK8sService(clients.Clientset, clients.Metrics,
+		services.WithDynamicClient(clients.Dynamic),
+		services.WithNodeStatsFunc(clients.NodeStats),
+	)
+	ctx := context.Background()
+
+	pods, err := service.GetPodInfo(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, pods, 1) {
+		assert.Equal(t, "web-1", pods[0].Name)
+		assert.Equal(t, int32(3), pods[0].Restarts)
+	}
+
+	// A listagem de nós usa os resumos do kubelet já coletados, como os da ocupação de disco.
+	_, err = service.GetDiskUsage(ctx)
+	assert.NoError(t, err)
+	nodes, err := service.GetNodeInfo(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, nodes, 1) {
+		assert.Equal(t, 25.0, nodes[0].CPUUsagePercentage)
+		if assert.NotNil(t, nodes[0].Filesystem) {
+			assert.Equal(t, int64(75), nodes[0].Filesystem.UsedBytes)
+		}
+	}
+
+	secret, err := service.GetSecretDetail(ctx, "shop", "db", true)
+	assert.NoError(t, err)
+	if assert.Len(t, secret.Keys, 1) {
+		assert.Equal(t, "******", secret.Keys[0].Value)
+	}
+
+	list, err := service.GetResourceList(ctx, "example.com", "v1", "widgets", "")
+	assert.NoError(t, err)
+	if assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "gear", list.Items[0].Name)
+	}
+}
+
+func TestRead_RejectsInvalidArchive(t *testing.T) {
+	_, err := Read(bytes.NewBufferString("não é um tar.gz"))
+	assert.Error(t, err)
+}
@@ -4,39 +4,56 @@ import (
 	"context"
 	"encoding/json"
 	"kubeowl/internal/k8s"
+	"kubeowl/internal/logging"
 	"kubeowl/internal/models"
 	"kubeowl/internal/websocket"
-	"log"
+	"log/slog"
 	"time"
 
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
-// Start inicia os watchers para os recursos do Kubernetes.
-func Start(hub *websocket.Hub) {
-	log.Println("Iniciando watchers do Kubernetes...")
-	go runWatcher(hub, "pods", watchPods)
-	go runWatcher(hub, "events", watchEvents)
-	go runWatcher(hub, "nodes", watchNodes)
+// EventRecorder recebe cada evento do cluster observado pelo watcher, por exemplo para arquivá-lo. Os eventos
+// vêm da API events.k8s.io/v1, a mesma do feed de eventos, para que o histórico tenha o mesmo formato.
+type EventRecorder interface {
+	Record(event *eventsv1.Event) error
 }
 
-func runWatcher(hub *websocket.Hub, resourceType string, watchFunc func(context.Context) (watch.Interface, error)) {
+// Start inicia os watchers para os recursos do Kubernetes. O recorder é opcional.
+func Start(hub *websocket.Hub, recorder EventRecorder) {
+	slog.Info("iniciando watchers do Kubernetes")
+	go runWatcher("pods", watchPods, broadcastTo(hub, "pods"))
+	go runWatcher("events", watchEvents, broadcastTo(hub, "events"))
+	go runWatcher("nodes", watchNodes, broadcastTo(hub, "nodes"))
+	if recorder != nil {
+		// O WebSocket mantém o formato core/v1 esperado pela interface; o arquivo usa events.k8s.io/v1, como o feed.
+		go runWatcher("events.k8s.io", watchEventsV1, func(events <-chan watch.Event) { recordWatcherEvents(recorder, events) })
+	}
+}
+
+// broadcastTo retorna o processamento que repassa os eventos do watcher aos clientes WebSocket.
+func broadcastTo(hub *websocket.Hub, resourceType string) func(<-chan watch.Event) {
+	return func(events <-chan watch.Event) { processWatcherEvents(hub, events, resourceType) }
+}
+
+func runWatcher(resourceType string, watchFunc func(context.Context) (watch.Interface, error), process func(<-chan watch.Event)) {
 	for {
 		ctx, cancel := context.WithCancel(context.Background())
 		watcher, err := watchFunc(ctx)
 		if err != nil {
-			log.Printf("Erro ao iniciar watcher de %s: %v. Tentando novamente em 5s.", resourceType, err)
+			slog.Error("erro ao iniciar watcher; nova tentativa em 5s", logging.KeyResource, resourceType, logging.Err(err))
 			cancel()
 			time.Sleep(5 * time.Second)
 			continue
 		}
 
-		log.Printf("Watcher de %s iniciado.", resourceType)
-		processWatcherEvents(hub, watcher.ResultChan(), resourceType)
+		slog.Info("watcher iniciado", logging.KeyResource, resourceType)
+		process(watcher.ResultChan())
 		watcher.Stop()
 		cancel()
-		log.Printf("Watcher de %s encerrado. Reiniciando...", resourceType)
+		slog.Warn("watcher encerrado; reiniciando", logging.KeyResource, resourceType)
 	}
 }
 
@@ -45,13 +62,30 @@ func processWatcherEvents(hub *websocket.Hub, events <-chan watch.Event, resourc
 		msg := models.WSMessage{Type: resourceType, Payload: event}
 		jsonMsg, err := json.Marshal(msg)
 		if err != nil {
-			log.Printf("Erro ao serializar mensagem do watcher de %s: %v", resourceType, err)
+			slog.Error("erro ao serializar mensagem do watcher", logging.KeyResource, resourceType, logging.Err(err))
 			continue
 		}
 		hub.Broadcast <- jsonMsg
 	}
 }
 
+// recordWatcherEvents entrega ao recorder os eventos criados e atualizados. Remoções são ignoradas: os eventos
+// apagados pelo TTL do Kubernetes continuam no arquivo.
+func recordWatcherEvents(recorder EventRecorder, events <-chan watch.Event) {
+	for event := range events {
+		if event.Type != watch.Added && event.Type != watch.Modified {
+			continue
+		}
+		k8sEvent, ok := event.Object.(*eventsv1.Event)
+		if !ok {
+			continue
+		}
+		if err := recorder.Record(k8sEvent); err != nil {
+			slog.Error("erro ao arquivar evento", logging.KeyNamespace, k8sEvent.Namespace, "name", k8sEvent.Name, logging.Err(err))
+		}
+	}
+}
+
 func watchPods(ctx context.Context) (watch.Interface, error) {
 	return k8s.Clientset.CoreV1().Pods("").Watch(ctx, metav1.ListOptions{})
 }
@@ -60,6 +94,10 @@ func watchEvents(ctx context.Context) (watch.Interface, error) {
 	return k8s.Clientset.CoreV1().Events("").Watch(ctx, metav1.ListOptions{})
 }
 
+func watchEventsV1(ctx context.Context) (watch.Interface, error) {
+	return k8s.Clientset.EventsV1().Events("").Watch(ctx, metav1.ListOptions{})
+}
+
 func watchNodes(ctx context.Context) (watch.Interface, error) {
 	return k8s.Clientset.CoreV1().Nodes().Watch(ctx, metav1.ListOptions{})
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
@@ -44,7 +45,7 @@ func TestStart(t *testing.T) {
 	}()
 
 	assert.NotPanics(t, func() {
-		Start(hub)
+		Start(hub, nil)
 	}, "Start não deve causar pânico")
 }
 
@@ -62,7 +63,7 @@ func TestRunWatcher_ErrorHandling(t *testing.T) {
 		return fakeWatcher, nil
 	}
 
-	go runWatcher(hub, "test-resource", watchFunc)
+	go runWatcher("test-resource", watchFunc, broadcastTo(hub, "test-resource"))
 
 	time.Sleep(100 * time.Millisecond)
 	assert.Equal(t, 1, errorCount, "A função de watch deveria ter sido chamada novamente após o erro")
@@ -89,3 +90,29 @@ func TestProcessWatcherEvents(t *testing.T) {
 		t.Fatal("Tempo esgotado esperando a mensagem no canal de broadcast")
 	}
 }
+
+type recorderStub struct {
+	recorded []string
+}
+
+func (r *recorderStub) Record(event *eventsv1.Event) error {
+	r.recorded = append(r.recorded, event.Name)
+	return nil
+}
+
+func TestRecordWatcherEvents(t *testing.T) {
+	recorder := &recorderStub{}
+
+	eventChan := make(chan watch.Event, 4)
+	eventChan <- watch.Event{Type: watch.Added, Object: &eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "criado"}}}
+	eventChan <- watch.Event{Type: watch.Modified, Object: &eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "atualizado"}}}
+	eventChan <- watch.Event{Type: watch.Deleted, Object: &eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "removido"}}}
+	// Objetos de outro tipo, como um evento core/v1, são ignorados.
+	eventChan <- watch.Event{Type: watch.Added, Object: &v1.Event{ObjectMeta: metav1.ObjectMeta{Name: "core"}}}
+	close(eventChan)
+
+	recordWatcherEvents(recorder, eventChan)
+
+	// Eventos removidos pelo TTL do Kubernetes continuam no arquivo.
+	assert.Equal(t, []string{"criado", "atualizado"}, recorder.recorded)
+}
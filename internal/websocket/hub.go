@@ -1,7 +1,8 @@
 package websocket
 
 import (
-	"log"
+	"kubeowl/internal/logging"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -39,7 +40,7 @@ func (c *Client) readPump() {
 	for {
 		if _, _, err := c.conn.ReadMessage(); err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("error: %v", err)
+				slog.Warn("conexão WebSocket encerrada inesperadamente", logging.Err(err))
 			}
 			break
 		}
@@ -99,12 +100,12 @@ func (h *Hub) Run() {
 		select {
 		case client := <-h.register:
 			h.clients[client] = true
-			log.Printf("Cliente WebSocket conectado. Clientes ativos: %d", len(h.clients))
+			slog.Info("cliente WebSocket conectado", "clients", len(h.clients))
 		case client := <-h.unregister:
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
 				close(client.send)
-				log.Printf("Cliente WebSocket desconectado. Clientes ativos: %d", len(h.clients))
+				slog.Info("cliente WebSocket desconectado", "clients", len(h.clients))
 			}
 		case message := <-h.Broadcast:
 			for client := range h.clients {
@@ -123,7 +124,7 @@ func (h *Hub) Run() {
 func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Println(err)
+		slog.WarnContext(r.Context(), "falha no upgrade para WebSocket", logging.Err(err))
 		return
 	}
 	client := &Client{hub: hub, conn: conn, send: make(chan []byte, 256)}
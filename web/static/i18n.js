@@ -0,0 +1,390 @@
+// Traduções da interface. O texto original em pt-BR é a chave; textos sem tradução aparecem como estão.
+// Parâmetros usam a forma {nome}.
+const I18N_DICTIONARIES = {
+    en: {
+        // Navegação e rodapé
+        'Nós': 'Nodes',
+        'Topologia': 'Topology',
+        'Configurações': 'Configuration',
+        'Armazenamento': 'Storage',
+        'Disco': 'Disk',
+        'Dimensionamento': 'Right-Sizing',
+        'Recursos': 'Resources',
+        'Eventos': 'Events',
+        'Idioma': 'Language',
+        'Aguardando dados...': 'Waiting for data...',
+        'Carregado: {time}': 'Loaded: {time}',
+        'Atualizado: {time}': 'Updated: {time}',
+        'Erro ao carregar dados.': 'Failed to load data.',
+
+        // Visão geral
+        'Visão Geral do Cluster': 'Cluster Overview',
+        'NÓS': 'NODES',
+        'Uso de CPU do Cluster': 'Cluster CPU Usage',
+        'Uso de Memória do Cluster': 'Cluster Memory Usage',
+        'Quotas em Alerta': 'Quotas in Alert',
+        'Recurso': 'Resource',
+        'Uso': 'Usage',
+        'Nenhum quota acima do limite de alerta.': 'No quota above the alert threshold.',
+        'Saúde do Cluster': 'Cluster Health',
+        'Pontuação: {score}/100': 'Score: {score}/100',
+        'Saudável': 'Healthy',
+        'Degradado': 'Degraded',
+        'Crítico': 'Critical',
+        'Aviso': 'Warning',
+        'Severidade': 'Severity',
+        'Problema': 'Issue',
+        'Nenhum problema encontrado.': 'No issues found.',
+
+        // Nós
+        'Nós do Cluster': 'Cluster Nodes',
+        'Pools de Nós': 'Node Pools',
+        'Nós Prontos': 'Ready Nodes',
+        'Papéis': 'Roles',
+        'Memória': 'Memory',
+        'Sem pool': 'No pool',
+        'Sem topologia': 'No topology',
+        'idade {age}': 'age {age}',
+        'Pods em Execução': 'Running Pods',
+        'Nenhum nó encontrado.': 'No nodes found.',
+
+        // Namespaces e pods
+        'Fase': 'Phase',
+        'Alertas Recentes': 'Recent Alerts',
+        'Nenhum namespace encontrado.': 'No namespaces found.',
+        'Pod / Namespace': 'Pod / Namespace',
+        'Nó': 'Node',
+        'Nenhum pod encontrado.': 'No pods found.',
+        'Nenhum pod': 'No pods',
+
+        // Services, Ingresses e Gateway API
+        'Nome': 'Name',
+        'Tipo': 'Type',
+        'Externo': 'External',
+        'Portas': 'Ports',
+        'Seletor': 'Selector',
+        'Afinidade de sessão: {affinity}': 'Session affinity: {affinity}',
+        'Nenhum Serviço encontrado.': 'No Services found.',
+        'Acessos Externos (Ingresses)': 'External Access (Ingresses)',
+        'Classe': 'Class',
+        'Caminhos': 'Paths',
+        'Endereços': 'Addresses',
+        'padrão': 'default',
+        'Nenhum Ingress encontrado.': 'No Ingresses found.',
+        'Controlador': 'Controller',
+        'Aceita': 'Accepted',
+        'Aceito': 'Accepted',
+        'Programado': 'Programmed',
+        'Rotas': 'Routes',
+        'Rotas (HTTPRoute e GRPCRoute)': 'Routes (HTTPRoute and GRPCRoute)',
+        'Sim': 'Yes',
+        'Não': 'No',
+        'CRDs gateway.networking.k8s.io instalados ({version}).': 'gateway.networking.k8s.io CRDs installed ({version}).',
+        'A Gateway API não está instalada neste cluster.': 'The Gateway API is not installed in this cluster.',
+        'Nenhuma GatewayClass encontrada.': 'No GatewayClasses found.',
+        'Nenhum Gateway encontrado.': 'No Gateways found.',
+        'Nenhuma rota encontrada.': 'No routes found.',
+
+        // Topologia
+        'Topologia de Tráfego': 'Traffic Topology',
+        'Ligações Quebradas': 'Broken Links',
+        'Motivo': 'Reason',
+        'Origem': 'Source',
+        'Destino': 'Target',
+        'Detalhe': 'Detail',
+        'Nenhuma ligação quebrada.': 'No broken links.',
+        'Nenhum caminho encontrado.': 'No paths found.',
+
+        // Configurações
+        'ConfigMaps e Secrets': 'ConfigMaps and Secrets',
+        'Chaves': 'Keys',
+        'Tamanho': 'Size',
+        'Usado por': 'Used by',
+        'Nenhum ConfigMap encontrado.': 'No ConfigMaps found.',
+        'Nenhum Secret encontrado.': 'No Secrets found.',
+
+        // Armazenamento e disco
+        'Capacidade': 'Capacity',
+        'Acesso': 'Access',
+        'Expansão': 'Expansion',
+        'Nenhum PVC encontrado.': 'No PVCs found.',
+        'Nenhum PV encontrado.': 'No PVs found.',
+        'Nenhum StorageClass encontrado.': 'No StorageClasses found.',
+        'Ocupação de Disco': 'Disk Usage',
+        'Pressão de Disco': 'Disk Pressure',
+        'Sistema de Arquivos': 'Filesystem',
+        'Imagens': 'Images',
+        'Contêineres': 'Containers',
+        'Contêiner': 'Container',
+        'Estatísticas indisponíveis': 'Statistics unavailable',
+        'Nenhum volume com estatísticas.': 'No volumes with statistics.',
+        'Nenhum contêiner com estatísticas.': 'No containers with statistics.',
+
+        'Dimensionamento de Recursos': 'Resource Right-Sizing',
+        'Recomendações por Workload': 'Recommendations by Workload',
+        'Situação': 'Status',
+        'Amostras': 'Samples',
+        'em {window}': 'over {window}',
+        'CPU (p95 / request → recomendado)': 'CPU (p95 / request → recommended)',
+        'Memória (p95 / request → recomendado)': 'Memory (p95 / request → recommended)',
+        'Subdimensionado': 'Under-provisioned',
+        'Superdimensionado': 'Over-provisioned',
+        'Sem request': 'No request',
+        'Adequado': 'Right-sized',
+        'Dados insuficientes': 'Insufficient data',
+        'limit {value}': 'limit {value}',
+        '{count} pods': '{count} pods',
+        'Janela de {window}; mínimo de {samples} amostras por contêiner.': 'Window of {window}; at least {samples} samples per container.',
+        'Nenhum workload com pods ativos.': 'No workloads with running pods.',
+
+        // Navegador de recursos
+        'Navegador de Recursos': 'Resource Browser',
+        'Namespace (todos)': 'Namespace (all)',
+        'Idade': 'Age',
+        'Falha ao listar recursos.': 'Failed to list resources.',
+        'Nenhum recurso encontrado.': 'No resources found.',
+
+        // Eventos
+        'Eventos Recentes do Cluster': 'Recent Cluster Events',
+        'Todos os tipos': 'All types',
+        'Kind (ex.: Pod)': 'Kind (e.g. Pod)',
+        'Nome do objeto': 'Object name',
+        'Qualquer período': 'Any time',
+        'Últimos 15 min': 'Last 15 min',
+        'Última hora': 'Last hour',
+        'Últimas 6 horas': 'Last 6 hours',
+        'Últimas 24 horas': 'Last 24 hours',
+        'Últimos 7 dias': 'Last 7 days',
+        'Buscar nos eventos': 'Search events',
+        'Histórico arquivado': 'Archived history',
+        'Carregar mais': 'Load more',
+        'Nenhum evento recente.': 'No recent events.',
+    },
+    es: {
+        // Navegación e rodapé
+        'Nós': 'Nodos',
+        'Topologia': 'Topología',
+        'Configurações': 'Configuración',
+        'Armazenamento': 'Almacenamiento',
+        'Disco': 'Disco',
+        'Dimensionamento': 'Dimensionamiento',
+        'Recursos': 'Recursos',
+        'Eventos': 'Eventos',
+        'Idioma': 'Idioma',
+        'Aguardando dados...': 'Esperando datos...',
+        'Carregado: {time}': 'Cargado: {time}',
+        'Atualizado: {time}': 'Actualizado: {time}',
+        'Erro ao carregar dados.': 'Error al cargar los datos.',
+
+        // Visão geral
+        'Visão Geral do Cluster': 'Vista General del Clúster',
+        'NÓS': 'NODOS',
+        'Uso de CPU do Cluster': 'Uso de CPU del Clúster',
+        'Uso de Memória do Cluster': 'Uso de Memoria del Clúster',
+        'Quotas em Alerta': 'Cuotas en Alerta',
+        'Quota': 'Cuota',
+        'Quotas': 'Cuotas',
+        'Recurso': 'Recurso',
+        'Uso': 'Uso',
+        'Nenhum quota acima do limite de alerta.': 'Ninguna cuota supera el umbral de alerta.',
+        'Saúde do Cluster': 'Salud del Clúster',
+        'Pontuação: {score}/100': 'Puntuación: {score}/100',
+        'Saudável': 'Saludable',
+        'Degradado': 'Degradado',
+        'Crítico': 'Crítico',
+        'Aviso': 'Advertencia',
+        'Severidade': 'Severidad',
+        'Problema': 'Problema',
+        'Nenhum problema encontrado.': 'No se encontraron problemas.',
+
+        // Nós
+        'Nós do Cluster': 'Nodos del Clúster',
+        'Pools de Nós': 'Pools de Nodos',
+        'Nós Prontos': 'Nodos Listos',
+        'Papéis': 'Roles',
+        'Memória': 'Memoria',
+        'Sem pool': 'Sin pool',
+        'Sem topologia': 'Sin topología',
+        'idade {age}': 'edad {age}',
+        'Pods em Execução': 'Pods en Ejecución',
+        'Nenhum nó encontrado.': 'No se encontraron nodos.',
+
+        // Namespaces e pods
+        'Fase': 'Fase',
+        'Alertas Recentes': 'Alertas Recientes',
+        'Nenhum namespace encontrado.': 'No se encontraron namespaces.',
+        'Nó': 'Nodo',
+        'Nenhum pod encontrado.': 'No se encontraron pods.',
+        'Nenhum pod': 'Ningún pod',
+
+        // Services, Ingresses e Gateway API
+        'Nome': 'Nombre',
+        'Tipo': 'Tipo',
+        'Externo': 'Externo',
+        'Portas': 'Puertos',
+        'Seletor': 'Selector',
+        'Afinidade de sessão: {affinity}': 'Afinidad de sesión: {affinity}',
+        'Nenhum Serviço encontrado.': 'No se encontraron Services.',
+        'Acessos Externos (Ingresses)': 'Accesos Externos (Ingresses)',
+        'Classe': 'Clase',
+        'Caminhos': 'Rutas',
+        'Endereços': 'Direcciones',
+        'padrão': 'predeterminado',
+        'Nenhum Ingress encontrado.': 'No se encontraron Ingresses.',
+        'Controlador': 'Controlador',
+        'Aceita': 'Aceptada',
+        'Aceito': 'Aceptado',
+        'Programado': 'Programado',
+        'Rotas': 'Rutas',
+        'Rotas (HTTPRoute e GRPCRoute)': 'Rutas (HTTPRoute y GRPCRoute)',
+        'Sim': 'Sí',
+        'Não': 'No',
+        'CRDs gateway.networking.k8s.io instalados ({version}).': 'CRDs gateway.networking.k8s.io instalados ({version}).',
+        'A Gateway API não está instalada neste cluster.': 'La Gateway API no está instalada en este clúster.',
+        'Nenhuma GatewayClass encontrada.': 'No se encontraron GatewayClasses.',
+        'Nenhum Gateway encontrado.': 'No se encontraron Gateways.',
+        'Nenhuma rota encontrada.': 'No se encontraron rutas.',
+
+        // Topologia
+        'Topologia de Tráfego': 'Topología de Tráfico',
+        'Ligações Quebradas': 'Enlaces Rotos',
+        'Motivo': 'Motivo',
+        'Origem': 'Origen',
+        'Destino': 'Destino',
+        'Detalhe': 'Detalle',
+        'Nenhuma ligação quebrada.': 'Ningún enlace roto.',
+        'Nenhum caminho encontrado.': 'No se encontraron rutas.',
+
+        // Configurações
+        'ConfigMaps e Secrets': 'ConfigMaps y Secrets',
+        'Chaves': 'Claves',
+        'Tamanho': 'Tamaño',
+        'Usado por': 'Usado por',
+        'Nenhum ConfigMap encontrado.': 'No se encontraron ConfigMaps.',
+        'Nenhum Secret encontrado.': 'No se encontraron Secrets.',
+
+        // Armazenamento e disco
+        'Capacidade': 'Capacidad',
+        'Acesso': 'Acceso',
+        'Expansão': 'Expansión',
+        'Nenhum PVC encontrado.': 'No se encontraron PVCs.',
+        'Nenhum PV encontrado.': 'No se encontraron PVs.',
+        'Nenhum StorageClass encontrado.': 'No se encontraron StorageClasses.',
+        'Ocupação de Disco': 'Ocupación de Disco',
+        'Pressão de Disco': 'Presión de Disco',
+        'Sistema de Arquivos': 'Sistema de Archivos',
+        'Imagens': 'Imágenes',
+        'Contêineres': 'Contenedores',
+        'Contêiner': 'Contenedor',
+        'Estatísticas indisponíveis': 'Estadísticas no disponibles',
+        'Nenhum volume com estatísticas.': 'Ningún volumen con estadísticas.',
+        'Nenhum contêiner com estatísticas.': 'Ningún contenedor con estadísticas.',
+
+        'Dimensionamento de Recursos': 'Dimensionamiento de Recursos',
+        'Recomendações por Workload': 'Recomendaciones por Workload',
+        'Situação': 'Estado',
+        'Amostras': 'Muestras',
+        'em {window}': 'en {window}',
+        'CPU (p95 / request → recomendado)': 'CPU (p95 / request → recomendado)',
+        'Memória (p95 / request → recomendado)': 'Memoria (p95 / request → recomendado)',
+        'Subdimensionado': 'Subdimensionado',
+        'Superdimensionado': 'Sobredimensionado',
+        'Sem request': 'Sin request',
+        'Adequado': 'Adecuado',
+        'Dados insuficientes': 'Datos insuficientes',
+        'limit {value}': 'limit {value}',
+        '{count} pods': '{count} pods',
+        'Janela de {window}; mínimo de {samples} amostras por contêiner.': 'Ventana de {window}; mínimo de {samples} muestras por contenedor.',
+        'Nenhum workload com pods ativos.': 'Ningún workload con pods activos.',
+
+        // Navegador de recursos
+        'Navegador de Recursos': 'Navegador de Recursos',
+        'Namespace (todos)': 'Namespace (todos)',
+        'Idade': 'Edad',
+        'Falha ao listar recursos.': 'Error al listar los recursos.',
+        'Nenhum recurso encontrado.': 'No se encontraron recursos.',
+
+        // Eventos
+        'Eventos Recentes do Cluster': 'Eventos Recientes del Clúster',
+        'Todos os tipos': 'Todos los tipos',
+        'Kind (ex.: Pod)': 'Kind (ej.: Pod)',
+        'Nome do objeto': 'Nombre del objeto',
+        'Qualquer período': 'Cualquier período',
+        'Últimos 15 min': 'Últimos 15 min',
+        'Última hora': 'Última hora',
+        'Últimas 6 horas': 'Últimas 6 horas',
+        'Últimas 24 horas': 'Últimas 24 horas',
+        'Últimos 7 dias': 'Últimos 7 días',
+        'Buscar nos eventos': 'Buscar en los eventos',
+        'Histórico arquivado': 'Historial archivado',
+        'Carregar mais': 'Cargar más',
+        'Nenhum evento recente.': 'Ningún evento reciente.',
+    },
+};
+
+const I18n = {
+    languages: { 'pt-BR': 'Português', en: 'English', es: 'Español' },
+    defaultLanguage: 'pt-BR',
+    // Mesmo nome do cookie lido pelo servidor para traduzir as mensagens de erro da API
+    cookieName: 'kubeowl-lang',
+    language: 'pt-BR',
+    // Textos e atributos originais da página estática, para retraduzi-los a cada troca de idioma
+    staticTexts: [],
+
+    // Escolhe o idioma salvo pelo usuário ou o primeiro idioma suportado do navegador
+    init() {
+        const candidates = [localStorage.getItem('lang'), ...(navigator.languages || [navigator.language])];
+        this.language = candidates.map(tag => this.match(tag)).find(Boolean) || this.defaultLanguage;
+        // Mantém o cookie em dia com a escolha salva, para as respostas da API seguirem o mesmo idioma
+        if (localStorage.getItem('lang')) this.persist();
+        this.collectStaticTexts();
+        this.apply();
+    },
+
+    match(tag) {
+        if (!tag) return null;
+        const base = tag.toLowerCase().split(/[-_]/)[0];
+        return Object.keys(this.languages).find(lang => lang.toLowerCase().split('-')[0] === base) || null;
+    },
+
+    t(text, params = {}) {
+        const dictionary = I18N_DICTIONARIES[this.language] || {};
+        const template = dictionary[text] || text;
+        return template.replace(/\{(\w+)\}/g, (match, name) => (name in params ? params[name] : match));
+    },
+
+    collectStaticTexts() {
+        const walker = document.createTreeWalker(document.body, NodeFilter.SHOW_TEXT);
+        while (walker.nextNode()) {
+            const node = walker.currentNode;
+            const text = node.nodeValue.trim();
+            if (text && node.parentElement.tagName !== 'SCRIPT') {
+                this.staticTexts.push({ node, text, apply: (value) => { node.nodeValue = node.nodeValue.replace(/\S.*\S|\S/s, value); } });
+            }
+        }
+        document.querySelectorAll('[placeholder], [title]').forEach(el => {
+            ['placeholder', 'title'].forEach(attr => {
+                const text = el.getAttribute(attr);
+                if (text) this.staticTexts.push({ text, apply: (value) => el.setAttribute(attr, value) });
+            });
+        });
+    },
+
+    apply() {
+        document.documentElement.lang = this.language;
+        this.staticTexts.forEach(entry => entry.apply(this.t(entry.text)));
+    },
+
+    setLanguage(lang) {
+        this.language = this.match(lang) || this.defaultLanguage;
+        this.persist();
+        this.apply();
+    },
+
+    persist() {
+        localStorage.setItem('lang', this.language);
+        document.cookie = `${this.cookieName}=${this.language}; path=/; max-age=31536000; SameSite=Lax`;
+    },
+};
+
+const t = (text, params) => I18n.t(text, params);
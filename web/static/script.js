@@ -12,7 +12,15 @@ class KubeOwlApp {
             services: [],
             ingresses: [],
             pvcs: [],
+            pvs: [],
+            storageclasses: [],
             events: [],
+            namespaces: [],
+            diskUsage: {},
+            healthReport: {},
+            rightsizing: {},
+            configmaps: [],
+            secrets: [],
             overview: {}
         };
         this.ws = null;
@@ -21,8 +29,12 @@ class KubeOwlApp {
     }
 
     init() {
+        I18n.init();
+        this.setupLanguage();
         this.setupTheme();
         this.setupNavigation();
+        this.setupResourceBrowser();
+        this.setupEventFilters();
         this.fetchInitialData();
         this.setupWebSocket();
         // Atualiza as métricas (CPU/Mem) periodicamente, já que não vêm pelo watch
@@ -57,6 +69,30 @@ class KubeOwlApp {
         });
     }
     
+    // Seletor de idioma: traduz a página estática e re-renderiza as seções a partir do cache
+    setupLanguage() {
+        const select = document.getElementById('language-select');
+        select.innerHTML = Object.entries(I18n.languages).map(([lang, label]) => `<option value="${lang}">${label}</option>`).join('');
+        select.value = I18n.language;
+        select.addEventListener('change', () => {
+            I18n.setLanguage(select.value);
+            this.renderLastUpdated();
+            this.renderAllSections();
+            this.fetchHealthReport();
+        });
+    }
+
+    // Guarda o estado do rodapé para que ele também acompanhe a troca de idioma
+    setLastUpdated(label) {
+        this.lastUpdated = { label, time: new Date().toLocaleTimeString() };
+        this.renderLastUpdated();
+    }
+
+    renderLastUpdated() {
+        if (!this.lastUpdated) return;
+        document.getElementById('last-updated').innerText = t(this.lastUpdated.label, { time: this.lastUpdated.time });
+    }
+
     setupNavigation() {
         const navLinks = document.querySelectorAll('.nav-link');
         const sections = document.querySelectorAll('.main-section');
@@ -77,20 +113,173 @@ class KubeOwlApp {
         });
     }
 
+    // Navegador genérico: carrega os tipos de recurso via discovery e lista os objetos do tipo escolhido
+    async setupResourceBrowser() {
+        const select = document.getElementById('resource-type-select');
+        const namespaceInput = document.getElementById('resource-namespace-input');
+        try {
+            const resources = await fetch('/api/v1/resources').then(res => res.json());
+            // Os recursos de CRDs aparecem primeiro, pois são o foco do navegador
+            resources.sort((a, b) => (b.custom - a.custom) || a.group.localeCompare(b.group) || a.resource.localeCompare(b.resource));
+            select.innerHTML = resources.map(r => {
+                const value = `${r.group || 'core'}/${r.version}/${r.resource}`;
+                return `<option value="${value}">${r.kind} (${r.group || 'core'}/${r.version})${r.custom ? ' · CRD' : ''}</option>`;
+            }).join('');
+        } catch (error) {
+            console.error("Erro ao buscar os tipos de recurso:", error);
+        }
+        const load = () => this.fetchResourceList(select.value, namespaceInput.value.trim());
+        select.addEventListener('change', load);
+        namespaceInput.addEventListener('change', load);
+        if (select.value) load();
+    }
+
+    // Filtros do feed de eventos: cada alteração recarrega a primeira página; "Carregar mais" busca a próxima
+    setupEventFilters() {
+        const ids = ['events-type-filter', 'events-namespace-filter', 'events-kind-filter', 'events-name-filter', 'events-reason-filter', 'events-since-filter', 'events-text-filter', 'events-history-toggle'];
+        ids.forEach(id => document.getElementById(id).addEventListener('change', () => this.fetchEvents(false)));
+        document.getElementById('events-load-more').addEventListener('click', () => this.fetchEvents(true));
+    }
+
+    eventQuery(continueToken) {
+        const params = new URLSearchParams({ limit: 50 });
+        if (continueToken) params.set('continue', continueToken);
+        const filters = {
+            type: 'events-type-filter', namespace: 'events-namespace-filter', kind: 'events-kind-filter',
+            name: 'events-name-filter', reason: 'events-reason-filter', since: 'events-since-filter',
+            q: 'events-text-filter',
+        };
+        Object.entries(filters).forEach(([param, id]) => {
+            const value = document.getElementById(id).value.trim();
+            if (value) params.set(param, value);
+        });
+        return params;
+    }
+
+    isEventHistory() {
+        return document.getElementById('events-history-toggle').checked;
+    }
+
+    hasEventFilters() {
+        return this.isEventHistory() || [...this.eventQuery().keys()].some(key => key !== 'limit');
+    }
+
+    async fetchEvents(append) {
+        const continueToken = append ? this.eventsContinue : '';
+        try {
+            const endpoint = this.isEventHistory() ? '/api/v1/events/history' : '/api/v1/events';
+            const res = await fetch(`${endpoint}?${this.eventQuery(continueToken)}`);
+            if (!res.ok) {
+                const { error } = await res.json();
+                document.getElementById('events-list').innerHTML = `<p>${error.message}</p>`;
+                document.getElementById('events-load-more').classList.add('hidden');
+                return;
+            }
+            const page = await res.json();
+            this.eventsContinue = page.continue || '';
+            this.dataCache.events = append ? this.dataCache.events.concat(page.items) : page.items;
+            this.renderEventFeed(this.dataCache.events);
+        } catch (error) {
+            console.error("Erro ao buscar eventos:", error);
+        }
+    }
+
+    async fetchResourceList(path, namespace) {
+        if (!path) return;
+        const query = namespace ? `?namespace=${encodeURIComponent(namespace)}` : '';
+        try {
+            const list = await fetch(`/api/v1/resources/${path}${query}`).then(res => res.json());
+            this.renderResourceList(path, list);
+        } catch (error) {
+            console.error("Erro ao listar recursos:", error);
+        }
+    }
+
+    renderResourceList(path, list) {
+        const head = document.getElementById('resource-table-head');
+        const body = document.getElementById('resource-table-body');
+        document.getElementById('resource-detail-card').classList.add('hidden');
+        if (!list.items) {
+            head.innerHTML = '';
+            body.innerHTML = `<tr><td style="text-align: center; padding: 2rem;">${list.error?.message || t('Falha ao listar recursos.')}</td></tr>`;
+            return;
+        }
+        const namespaced = list.resource.namespaced;
+        head.innerHTML = `<tr>${namespaced ? '<th>Namespace</th>' : ''}<th>${t('Nome')}</th>${list.columns.map(c => `<th title="${c.description || ''}">${c.name}</th>`).join('')}<th>${t('Idade')}</th></tr>`;
+        const colspan = list.columns.length + (namespaced ? 3 : 2);
+        body.innerHTML = list.items.length ? list.items.map(item => `
+            <tr class="resource-row" data-name="${item.name}" data-namespace="${item.namespace || ''}" style="cursor: pointer;">
+                ${namespaced ? `<td>${item.namespace}</td>` : ''}
+                <td><b>${item.name}</b></td>
+                ${item.cells.map(cell => `<td style="font-family: monospace;">${cell || '-'}</td>`).join('')}
+                <td>${item.age}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="${colspan}" style="text-align: center; padding: 2rem;">${t('Nenhum recurso encontrado.')}</td></tr>`;
+        body.querySelectorAll('.resource-row').forEach(row => {
+            row.addEventListener('click', () => this.fetchResourceDetail(path, row.dataset.namespace, row.dataset.name));
+        });
+    }
+
+    async fetchResourceDetail(path, namespace, name) {
+        const query = namespace ? `?namespace=${encodeURIComponent(namespace)}` : '';
+        try {
+            const obj = await fetch(`/api/v1/resources/${path}/${encodeURIComponent(name)}${query}`).then(res => res.json());
+            document.getElementById('resource-detail-title').innerText = namespace ? `${namespace}/${name}` : name;
+            document.getElementById('resource-detail').textContent = JSON.stringify(obj, null, 2);
+            document.getElementById('resource-detail-card').classList.remove('hidden');
+        } catch (error) {
+            console.error("Erro ao buscar recurso:", error);
+        }
+    }
+
     // Busca todos os dados uma vez para popular a UI rapidamente
     async fetchInitialData() {
-        const endpoints = ['overview', 'nodes', 'pods', 'services', 'ingresses', 'pvcs', 'events'];
+        const endpoints = ['overview', 'nodes', 'pods', 'services', 'ingresses', 'pvcs', 'events', 'namespaces', 'configmaps', 'secrets', 'pvs', 'storageclasses', 'node-pools', 'topology', 'gateways'];
         try {
-            const promises = endpoints.map(e => fetch(`/api/${e}`).then(res => res.json()));
-            const [overview, nodes, pods, services, ingresses, pvcs, events] = await Promise.all(promises);
+            const promises = endpoints.map(e => fetch(`/api/v1/${e === 'events' ? 'events?limit=50' : e}`).then(res => res.json()));
+            const [overview, nodes, pods, services, ingresses, pvcs, events, namespaces, configmaps, secrets, pvs, storageclasses, nodePools, topology, gateways] = await Promise.all(promises);
             
-            this.dataCache = { overview, nodes, pods, services, ingresses, pvcs, events };
+            this.eventsContinue = events.continue || '';
+            this.dataCache = { overview, nodes, pods, services, ingresses, pvcs, events: events.items, namespaces, configmaps, secrets, pvs, storageclasses, nodePools, topology, gateways, diskUsage: {}, healthReport: {}, rightsizing: {} };
+            this.fetchDiskUsage();
+            this.fetchHealthReport();
+            this.fetchRightsizing();
             
-            document.getElementById('last-updated').innerText = `Carregado: ${new Date().toLocaleTimeString()}`;
+            this.setLastUpdated('Carregado: {time}');
             this.renderAllSections();
         } catch (error) {
             console.error("Erro ao buscar dados iniciais:", error);
-            document.getElementById('last-updated').innerText = "Erro ao carregar dados.";
+            this.setLastUpdated('Erro ao carregar dados.');
+        }
+    }
+
+    // A ocupação de disco consulta o kubelet de cada nó, por isso é carregada separadamente
+    async fetchDiskUsage() {
+        try {
+            this.dataCache.diskUsage = await fetch('/api/v1/disk-usage').then(res => res.json());
+            this.renderDiskUsageView(this.dataCache.diskUsage);
+        } catch (error) {
+            console.error("Erro ao buscar ocupação de disco:", error);
+        }
+    }
+
+    // As mensagens dos achados vêm traduzidas do servidor, por isso o relatório é recarregado na troca de idioma
+    async fetchHealthReport() {
+        try {
+            this.dataCache.healthReport = await fetch('/api/v1/health-report').then(res => res.json());
+            this.renderHealthReport(this.dataCache.healthReport);
+        } catch (error) {
+            console.error("Erro ao buscar relatório de saúde:", error);
+        }
+    }
+
+    // As recomendações dependem do histórico de uso do servidor e mudam devagar, por isso são carregadas à parte
+    async fetchRightsizing() {
+        try {
+            this.dataCache.rightsizing = await fetch('/api/v1/rightsizing').then(res => res.json());
+            this.renderRightsizingView(this.dataCache.rightsizing);
+        } catch (error) {
+            console.error("Erro ao buscar recomendações de dimensionamento:", error);
         }
     }
 
@@ -98,8 +287,8 @@ class KubeOwlApp {
     async fetchMetrics() {
         try {
             const [nodes, pods] = await Promise.all([
-                fetch('/api/nodes').then(res => res.json()),
-                fetch('/api/pods').then(res => res.json())
+                fetch('/api/v1/nodes').then(res => res.json()),
+                fetch('/api/v1/pods').then(res => res.json())
             ]);
 
             // Atualiza o cache de nós e pods com as novas métricas
@@ -145,7 +334,7 @@ class KubeOwlApp {
         const resource = payload.object;
         const eventType = payload.type; // ADDED, MODIFIED, DELETED
 
-        document.getElementById('last-updated').innerText = `Atualizado: ${new Date().toLocaleTimeString()}`;
+        this.setLastUpdated('Atualizado: {time}');
         const indicator = document.getElementById('update-indicator');
         indicator.style.backgroundColor = 'var(--blue-500)';
         setTimeout(() => { indicator.style.backgroundColor = 'var(--green-500)'; }, 500);
@@ -162,7 +351,9 @@ class KubeOwlApp {
                 });
                 break;
             case 'events':
-                 // Eventos são sempre adicionados
+                // Com filtros ativos o feed mostra apenas o resultado da consulta
+                if (this.hasEventFilters()) break;
+                // Eventos são sempre adicionados
                 const eventInfo = this.processEventInfo(resource);
                 this.dataCache.events.unshift(eventInfo);
                 if (this.dataCache.events.length > 50) this.dataCache.events.pop();
@@ -235,12 +426,17 @@ class KubeOwlApp {
     }
     
     processEventInfo(event) {
+        // Eventos criados pela API events.k8s.io/v1 não têm lastTimestamp
+        const lastSeen = event.lastTimestamp || (event.series && event.series.lastObservedTime) || event.eventTime || event.firstTimestamp;
         return {
-            timestamp: new Date(event.lastTimestamp || event.eventTime).toLocaleString(),
+            timestamp: new Date(lastSeen).toLocaleString(),
             type: event.type,
             reason: event.reason,
             object: `${event.involvedObject.kind}/${event.involvedObject.name}`,
             message: event.message,
+            namespace: event.metadata.namespace,
+            count: (event.series && event.series.count) || event.count || 1,
+            reportingController: event.reportingComponent || (event.source && event.source.component) || '',
         };
     }
     
@@ -248,11 +444,38 @@ class KubeOwlApp {
     renderAllSections() {
         this.renderOverview(this.dataCache.overview);
         this.renderNodeList(this.dataCache.nodes);
+        this.renderNodePools(this.dataCache.nodePools);
         this.renderPodTable(this.dataCache.pods);
         this.renderServicesView(this.dataCache.services);
         this.renderIngressesView(this.dataCache.ingresses);
+        this.renderTopologyView(this.dataCache.topology);
+        this.renderGatewaysView(this.dataCache.gateways);
         this.renderEventFeed(this.dataCache.events);
-        this.renderStorageView(this.dataCache.pvcs);
+        this.renderStorageView(this.dataCache.pvcs, this.dataCache.pvs, this.dataCache.storageclasses);
+        this.renderNamespacesView(this.dataCache.namespaces);
+        this.renderConfigsView(this.dataCache.configmaps, this.dataCache.secrets);
+        this.renderDiskUsageView(this.dataCache.diskUsage);
+        this.renderHealthReport(this.dataCache.healthReport);
+        this.renderRightsizingView(this.dataCache.rightsizing);
+    }
+
+    formatBytes(bytes) {
+        const units = ['B', 'KiB', 'MiB', 'GiB', 'TiB'];
+        let value = bytes;
+        let unit = 0;
+        while (value >= 1024 && unit < units.length - 1) {
+            value /= 1024;
+            unit++;
+        }
+        return `${value.toFixed(unit === 0 ? 0 : 1)} ${units[unit]}`;
+    }
+
+    // Formata a ocupação de um sistema de arquivos como "usado / capacidade (%)"
+    formatFsUsage(fs) {
+        if (!fs) return '-';
+        const capacity = fs.capacityBytes ? ` / ${this.formatBytes(fs.capacityBytes)}` : '';
+        const percentage = fs.capacityBytes ? ` (${fs.usagePercentage.toFixed(0)}%)` : '';
+        return `${this.formatBytes(fs.usedBytes)}${capacity}${percentage}`;
     }
 
     renderOverview(data) {
@@ -264,6 +487,48 @@ class KubeOwlApp {
         document.getElementById('deployments-count').innerText = data.deploymentCount || 0;
         document.getElementById('namespaces-count').innerText = data.namespaceCount || 0;
         this.renderCapacityView(data.capacity);
+        this.renderQuotaAlerts(data.quotaAlerts || []);
+        this.renderHealthSummary(data.health);
+    }
+
+    // Resumo da saúde, vindo da visão geral ou do relatório completo
+    renderHealthSummary(summary) {
+        if (!summary) return;
+        const badges = { critical: 'status-failed', degraded: 'status-pending', healthy: 'status-running' };
+        const labels = { healthy: 'Saudável', degraded: 'Degradado', critical: 'Crítico' };
+        document.getElementById('health-score').innerText = t('Pontuação: {score}/100', { score: summary.score });
+        document.getElementById('health-status').innerHTML = `<span class="status-badge ${badges[summary.status]}">${t(labels[summary.status])}</span>`;
+    }
+
+    renderHealthReport(report) {
+        if (!report || !report.summary) return;
+        const badges = { critical: 'status-failed', warning: 'status-pending', info: 'status-running' };
+        const severityLabels = { critical: 'Crítico', warning: 'Aviso', info: 'Info' };
+        this.renderHealthSummary(report.summary);
+
+        const findings = report.findings || [];
+        const tableBody = document.getElementById('health-findings-table-body');
+        tableBody.innerHTML = findings.length ? findings.map(finding => `
+             <tr>
+                <td><span class="status-badge ${badges[finding.severity]}">${t(severityLabels[finding.severity])}</span></td>
+                <td>${finding.kind}</td>
+                <td><b>${finding.namespace ? `${finding.namespace}/` : ''}${finding.name}</b></td>
+                <td>${finding.message}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="4" style="text-align: center; padding: 2rem;">${t('Nenhum problema encontrado.')}</td></tr>`;
+    }
+
+    renderQuotaAlerts(alerts) {
+        const tableBody = document.getElementById('quota-alerts-table-body');
+        tableBody.innerHTML = alerts.length ? alerts.map(alert => `
+             <tr>
+                <td>${alert.namespace}</td>
+                <td><b>${alert.quota}</b></td>
+                <td style="font-family: monospace;">${alert.resource}</td>
+                <td style="font-family: monospace;">${alert.used} / ${alert.hard}</td>
+                <td><span class="status-badge ${alert.percentage >= 100 ? 'status-failed' : 'status-pending'}">${alert.percentage.toFixed(0)}%</span></td>
+            </tr>`
+        ).join('') : `<tr><td colspan="5" style="text-align: center; padding: 2rem;">${t('Nenhum quota acima do limite de alerta.')}</td></tr>`;
     }
 
     renderCapacityView(capacity) {
@@ -286,7 +551,15 @@ class KubeOwlApp {
             <div class="card node-card" id="node-${node.name}">
                 <div class="node-header">
                     <h4>${node.name}</h4>
-                    ${node.role === 'Control-Plane' ? '<span class="node-role">MASTER</span>' : ''}
+                    <div>${(node.roles || []).map(role => `<span class="node-role">${role}</span>`).join(' ')}</div>
+                </div>
+                ${node.pool && node.pool !== 'none' ? `<div class="node-pool">Pool: ${node.pool}</div>` : ''}
+                <div class="node-badges">${this.renderNodeBadges(node)}</div>
+                <div class="node-system-info">
+                    <span>${node.kubeletVersion || '-'} · ${node.containerRuntime || '-'}</span>
+                    <span>${node.osImage || '-'} (${node.architecture || '-'}) · kernel ${node.kernelVersion || '-'}</span>
+                    <span>${(node.internalIps || []).join(', ') || '-'}${node.externalIps && node.externalIps.length ? ` · ${node.externalIps.join(', ')}` : ''}</span>
+                    <span>${[node.region, node.zone].filter(Boolean).join(' / ') || t('Sem topologia')} · ${t('idade {age}', { age: node.age || '-' })}</span>
                 </div>
                 <div>
                     <div class="node-metric-label">
@@ -297,19 +570,57 @@ class KubeOwlApp {
                 </div>
                 <div>
                     <div class="node-metric-label">
-                        <span>Memória</span>
+                        <span>${t('Memória')}</span>
                         <span style="font-family: monospace;">${node.usedMemory} / ${node.totalMemory}</span>
                     </div>
                     <div class="progress-bar-bg"><div class="progress-bar bg-green" style="width: ${node.memoryUsagePercentage.toFixed(2)}%"></div></div>
                 </div>
+                ${node.filesystem ? `
+                <div>
+                    <div class="node-metric-label">
+                        <span>${t('Disco')}</span>
+                        <span style="font-family: monospace;">${this.formatFsUsage(node.filesystem)}</span>
+                    </div>
+                    <div class="progress-bar-bg"><div class="progress-bar bg-blue" style="width: ${node.filesystem.usagePercentage.toFixed(2)}%"></div></div>
+                </div>` : ''}
                 <div class="node-pods-count">
-                     <span>Pods em Execução</span>
-                     <span class="count">${node.podCount}</span>
+                     <span>${t('Pods em Execução')}</span>
+                     <span class="count">${node.runningPods ?? node.podCount}${node.allocatablePods ? ` / ${node.allocatablePods}` : ''}</span>
                 </div>
             </div>
         `).join('');
     }
 
+    // Resume os nós agrupados por pool
+    renderNodePools(pools) {
+        const tbody = document.getElementById('node-pools-table-body');
+        if (!tbody || !pools) return;
+        tbody.innerHTML = pools.map(pool => `
+            <tr>
+                <td><b>${pool.name === 'none' ? t('Sem pool') : pool.name}</b></td>
+                <td>${pool.readyNodes} / ${pool.nodeCount}</td>
+                <td>${(pool.roles || []).join(', ')}</td>
+                <td>${pool.cpuUsagePercentage.toFixed(2)}%</td>
+                <td>${pool.memoryUsagePercentage.toFixed(2)}%</td>
+            </tr>
+        `).join('');
+    }
+
+    // Gera os badges de prontidão, pressão, cordon e taints de um nó
+    renderNodeBadges(node) {
+        const badges = [node.ready
+            ? '<span class="status-badge status-running">Ready</span>'
+            : '<span class="status-badge status-failed">NotReady</span>'];
+        if (node.memoryPressure) badges.push('<span class="status-badge status-failed">MemoryPressure</span>');
+        if (node.diskPressure) badges.push('<span class="status-badge status-failed">DiskPressure</span>');
+        if (node.pidPressure) badges.push('<span class="status-badge status-failed">PIDPressure</span>');
+        if (node.unschedulable) badges.push('<span class="status-badge status-pending">Cordoned</span>');
+        (node.taints || []).forEach(taint => {
+            badges.push(`<span class="status-badge status-unknown" title="${taint.effect}">${taint.key}${taint.value ? `=${taint.value}` : ''}:${taint.effect}</span>`);
+        });
+        return badges.join(' ');
+    }
+
     getPodStatusClass(status) {
         if (!status) return 'status-unknown';
         const s = status.toLowerCase();
@@ -341,11 +652,11 @@ class KubeOwlApp {
         // Adiciona a lógica para renderizar o cabeçalho e os listeners de ordenação
         const headers = [
             { name: 'Pod / Namespace', key: 'name' },
-            { name: 'Nó', key: 'nodeName' },
+            { name: t('Nó'), key: 'nodeName' },
             { name: 'Status', key: 'status' },
             { name: 'Restarts', key: 'restarts' },
             { name: 'CPU', key: 'usedCpuMilli' },
-            { name: 'Memória', key: 'usedMemoryBytes' },
+            { name: t('Memória'), key: 'usedMemoryBytes' },
         ];
         
         tableHeader.innerHTML = headers.map(h => 
@@ -383,7 +694,7 @@ class KubeOwlApp {
         });
 
         if (pods.length === 0) {
-            tableBody.innerHTML = '<tr><td colspan="6" style="text-align: center; padding: 2rem;">Nenhum pod encontrado.</td></tr>';
+            tableBody.innerHTML = `<tr><td colspan="6" style="text-align: center; padding: 2rem;">${t('Nenhum pod encontrado.')}</td></tr>`;
             return;
         }
         
@@ -395,30 +706,126 @@ class KubeOwlApp {
         });
     }
 
+    // Gera o badge de uma condição da Gateway API ("True", "False", "Unknown" ou ausente)
+    renderConditionBadge(status) {
+        if (status === 'True') return `<span class="status-badge status-running">${t('Sim')}</span>`;
+        if (status === 'False') return `<span class="status-badge status-failed">${t('Não')}</span>`;
+        return '<span class="status-badge status-unknown">-</span>';
+    }
+
+    renderGatewaysView(gatewayApi) {
+        if (!gatewayApi) return;
+        document.getElementById('gateway-api-status').innerText = gatewayApi.installed
+            ? t('CRDs gateway.networking.k8s.io instalados ({version}).', { version: gatewayApi.version })
+            : t('A Gateway API não está instalada neste cluster.');
+
+        document.getElementById('gatewayclasses-table-body').innerHTML = gatewayApi.gatewayClasses.length ? gatewayApi.gatewayClasses.map(gc => `
+            <tr>
+                <td><b>${gc.name}</b></td>
+                <td style="font-family: monospace;">${gc.controller}</td>
+                <td>${this.renderConditionBadge(gc.accepted)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="3" style="text-align: center; padding: 2rem;">${t('Nenhuma GatewayClass encontrada.')}</td></tr>`;
+
+        document.getElementById('gateways-table-body').innerHTML = gatewayApi.gateways.length ? gatewayApi.gateways.map(gw => `
+            <tr>
+                <td>${gw.namespace}</td>
+                <td><b>${gw.name}</b></td>
+                <td>${gw.gatewayClass}</td>
+                <td style="font-family: monospace;">${gw.listeners.map(l => `${l.name} ${l.protocol}/${l.port}${l.hostname ? ` ${l.hostname}` : ''} (${l.attachedRoutes})`).join('<br>')}</td>
+                <td>${gw.attachedRoutes}</td>
+                <td style="font-family: monospace;">${gw.addresses.join('<br>') || '-'}</td>
+                <td>${this.renderConditionBadge(gw.accepted)}</td>
+                <td>${this.renderConditionBadge(gw.programmed)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="8" style="text-align: center; padding: 2rem;">${t('Nenhum Gateway encontrado.')}</td></tr>`;
+
+        const routes = [...gatewayApi.httpRoutes, ...gatewayApi.grpcRoutes];
+        document.getElementById('routes-table-body').innerHTML = routes.length ? routes.map(route => `
+            <tr>
+                <td>${route.kind}</td>
+                <td>${route.namespace}</td>
+                <td><b>${route.name}</b></td>
+                <td style="font-family: monospace;">${route.hostnames.join('<br>') || '*'}</td>
+                <td>${route.parentRefs.map(p => `${p.namespace}/${p.name}${p.sectionName ? `#${p.sectionName}` : ''} ${this.renderConditionBadge(p.accepted)}`).join('<br>')}</td>
+                <td style="font-family: monospace;">${route.backends.join('<br>') || '-'}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="6" style="text-align: center; padding: 2rem;">${t('Nenhuma rota encontrada.')}</td></tr>`;
+    }
+
+    // Lista as ligações quebradas e os caminhos Ingress → Service → EndpointSlice → Pod → Nó
+    renderTopologyView(topology) {
+        if (!topology) return;
+        const brokenBody = document.getElementById('topology-broken-table-body');
+        const broken = topology.nodes.filter(node => node.broken);
+        brokenBody.innerHTML = broken.length ? broken.map(node => `
+            <tr>
+                <td><span class="status-badge status-failed">${node.kind}</span></td>
+                <td>${node.namespace || '-'}</td>
+                <td><b>${node.name}</b></td>
+                <td>${node.reason || '-'}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="4" style="text-align: center; padding: 2rem;">${t('Nenhuma ligação quebrada.')}</td></tr>`;
+
+        const labelOf = (id) => id.split('/').filter(Boolean).join(' / ');
+        const edgesBody = document.getElementById('topology-edges-table-body');
+        edgesBody.innerHTML = topology.edges.length ? topology.edges.map(edge => `
+            <tr${edge.broken ? ' class="topology-broken"' : ''}>
+                <td style="font-family: monospace;">${labelOf(edge.source)}</td>
+                <td style="font-family: monospace;">${labelOf(edge.target)}</td>
+                <td>${edge.label || '-'}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="3" style="text-align: center; padding: 2rem;">${t('Nenhum caminho encontrado.')}</td></tr>`;
+    }
+
     renderServicesView(services) {
         const servicesTableBody = document.getElementById('services-table-body');
-        servicesTableBody.innerHTML = services.length ? services.map(service => `
+        const formatPort = (port) => `${port.name ? `${port.name} ` : ''}${port.port}${port.targetPort && port.targetPort !== String(port.port) ? `→${port.targetPort}` : ''}${port.nodePort ? ` (node ${port.nodePort})` : ''}/${port.protocol}`;
+        servicesTableBody.innerHTML = services.length ? services.map(service => {
+            const external = service.externalName || service.externalIps.join('<br>') || 'N/A';
+            const selector = Object.entries(service.selector).map(([k, v]) => `${k}=${v}`).join('<br>') || '-';
+            const endpointsClass = service.type === 'ExternalName' ? 'status-unknown'
+                : (service.readyEndpoints > 0 ? (service.notReadyEndpoints > 0 ? 'status-pending' : 'status-running') : 'status-failed');
+            const endpoints = service.type === 'ExternalName' ? '-' : `${service.readyEndpoints} / ${service.readyEndpoints + service.notReadyEndpoints}`;
+            return `
              <tr>
                 <td>${service.namespace}</td>
                 <td><b>${service.name}</b></td>
-                <td style="font-family: monospace;">${service.type}</td>
+                <td style="font-family: monospace;" title="${t('Afinidade de sessão: {affinity}', { affinity: service.sessionAffinity || 'None' })}">${service.type}</td>
                 <td style="font-family: monospace;">${service.clusterIp || 'N/A'}</td>
-                <td style="font-family: monospace;">${service.externalIp || 'N/A'}</td>
-                <td style="font-family: monospace;">${service.ports}</td>
-            </tr>`
-        ).join('') : '<tr><td colspan="6" style="text-align: center; padding: 2rem;">Nenhum Serviço encontrado.</td></tr>';
+                <td style="font-family: monospace;">${external}</td>
+                <td style="font-family: monospace;">${service.ports.map(formatPort).join('<br>') || '-'}</td>
+                <td style="font-family: monospace;">${selector}</td>
+                <td><span class="status-badge ${endpointsClass}">${endpoints}</span></td>
+            </tr>`;
+        }).join('') : `<tr><td colspan="8" style="text-align: center; padding: 2rem;">${t('Nenhum Serviço encontrado.')}</td></tr>`;
     }
 
     renderIngressesView(ingresses) {
         const ingressesTableBody = document.getElementById('ingresses-table-body');
-        ingressesTableBody.innerHTML = ingresses.length ? ingresses.map(ingress => `
+        const formatBackend = (backend) => backend.service
+            ? `${backend.service}${backend.port ? `:${backend.port}` : ''}`
+            : (backend.resource || '-');
+        ingressesTableBody.innerHTML = ingresses.length ? ingresses.map(ingress => {
+            const tlsHosts = new Set((ingress.tls || []).flatMap(tls => tls.hosts));
+            const hosts = ingress.hosts.map(host => {
+                const scheme = tlsHosts.has(host) ? 'https' : 'http';
+                return `<a href="${scheme}://${host}" target="_blank" style="color: var(--blue-500); text-decoration: none;">${host}</a>`;
+            }).join('<br>');
+            const paths = ingress.rules.flatMap(rule => rule.paths.map(path =>
+                `${rule.host || '*'}${path.path || '/'}${path.pathType ? ` (${path.pathType})` : ''} → ${formatBackend(path.backend)}`));
+            if (ingress.defaultBackend) paths.push(`${t('padrão')} → ${formatBackend(ingress.defaultBackend)}`);
+            return `
              <tr>
                 <td>${ingress.namespace}</td>
                 <td><b>${ingress.name}</b></td>
-                <td style="font-family: monospace;"><a href="http://${ingress.hosts.split(',')[0]}" target="_blank" style="color: var(--blue-500); text-decoration: none;">${ingress.hosts}</a></td>
-                <td style="font-family: monospace;">${ingress.service}</td>
-            </tr>`
-        ).join('') : '<tr><td colspan="4" style="text-align: center; padding: 2rem;">Nenhum Ingress encontrado.</td></tr>';
+                <td style="font-family: monospace;" title="${ingress.classController || ''}">${ingress.ingressClass || '-'}</td>
+                <td style="font-family: monospace;">${hosts || '*'}</td>
+                <td style="font-family: monospace;">${paths.join('<br>') || '-'}</td>
+                <td style="font-family: monospace;">${ingress.tls.map(tls => tls.secretName || '-').join('<br>') || '-'}</td>
+                <td style="font-family: monospace;">${ingress.addresses.join('<br>') || '-'}</td>
+            </tr>`;
+        }).join('') : `<tr><td colspan="7" style="text-align: center; padding: 2rem;">${t('Nenhum Ingress encontrado.')}</td></tr>`;
     }
     
     createEventCard(event) {
@@ -428,8 +835,8 @@ class KubeOwlApp {
         div.style.borderLeftColor = eventTypeBorders[event.type] || 'var(--gray-500)';
         div.innerHTML = `
             <div class="event-header">
-                <b>${event.reason}</b>
-                <span>${event.timestamp}</span>
+                <b>${event.reason}${event.count > 1 ? ` ×${event.count}` : ''}</b>
+                <span>${event.namespace ? `${event.namespace} · ` : ''}${event.reportingController ? `${event.reportingController} · ` : ''}${event.timestamp}</span>
             </div>
             <p class="event-message"><b>${event.object}:</b> ${event.message}</p>`;
         return div;
@@ -443,19 +850,178 @@ class KubeOwlApp {
                 eventsList.appendChild(this.createEventCard(event));
             });
         } else {
-            eventsList.innerHTML = '<p>Nenhum evento recente.</p>';
+            eventsList.innerHTML = `<p>${t('Nenhum evento recente.')}</p>`;
         }
+        document.getElementById('events-load-more').classList.toggle('hidden', !this.eventsContinue);
     }
 
-    renderStorageView(pvcs) {
+    renderStorageView(pvcs, pvs, storageClasses) {
         const pvcsTableBody = document.getElementById('pvcs-table-body');
         pvcsTableBody.innerHTML = pvcs.length ? pvcs.map(pvc => `
              <tr>
                 <td>${pvc.namespace}</td>
                 <td><b>${pvc.name}</b></td>
                 <td><span class="status-badge ${pvc.status === 'Bound' ? 'status-bound' : 'status-pending'}">${pvc.status}</span></td>
-                <td style="font-family: monospace;">${pvc.capacity}</td>
+                <td style="font-family: monospace;">${pvc.boundCapacity || pvc.capacity}</td>
+                <td style="font-family: monospace;">${this.formatFsUsage(pvc.usage)}</td>
+                <td style="font-family: monospace;">${pvc.volumeName || '-'}</td>
+                <td style="font-family: monospace;">${pvc.storageClass || '-'}</td>
+                <td style="font-family: monospace;">${(pvc.accessModes || []).join(', ') || '-'}</td>
+                <td style="font-size: 0.8rem;">${(pvc.usedBy || []).join(', ') || '-'}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="9" style="text-align: center; padding: 2rem;">${t('Nenhum PVC encontrado.')}</td></tr>`;
+
+        const pvsTableBody = document.getElementById('pvs-table-body');
+        pvsTableBody.innerHTML = pvs.length ? pvs.map(pv => `
+             <tr>
+                <td><b>${pv.name}</b></td>
+                <td><span class="status-badge ${pv.status === 'Bound' ? 'status-bound' : pv.status === 'Failed' ? 'status-failed' : 'status-pending'}">${pv.status}</span></td>
+                <td style="font-family: monospace;">${pv.capacity}</td>
+                <td style="font-family: monospace;">${pv.claim || '-'}</td>
+                <td style="font-family: monospace;">${pv.storageClass || '-'}</td>
+                <td>${pv.reclaimPolicy}</td>
+                <td style="font-family: monospace;">${pv.source}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="7" style="text-align: center; padding: 2rem;">${t('Nenhum PV encontrado.')}</td></tr>`;
+
+        const scTableBody = document.getElementById('storageclasses-table-body');
+        scTableBody.innerHTML = storageClasses.length ? storageClasses.map(sc => `
+             <tr>
+                <td><b>${sc.name}</b> ${sc.isDefault ? `<span class="status-badge status-bound">${t('padrão')}</span>` : ''}</td>
+                <td style="font-family: monospace;">${sc.provisioner}</td>
+                <td>${sc.reclaimPolicy || '-'}</td>
+                <td>${sc.volumeBindingMode || '-'}</td>
+                <td>${sc.allowVolumeExpansion ? t('Sim') : t('Não')}</td>
+                <td style="font-family: monospace; text-align: center;">${sc.volumeCount}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="6" style="text-align: center; padding: 2rem;">${t('Nenhum StorageClass encontrado.')}</td></tr>`;
+    }
+
+    renderNamespacesView(namespaces) {
+        const namespacesTableBody = document.getElementById('namespaces-table-body');
+        namespacesTableBody.innerHTML = namespaces.length ? namespaces.map(ns => {
+            const podsByStatus = Object.entries(ns.podsByStatus || {})
+                .map(([status, count]) => `<span class="status-badge ${this.getPodStatusClass(status)}">${status}: ${count}</span>`)
+                .join(' ');
+            const quotaUsage = (ns.quotas || []).flatMap(q => q.resources)
+                .reduce((max, r) => Math.max(max, r.percentage), 0);
+            const warnings = (ns.warningEvents || []).map(e => `<div><b>${e.reason}</b> ${e.object}</div>`).join('');
+            return `
+             <tr>
+                <td><b>${ns.name}</b></td>
+                <td>${ns.phase}</td>
+                <td><div>${ns.podCount}</div><div>${podsByStatus}</div></td>
+                <td style="font-family: monospace;">${ns.usedCpu}</td>
+                <td style="font-family: monospace;">${ns.usedMemory}</td>
+                <td style="font-family: monospace;">${ns.quotas && ns.quotas.length ? `${quotaUsage.toFixed(0)}%` : '-'}</td>
+                <td style="font-size: 0.8rem;">${warnings || '-'}</td>
+            </tr>`;
+        }).join('') : `<tr><td colspan="7" style="text-align: center; padding: 2rem;">${t('Nenhum namespace encontrado.')}</td></tr>`;
+    }
+
+    renderConfigsView(configmaps, secrets) {
+        const formatSize = (bytes) => bytes >= 1024 ? `${(bytes / 1024).toFixed(1)} KiB` : `${bytes} B`;
+        const formatUsage = (usedBy) => usedBy.length
+            ? [...new Set(usedBy.map(ref => ref.pod))].join(', ')
+            : `<span style="color: var(--gray-500);">${t('Nenhum pod')}</span>`;
+
+        document.getElementById('configmaps-table-body').innerHTML = configmaps.length ? configmaps.map(cm => `
+             <tr>
+                <td>${cm.namespace}</td>
+                <td><b>${cm.name}</b></td>
+                <td style="font-family: monospace;">${cm.keys.map(k => k.name).join(', ')}</td>
+                <td style="font-family: monospace;">${formatSize(cm.totalSize)}</td>
+                <td style="font-size: 0.8rem;">${formatUsage(cm.usedBy)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="5" style="text-align: center; padding: 2rem;">${t('Nenhum ConfigMap encontrado.')}</td></tr>`;
+
+        document.getElementById('secrets-table-body').innerHTML = secrets.length ? secrets.map(secret => `
+             <tr>
+                <td>${secret.namespace}</td>
+                <td><b>${secret.name}</b></td>
+                <td style="font-family: monospace;">${secret.type}</td>
+                <td style="font-family: monospace;">${secret.keys.map(k => k.name).join(', ')}</td>
+                <td style="font-family: monospace;">${formatSize(secret.totalSize)}</td>
+                <td style="font-size: 0.8rem;">${formatUsage(secret.usedBy)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="6" style="text-align: center; padding: 2rem;">${t('Nenhum Secret encontrado.')}</td></tr>`;
+    }
+
+    renderDiskUsageView(report) {
+        if (!report || !report.nodes) return;
+        const usageBadge = (fs) => {
+            if (!fs) return '-';
+            const cls = fs.usagePercentage >= 90 ? 'status-failed' : fs.usagePercentage >= 75 ? 'status-pending' : 'status-running';
+            return `<span class="status-badge ${cls}">${this.formatFsUsage(fs)}</span>`;
+        };
+
+        document.getElementById('disk-nodes-table-body').innerHTML = report.nodes.length ? report.nodes.map(node => `
+             <tr>
+                <td><b>${node.node}</b></td>
+                <td>${node.diskPressure ? '<span class="status-badge status-failed">DiskPressure</span>' : '<span class="status-badge status-running">OK</span>'}</td>
+                <td>${node.statsAvailable ? usageBadge(node.filesystem) : t('Estatísticas indisponíveis')}</td>
+                <td>${usageBadge(node.imageFilesystem)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="4" style="text-align: center; padding: 2rem;">${t('Nenhum nó encontrado.')}</td></tr>`;
+
+        document.getElementById('disk-volumes-table-body').innerHTML = report.volumes.length ? report.volumes.map(volume => `
+             <tr>
+                <td>${volume.namespace}</td>
+                <td><b>${volume.pod}</b></td>
+                <td style="font-family: monospace;">${volume.volume}</td>
+                <td style="font-family: monospace;">${volume.pvc || '-'}</td>
+                <td>${usageBadge(volume.usage)}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="5" style="text-align: center; padding: 2rem;">${t('Nenhum volume com estatísticas.')}</td></tr>`;
+
+        document.getElementById('disk-containers-table-body').innerHTML = report.containers.length ? report.containers.map(container => `
+             <tr>
+                <td>${container.namespace}</td>
+                <td><b>${container.pod}</b></td>
+                <td style="font-family: monospace;">${container.container}</td>
+                <td style="font-family: monospace;">${container.rootfs ? this.formatBytes(container.rootfs.usedBytes) : '-'}</td>
+                <td style="font-family: monospace;">${container.logs ? this.formatBytes(container.logs.usedBytes) : '-'}</td>
+            </tr>`
+        ).join('') : `<tr><td colspan="5" style="text-align: center; padding: 2rem;">${t('Nenhum contêiner com estatísticas.')}</td></tr>`;
+    }
+
+    renderRightsizingView(report) {
+        if (!report || !report.workloads) return;
+        const badges = {
+            'under-provisioned': 'status-failed',
+            'over-provisioned': 'status-pending',
+            'no-request': 'status-pending',
+            'ok': 'status-running',
+            'insufficient-data': 'status-unknown'
+        };
+        const labels = {
+            'under-provisioned': 'Subdimensionado',
+            'over-provisioned': 'Superdimensionado',
+            'no-request': 'Sem request',
+            'ok': 'Adequado',
+            'insufficient-data': 'Dados insuficientes'
+        };
+        const badge = (status) => `<span class="status-badge ${badges[status]}">${t(labels[status])}</span>`;
+        const formatCPU = (milli) => `${milli}m`;
+        // Mostra "p95 / request → recomendado" e, quando houver, o limit sugerido
+        const formatResource = (rec, format) => {
+            const request = rec.request ? format(rec.request) : '-';
+            const recommended = rec.recommendedRequest ? ` → <b>${rec.recommendedRequest}</b>` : '';
+            const limit = rec.recommendedLimit ? ` <span style="font-size: 0.75rem;">(${t('limit {value}', { value: rec.recommendedLimit })})</span>` : '';
+            return `${badge(rec.status)} <span style="font-family: monospace;">${format(rec.p95)} / ${request}${recommended}${limit}</span>`;
+        };
+
+        document.getElementById('rightsizing-window').innerText = t('Janela de {window}; mínimo de {samples} amostras por contêiner.', { window: report.window, samples: report.minSamples });
+        const rows = report.workloads.flatMap(workload => workload.containers.map((container, index) => `
+             <tr>
+                <td>${index === 0 ? badge(workload.status) : ''}</td>
+                <td>${index === 0 ? `${workload.kind} <b>${workload.namespace}/${workload.name}</b> (${t('{count} pods', { count: workload.pods })})` : ''}</td>
+                <td style="font-family: monospace;">${container.container}</td>
+                <td>${container.samples}${container.observedWindow ? ` <span style="font-size: 0.75rem;">(${t('em {window}', { window: container.observedWindow })})</span>` : ''}</td>
+                <td>${formatResource(container.cpu, formatCPU)}</td>
+                <td>${formatResource(container.memory, (bytes) => this.formatBytes(bytes))}</td>
             </tr>`
-        ).join('') : '<tr><td colspan="4" style="text-align: center; padding: 2rem;">Nenhum PVC encontrado.</td></tr>';
+        ));
+        document.getElementById('rightsizing-table-body').innerHTML = rows.length ? rows.join('') : `<tr><td colspan="6" style="text-align: center; padding: 2rem;">${t('Nenhum workload com pods ativos.')}</td></tr>`;
     }
 }